@@ -0,0 +1,3 @@
+data "mssqlpermissions_login" "example" {
+  name = "my-server-login"
+}
@@ -0,0 +1,35 @@
+terraform {
+
+  required_version = ">= 1.0"
+
+  required_providers {
+    mssqlpermissions = {
+      source  = "WeAreRetail/mssqlpermissions"
+      version = ">= 0.0.5"
+    }
+  }
+}
+
+provider "mssqlpermissions" {
+  server_fqdn   = "mssql-fixture"
+  server_port   = 1433
+  database_name = "ApplicationDB"
+
+  sql_login = {
+    username = "sa"
+    password = "P@ssw0rd"
+  }
+}
+
+output "example" {
+  value = {
+    name             = data.mssqlpermissions_login.example.name
+    external         = data.mssqlpermissions_login.example.external
+    principal_id     = data.mssqlpermissions_login.example.principal_id
+    check_policy     = data.mssqlpermissions_login.example.check_policy
+    check_expiration = data.mssqlpermissions_login.example.check_expiration
+    default_database = data.mssqlpermissions_login.example.default_database
+    default_language = data.mssqlpermissions_login.example.default_language
+    sid              = data.mssqlpermissions_login.example.sid
+  }
+}
@@ -0,0 +1,9 @@
+data "mssqlpermissions_preflight_checks" "example" {}
+
+# Fail the plan with the list of missing grants instead of failing halfway through the apply.
+check "provider_permissions" {
+  assert {
+    condition     = data.mssqlpermissions_preflight_checks.example.passed
+    error_message = join("\n", data.mssqlpermissions_preflight_checks.example.missing_permissions)
+  }
+}
@@ -0,0 +1,31 @@
+terraform {
+
+  required_version = ">= 1.0"
+
+  required_providers {
+    mssqlpermissions = {
+      source  = "WeAreRetail/mssqlpermissions"
+      version = ">= 0.0.5"
+    }
+  }
+}
+
+provider "mssqlpermissions" {
+  server_fqdn   = "mssql-fixture"
+  server_port   = 1433
+  database_name = "ApplicationDB"
+
+  sql_login = {
+    username = "sa"
+    password = "P@ssw0rd"
+  }
+}
+
+output "example" {
+  value = {
+    database_name       = data.mssqlpermissions_preflight_checks.example.database_name
+    checks              = data.mssqlpermissions_preflight_checks.example.checks
+    missing_permissions = data.mssqlpermissions_preflight_checks.example.missing_permissions
+    passed              = data.mssqlpermissions_preflight_checks.example.passed
+  }
+}
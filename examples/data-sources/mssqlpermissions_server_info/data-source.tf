@@ -0,0 +1,8 @@
+data "mssqlpermissions_server_info" "example" {}
+
+# Only set a default language where the database supports it.
+resource "mssqlpermissions_user" "example" {
+  name             = "my-contained-user"
+  password         = "P@ssw0rd1234!"
+  default_language = data.mssqlpermissions_server_info.example.default_language_supported ? "French" : null
+}
@@ -0,0 +1,40 @@
+terraform {
+
+  required_version = ">= 1.0"
+
+  required_providers {
+    mssqlpermissions = {
+      source  = "WeAreRetail/mssqlpermissions"
+      version = ">= 0.0.5"
+    }
+  }
+}
+
+provider "mssqlpermissions" {
+  server_fqdn   = "mssql-fixture"
+  server_port   = 1433
+  database_name = "ApplicationDB"
+
+  sql_login = {
+    username = "sa"
+    password = "P@ssw0rd"
+  }
+}
+
+output "example" {
+  value = {
+    database_name              = data.mssqlpermissions_server_info.example.database_name
+    product_version            = data.mssqlpermissions_server_info.example.product_version
+    major_version              = data.mssqlpermissions_server_info.example.major_version
+    edition                    = data.mssqlpermissions_server_info.example.edition
+    engine_edition_name        = data.mssqlpermissions_server_info.example.engine_edition_name
+    is_azure                   = data.mssqlpermissions_server_info.example.is_azure
+    containment                = data.mssqlpermissions_server_info.example.containment
+    compatibility_level        = data.mssqlpermissions_server_info.example.compatibility_level
+    collation                  = data.mssqlpermissions_server_info.example.collation
+    default_language           = data.mssqlpermissions_server_info.example.default_language
+    default_language_supported = data.mssqlpermissions_server_info.example.default_language_supported
+    login_name                 = data.mssqlpermissions_server_info.example.login_name
+    user_name                  = data.mssqlpermissions_server_info.example.user_name
+  }
+}
@@ -0,0 +1,21 @@
+# Generate the password of a user without storing it anywhere in state (Terraform 1.11 or later).
+ephemeral "mssqlpermissions_password" "app_user" {
+  length    = 32
+  user_name = "my-app-user"
+}
+
+resource "mssqlpermissions_user" "app_user" {
+  name             = "my-app-user"
+  password_wo      = ephemeral.mssqlpermissions_password.app_user.result
+  rotation_trigger = "2026-Q4"
+  rotate_after     = "2160h"
+}
+
+# Hand the same password over to the application through a write-only attribute of a secret store,
+# versioned by the rotation of the user.
+resource "azurerm_key_vault_secret" "app_user_password" {
+  name             = "app-user-password"
+  key_vault_id     = var.key_vault_id
+  value_wo         = ephemeral.mssqlpermissions_password.app_user.result
+  value_wo_version = parseint(formatdate("YYYYMMDDhhmmss", mssqlpermissions_user.app_user.password_rotated_at), 10)
+}
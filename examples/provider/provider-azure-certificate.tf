@@ -0,0 +1,29 @@
+# Example provider configuration for Azure SQL Database using Service Principal certificate authentication
+provider "mssqlpermissions" {
+  server_fqdn   = "myserver.database.windows.net"
+  server_port   = 1433
+  database_name = "ApplicationDB"
+
+  spn_login = {
+    client_id                   = var.azure_client_id
+    tenant_id                   = var.azure_tenant_id
+    client_certificate_path     = "/secrets/terraform-spn.pfx"
+    client_certificate_password = var.azure_client_certificate_password
+  }
+}
+
+variable "azure_client_id" {
+  description = "Azure AD application client ID"
+  type        = string
+}
+
+variable "azure_tenant_id" {
+  description = "Azure AD tenant ID"
+  type        = string
+}
+
+variable "azure_client_certificate_password" {
+  description = "Password protecting the Azure AD application client certificate"
+  type        = string
+  sensitive   = true
+}
@@ -0,0 +1,18 @@
+# Example provider configuration for local runs using the Azure CLI identity
+provider "mssqlpermissions" {
+  server_fqdn   = "myserver.database.windows.net"
+  server_port   = 1433
+  database_name = "ApplicationDB"
+
+  entra_login = {
+    method = "ActiveDirectoryAzCli"
+  }
+}
+
+# Other Entra ID methods take the attributes they need, for example:
+# entra_login = {
+#   method    = "ActiveDirectoryPassword"
+#   client_id = var.azure_client_id
+#   username  = var.azure_username
+#   password  = var.azure_password
+# }
@@ -0,0 +1,9 @@
+# Example provider configuration read entirely from the environment.
+# The authentication method is inferred from the variables present, e.g.:
+#
+#   export MSSQL_SERVER_FQDN="myserver.database.windows.net"
+#   export MSSQL_DATABASE="ApplicationDB"
+#   export ARM_CLIENT_ID="00000000-0000-0000-0000-000000000000"
+#   export ARM_TENANT_ID="00000000-0000-0000-0000-000000000000"
+#   export ARM_CLIENT_SECRET="..."
+provider "mssqlpermissions" {}
@@ -0,0 +1,22 @@
+# Example provider configuration for Azure SQL Database using workload identity federation (e.g. GitHub Actions OIDC)
+provider "mssqlpermissions" {
+  server_fqdn   = "myserver.database.windows.net"
+  server_port   = 1433
+  database_name = "ApplicationDB"
+
+  federated_login = {
+    client_id            = var.azure_client_id
+    tenant_id            = var.azure_tenant_id
+    oidc_token_file_path = "/var/run/secrets/azure/tokens/azure-identity-token"
+  }
+}
+
+variable "azure_client_id" {
+  description = "Client ID of the Azure AD application trusting the OIDC issuer"
+  type        = string
+}
+
+variable "azure_tenant_id" {
+  description = "Azure AD tenant ID"
+  type        = string
+}
@@ -0,0 +1,30 @@
+# Example provider configuration for an on-premises SQL Server behind a private endpoint with custom DNS,
+# whose certificate is signed by an internal certificate authority
+provider "mssqlpermissions" {
+  server_fqdn   = "sql01.corp.example.com"
+  server_port   = 1433
+  database_name = "ApplicationDB"
+
+  sql_login = {
+    username = var.sql_username
+    password = var.sql_password
+  }
+
+  tls = {
+    encrypt                  = "strict"
+    ca_certificate_path      = "/etc/ssl/certs/corp-root-ca.pem"
+    host_name_in_certificate = "sql01.database.corp.example.com"
+    min_tls_version          = "1.2"
+  }
+}
+
+variable "sql_username" {
+  description = "SQL Server login username"
+  type        = string
+}
+
+variable "sql_password" {
+  description = "SQL Server login password"
+  type        = string
+  sensitive   = true
+}
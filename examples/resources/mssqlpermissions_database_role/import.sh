@@ -0,0 +1,4 @@
+# Database roles can be imported using their name, their principal id or their SID in hexadecimal,
+# optionally prefixed by the database and a colon.
+terraform import mssqlpermissions_database_role.role my-database-role
+terraform import mssqlpermissions_database_role.role ApplicationDB:16384
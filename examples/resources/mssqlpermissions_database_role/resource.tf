@@ -10,3 +10,9 @@ resource "mssqlpermissions_database_role_members" "role_members" {
     "fixtureTwo",
   ]
 }
+
+# Manage a role in another database of the same server, reusing the provider authentication
+resource "mssqlpermissions_database_role" "reporting_role" {
+  name          = "my-database-role"
+  database_name = "ReportingDB"
+}
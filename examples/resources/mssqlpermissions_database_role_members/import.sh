@@ -0,0 +1,3 @@
+# Role members can be imported using the role name, optionally prefixed by the database and a colon.
+# Every member of the role is adopted, in name order.
+terraform import mssqlpermissions_database_role_members.role my-database-role
@@ -0,0 +1,2 @@
+# Server logins can be imported using their name. The password of a SQL login cannot be read back.
+terraform import mssqlpermissions_login.login_resource my-server-login
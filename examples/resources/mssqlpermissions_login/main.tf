@@ -0,0 +1,48 @@
+terraform {
+
+  required_version = ">= 1.0"
+
+  required_providers {
+    mssqlpermissions = {
+      source  = "WeAreRetail/mssqlpermissions"
+      version = ">= 0.0.5"
+    }
+  }
+}
+
+provider "mssqlpermissions" {
+  server_fqdn   = "mssql-fixture"
+  server_port   = 1433
+  database_name = "ApplicationDB"
+
+  sql_login = {
+    username = "sa"
+    password = "P@ssw0rd"
+  }
+}
+
+resource "mssqlpermissions_login" "login_resource" {
+  name             = "my-server-login"
+  password         = "P@ssw0rd!"
+  check_policy     = true
+  check_expiration = false
+  default_database = "ApplicationDB"
+}
+
+resource "mssqlpermissions_user" "login_user" {
+  name       = "my-login-user"
+  login_name = mssqlpermissions_login.login_resource.name
+}
+
+output "login" {
+  value = {
+    name             = mssqlpermissions_login.login_resource.name
+    principal_id     = mssqlpermissions_login.login_resource.principal_id
+    check_policy     = mssqlpermissions_login.login_resource.check_policy
+    check_expiration = mssqlpermissions_login.login_resource.check_expiration
+    default_database = mssqlpermissions_login.login_resource.default_database
+    default_language = mssqlpermissions_login.login_resource.default_language
+    sid              = mssqlpermissions_login.login_resource.sid
+  }
+  sensitive = true # Because it references a resource with sensitive attributes
+}
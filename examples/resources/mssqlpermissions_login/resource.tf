@@ -0,0 +1,13 @@
+resource "mssqlpermissions_login" "login_resource" {
+  name             = "my-server-login"
+  password         = "P@ssw0rd!"
+  check_policy     = true
+  check_expiration = false
+  default_database = "ApplicationDB"
+}
+
+# On Azure SQL Managed Instance and Azure SQL Database, create a login for a Microsoft Entra ID principal
+resource "mssqlpermissions_login" "entra_login" {
+  name     = "my-entra-group"
+  external = true
+}
@@ -0,0 +1,6 @@
+# Database permissions can be imported using the role name, optionally prefixed by the database and a colon,
+# to adopt every permission granted or denied to the role on the database, in permission name order.
+terraform import mssqlpermissions_permissions_to_role.permissions another-database-role
+
+# Add a slash and a comma-separated list of permissions to only adopt those.
+terraform import mssqlpermissions_permissions_to_role.permissions ApplicationDB:another-database-role/SELECT,INSERT
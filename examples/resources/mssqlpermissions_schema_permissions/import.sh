@@ -0,0 +1,3 @@
+# Schema permissions can be imported using the schema and role names separated by a slash, optionally prefixed
+# by the database and a colon. Every permission granted or denied to the role on the schema is adopted.
+terraform import mssqlpermissions_schema_permissions.sales_permissions sales/schema_permissions_example_role
@@ -0,0 +1,6 @@
+# Database users can be imported using their name, their principal id or their SID in hexadecimal,
+# optionally prefixed by the database and a colon. The password of a contained user cannot be read back:
+# the first apply after the import sets the configured password again, or password_wo when its version is set.
+terraform import mssqlpermissions_user.user_resource my-second-tf-user
+terraform import mssqlpermissions_user.user_resource 7
+terraform import mssqlpermissions_user.external_user ApplicationDB:0x1F6A4B5C7D8E9F001122334455667788
@@ -3,3 +3,40 @@ resource "mssqlpermissions_user" "user_resource" {
   password = "P@ssw0rd!"
   external = false
 }
+
+# Keep the password out of the plan and state with a write-only attribute (Terraform 1.11 or later).
+# The password is only set again when password_wo_version changes.
+resource "mssqlpermissions_user" "write_only_user" {
+  name                = "my-write-only-user"
+  password_wo         = var.app_user_password
+  password_wo_version = 1
+}
+
+# Generate the password instead, and rotate it every 90 days or when the trigger changes.
+ephemeral "mssqlpermissions_password" "generated_user" {
+  user_name = "my-generated-user"
+}
+
+resource "mssqlpermissions_user" "generated_user" {
+  name             = "my-generated-user"
+  password_wo      = ephemeral.mssqlpermissions_password.generated_user.result
+  rotation_trigger = "2026-Q4"
+  rotate_after     = "2160h"
+}
+
+# On a database without contained database authentication, map the user to an existing server login
+resource "mssqlpermissions_user" "login_user" {
+  name       = "my-login-user"
+  login_name = "my-server-login"
+}
+
+# Allow more time for the Entra ID lookup of an external user on a busy server
+resource "mssqlpermissions_user" "external_user" {
+  name     = "my-entra-group"
+  external = true
+
+  timeouts {
+    create = "45m"
+    read   = "10m"
+  }
+}
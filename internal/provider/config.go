@@ -9,11 +9,16 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"terraform-provider-mssqlpermissions/internal/provider/model"
 	"terraform-provider-mssqlpermissions/internal/queries"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	providerSchema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
@@ -22,19 +27,45 @@ import (
 func getProviderConfigSchema() map[string]providerSchema.Attribute {
 	return map[string]providerSchema.Attribute{
 		"server_fqdn": providerSchema.StringAttribute{
-			Description:         "The SQL Server FQDN.",
-			MarkdownDescription: "The SQL Server FQDN.",
-			Required:            true,
+			Description:         "The SQL Server FQDN. Can also be set with the MSSQL_SERVER_FQDN environment variable.",
+			MarkdownDescription: "The SQL Server FQDN. Can also be set with the `MSSQL_SERVER_FQDN` environment variable.",
+			Optional:            true,
 		},
 		"server_port": providerSchema.Int64Attribute{
-			Description:         "The SQL Server port.",
-			MarkdownDescription: "The SQL Server port.",
+			Description:         "The SQL Server port. Defaults to 1433, or to the port resolved by the SQL Server Browser service when instance_name is set. Can also be set with the MSSQL_PORT environment variable.",
+			MarkdownDescription: "The SQL Server port. Defaults to `1433`, or to the port resolved by the SQL Server Browser service when `instance_name` is set. Can also be set with the `MSSQL_PORT` environment variable.",
+			Optional:            true,
+		},
+		"instance_name": providerSchema.StringAttribute{
+			Description:         "The SQL Server named instance. Its port is resolved by the SQL Server Browser service unless server_port is set. Can also be set with the MSSQL_INSTANCE_NAME environment variable.",
+			MarkdownDescription: "The SQL Server named instance. Its port is resolved by the SQL Server Browser service unless `server_port` is set. Can also be set with the `MSSQL_INSTANCE_NAME` environment variable.",
+			Optional:            true,
+		},
+		"failover_partner": providerSchema.StringAttribute{
+			Description:         "The database mirroring failover partner, as host or host:port, connected to when the server_fqdn is not available. The same instance_name is used on both servers. Can also be set with the MSSQL_FAILOVER_PARTNER environment variable.",
+			MarkdownDescription: "The database mirroring failover partner, as `host` or `host:port`, connected to when the `server_fqdn` is not available. The same `instance_name` is used on both servers. Can also be set with the `MSSQL_FAILOVER_PARTNER` environment variable.",
+			Optional:            true,
+		},
+		"multi_subnet_failover": providerSchema.BoolAttribute{
+			Description:         "Connect in parallel to every IP address of the server_fqdn, for availability group listeners spanning several subnets. Defaults to true. Can also be set with the MSSQL_MULTI_SUBNET_FAILOVER environment variable.",
+			MarkdownDescription: "Connect in parallel to every IP address of the `server_fqdn`, for availability group listeners spanning several subnets. Defaults to `true`. Can also be set with the `MSSQL_MULTI_SUBNET_FAILOVER` environment variable.",
+			Optional:            true,
+		},
+		"read_only_intent_for_data_sources": providerSchema.BoolAttribute{
+			Description:         "Connect the data sources with a read-only application intent, so that an availability group listener routes them to a readable secondary replica. Resources always connect with a read-write intent. Defaults to false. Can also be set with the MSSQL_READ_ONLY_INTENT_FOR_DATA_SOURCES environment variable.",
+			MarkdownDescription: "Connect the data sources with a read-only application intent, so that an availability group listener routes them to a readable secondary replica. Resources always connect with a read-write intent. Defaults to `false`. Can also be set with the `MSSQL_READ_ONLY_INTENT_FOR_DATA_SOURCES` environment variable.",
 			Optional:            true,
 		},
 		"database_name": providerSchema.StringAttribute{
-			Description:         "The SQL Server database name.",
-			MarkdownDescription: "The SQL Server database name.",
-			Required:            true,
+			Description:         "The SQL Server database name, used by the resources and data sources that don't set their own database_name. Can also be set with the MSSQL_DATABASE environment variable.",
+			MarkdownDescription: "The SQL Server database name, used by the resources and data sources that don't set their own `database_name`. Can also be set with the `MSSQL_DATABASE` environment variable.",
+			Optional:            true,
+		},
+		"connection_string": providerSchema.StringAttribute{
+			Description:         "An ADO.NET connection string (e.g. server=host,port;database=name;user id=user;password=secret), or an ODBC one prefixed by odbc: (e.g. odbc:server=host;database=name;user id=user;password={p;ss}), as an alternative to the structured connection settings. Values holding a semicolon or an equal sign are quoted with braces in ODBC strings, and with single or double quotes in ADO.NET strings. Only the keys go-mssqldb understands and the provider can apply are accepted: server, port, database, user id, password, fedauth and its keys, the TLS keys, the failover keys, dial timeout, app name and workstation id. Conflicts with server_fqdn, server_port, instance_name, database_name, failover_partner, multi_subnet_failover, tls, the login blocks and the access tokens. Can also be set with the MSSQL_CONNECTION_STRING environment variable, in which case the environment variables of the structured settings are ignored.",
+			MarkdownDescription: "An ADO.NET connection string (e.g. `server=host,port;database=name;user id=user;password=secret`), or an ODBC one prefixed by `odbc:` (e.g. `odbc:server=host;database=name;user id=user;password={p;ss}`), as an alternative to the structured connection settings. Values holding a semicolon or an equal sign are quoted with braces in ODBC strings, and with single or double quotes in ADO.NET strings. Only the keys go-mssqldb understands and the provider can apply are accepted: `server`, `port`, `database`, `user id`, `password`, `fedauth` and its keys, the TLS keys, the failover keys, `dial timeout`, `app name` and `workstation id`. Conflicts with `server_fqdn`, `server_port`, `instance_name`, `database_name`, `failover_partner`, `multi_subnet_failover`, `tls`, the login blocks and the access tokens. Can also be set with the `MSSQL_CONNECTION_STRING` environment variable, in which case the environment variables of the structured settings are ignored.",
+			Optional:            true,
+			Sensitive:           true,
 		},
 		"sql_login": providerSchema.SingleNestedAttribute{
 			Description:         "The SQL Server login configuration. Use to connect to the Database using SQL Authentication.",
@@ -42,16 +73,27 @@ func getProviderConfigSchema() map[string]providerSchema.Attribute {
 			Optional:            true,
 			Attributes: map[string]providerSchema.Attribute{
 				"username": providerSchema.StringAttribute{
-					Description:         "The SQL Server login username.",
-					MarkdownDescription: "The SQL Server login username.",
-					Required:            true,
+					Description:         "The SQL Server login username. Can also be set with the MSSQL_SQL_USERNAME environment variable.",
+					MarkdownDescription: "The SQL Server login username. Can also be set with the `MSSQL_SQL_USERNAME` environment variable.",
+					Optional:            true,
 				},
 				"password": providerSchema.StringAttribute{
-					Description:         "The SQL Server login password.",
-					MarkdownDescription: "The SQL Server login password.",
-					Required:            true,
+					Description:         "The SQL Server login password. Conflicts with password_file and password_command. Can also be set with the MSSQL_SQL_PASSWORD environment variable.",
+					MarkdownDescription: "The SQL Server login password. Conflicts with `password_file` and `password_command`. Can also be set with the `MSSQL_SQL_PASSWORD` environment variable.",
+					Optional:            true,
 					Sensitive:           true,
 				},
+				"password_file": providerSchema.StringAttribute{
+					Description:         "The path to a file holding the SQL Server login password, such as a mounted Kubernetes secret. The file is read again for every new connection, and a trailing line break is ignored. Conflicts with password and password_command. Can also be set with the MSSQL_SQL_PASSWORD_FILE environment variable.",
+					MarkdownDescription: "The path to a file holding the SQL Server login password, such as a mounted Kubernetes secret. The file is read again for every new connection, and a trailing line break is ignored. Conflicts with `password` and `password_command`. Can also be set with the `MSSQL_SQL_PASSWORD_FILE` environment variable.",
+					Optional:            true,
+				},
+				"password_command": providerSchema.ListAttribute{
+					Description:         "A command printing the SQL Server login password on its standard output, given as the program followed by its arguments (e.g. [\"vault\", \"kv\", \"get\", \"-field=password\", \"secret/sql\"]). The command is run without a shell for every new connection, and a trailing line break is ignored. Conflicts with password and password_file.",
+					MarkdownDescription: "A command printing the SQL Server login password on its standard output, given as the program followed by its arguments (e.g. `[\"vault\", \"kv\", \"get\", \"-field=password\", \"secret/sql\"]`). The command is run without a shell for every new connection, and a trailing line break is ignored. Conflicts with `password` and `password_file`.",
+					ElementType:         types.StringType,
+					Optional:            true,
+				},
 			},
 		},
 		"spn_login": providerSchema.SingleNestedAttribute{
@@ -60,20 +102,48 @@ func getProviderConfigSchema() map[string]providerSchema.Attribute {
 			Optional:            true,
 			Attributes: map[string]providerSchema.Attribute{
 				"client_id": providerSchema.StringAttribute{
-					Description:         "The Azure AD application client ID.",
-					MarkdownDescription: "The Azure AD application client ID.",
-					Required:            true,
+					Description:         "The Azure AD application client ID. Can also be set with the ARM_CLIENT_ID environment variable.",
+					MarkdownDescription: "The Azure AD application client ID. Can also be set with the `ARM_CLIENT_ID` environment variable.",
+					Optional:            true,
 				},
 				"client_secret": providerSchema.StringAttribute{
-					Description:         "The Azure AD application client secret.",
-					MarkdownDescription: "The Azure AD application client secret.",
-					Required:            true,
+					Description:         "The Azure AD application client secret. Conflicts with client_secret_file, client_secret_command, client_certificate_path and client_certificate. Can also be set with the ARM_CLIENT_SECRET environment variable.",
+					MarkdownDescription: "The Azure AD application client secret. Conflicts with `client_secret_file`, `client_secret_command`, `client_certificate_path` and `client_certificate`. Can also be set with the `ARM_CLIENT_SECRET` environment variable.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"client_secret_file": providerSchema.StringAttribute{
+					Description:         "The path to a file holding the Azure AD application client secret. The file is read again for every new connection, and a trailing line break is ignored. Conflicts with client_secret, client_secret_command, client_certificate_path and client_certificate. Can also be set with the ARM_CLIENT_SECRET_FILE_PATH environment variable.",
+					MarkdownDescription: "The path to a file holding the Azure AD application client secret. The file is read again for every new connection, and a trailing line break is ignored. Conflicts with `client_secret`, `client_secret_command`, `client_certificate_path` and `client_certificate`. Can also be set with the `ARM_CLIENT_SECRET_FILE_PATH` environment variable.",
+					Optional:            true,
+				},
+				"client_secret_command": providerSchema.ListAttribute{
+					Description:         "A command printing the Azure AD application client secret on its standard output, given as the program followed by its arguments. The command is run without a shell for every new connection, and a trailing line break is ignored. Conflicts with client_secret, client_secret_file, client_certificate_path and client_certificate.",
+					MarkdownDescription: "A command printing the Azure AD application client secret on its standard output, given as the program followed by its arguments. The command is run without a shell for every new connection, and a trailing line break is ignored. Conflicts with `client_secret`, `client_secret_file`, `client_certificate_path` and `client_certificate`.",
+					ElementType:         types.StringType,
+					Optional:            true,
+				},
+				"client_certificate_path": providerSchema.StringAttribute{
+					Description:         "The path to a PFX or PEM file holding the Azure AD application client certificate and its private key. Conflicts with client_secret and client_certificate. Can also be set with the ARM_CLIENT_CERTIFICATE_PATH environment variable.",
+					MarkdownDescription: "The path to a PFX or PEM file holding the Azure AD application client certificate and its private key. Conflicts with `client_secret` and `client_certificate`. Can also be set with the `ARM_CLIENT_CERTIFICATE_PATH` environment variable.",
+					Optional:            true,
+				},
+				"client_certificate": providerSchema.StringAttribute{
+					Description:         "The base64-encoded PFX or PEM Azure AD application client certificate and its private key. Conflicts with client_secret and client_certificate_path. Can also be set with the ARM_CLIENT_CERTIFICATE environment variable.",
+					MarkdownDescription: "The base64-encoded PFX or PEM Azure AD application client certificate and its private key. Conflicts with `client_secret` and `client_certificate_path`. Can also be set with the `ARM_CLIENT_CERTIFICATE` environment variable.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"client_certificate_password": providerSchema.StringAttribute{
+					Description:         "The password protecting the client certificate, if any. Can also be set with the ARM_CLIENT_CERTIFICATE_PASSWORD environment variable.",
+					MarkdownDescription: "The password protecting the client certificate, if any. Can also be set with the `ARM_CLIENT_CERTIFICATE_PASSWORD` environment variable.",
+					Optional:            true,
 					Sensitive:           true,
 				},
 				"tenant_id": providerSchema.StringAttribute{
-					Description:         "The Azure AD tenant ID.",
-					MarkdownDescription: "The Azure AD tenant ID.",
-					Required:            true,
+					Description:         "The Azure AD tenant ID. Can also be set with the ARM_TENANT_ID environment variable.",
+					MarkdownDescription: "The Azure AD tenant ID. Can also be set with the `ARM_TENANT_ID` environment variable.",
+					Optional:            true,
 				},
 			},
 		},
@@ -83,27 +153,215 @@ func getProviderConfigSchema() map[string]providerSchema.Attribute {
 			Optional:            true,
 			Attributes: map[string]providerSchema.Attribute{
 				"user_identity": providerSchema.BoolAttribute{
-					Description:         "Use the user identity.",
-					MarkdownDescription: "Use the user identity.",
-					Required:            true,
+					Description:         "Use the user identity. Defaults to true when user_id or resource_id is set.",
+					MarkdownDescription: "Use the user identity. Defaults to `true` when `user_id` or `resource_id` is set.",
+					Optional:            true,
 				},
 				"user_id": providerSchema.StringAttribute{
-					Description:         "The user identity. Required if user_identity is true.",
-					MarkdownDescription: "The user identity. Required if user_identity is true.",
+					Description:         "The user identity. Required if user_identity is true. Can also be set with the ARM_CLIENT_ID environment variable.",
+					MarkdownDescription: "The user identity. Required if user_identity is true. Can also be set with the `ARM_CLIENT_ID` environment variable.",
 					Optional:            true,
 				},
 				"resource_id": providerSchema.StringAttribute{
-					Description:         "The resource identity. Required if user_identity is false.",
-					MarkdownDescription: "The resource identity. Required if user_identity is false.",
+					Description:         "The resource identity. Required if user_identity is false. Can also be set with the ARM_MSI_RESOURCE_ID environment variable.",
+					MarkdownDescription: "The resource identity. Required if user_identity is false. Can also be set with the `ARM_MSI_RESOURCE_ID` environment variable.",
 					Optional:            true,
 				},
 			},
 		},
 		"federated_login": providerSchema.SingleNestedAttribute{
-			Description:         "Connect using a Federated Identity",
-			MarkdownDescription: "Connect using a Federated Identity",
+			Description:         "Connect using a Federated Identity (workload identity federation). The OIDC token issued by the CI platform is exchanged for an Entra ID access token.",
+			MarkdownDescription: "Connect using a Federated Identity (workload identity federation). The OIDC token issued by the CI platform is exchanged for an Entra ID access token.",
+			Optional:            true,
+			Attributes: map[string]providerSchema.Attribute{
+				"client_id": providerSchema.StringAttribute{
+					Description:         "The client ID of the Azure AD application or user-assigned identity trusting the OIDC issuer. Can also be set with the ARM_CLIENT_ID environment variable.",
+					MarkdownDescription: "The client ID of the Azure AD application or user-assigned identity trusting the OIDC issuer. Can also be set with the `ARM_CLIENT_ID` environment variable.",
+					Optional:            true,
+				},
+				"tenant_id": providerSchema.StringAttribute{
+					Description:         "The Azure AD tenant ID. Can also be set with the ARM_TENANT_ID environment variable.",
+					MarkdownDescription: "The Azure AD tenant ID. Can also be set with the `ARM_TENANT_ID` environment variable.",
+					Optional:            true,
+				},
+				"oidc_token": providerSchema.StringAttribute{
+					Description:         "The OIDC token to exchange. Conflicts with oidc_token_file_path. Can also be set with the ARM_OIDC_TOKEN environment variable.",
+					MarkdownDescription: "The OIDC token to exchange. Conflicts with `oidc_token_file_path`. Can also be set with the `ARM_OIDC_TOKEN` environment variable.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"oidc_token_file_path": providerSchema.StringAttribute{
+					Description:         "The path to a file containing the OIDC token to exchange. The file is read again whenever a new access token is needed. Conflicts with oidc_token. Can also be set with the ARM_OIDC_TOKEN_FILE_PATH environment variable.",
+					MarkdownDescription: "The path to a file containing the OIDC token to exchange. The file is read again whenever a new access token is needed. Conflicts with `oidc_token`. Can also be set with the `ARM_OIDC_TOKEN_FILE_PATH` environment variable.",
+					Optional:            true,
+				},
+			},
+		},
+		"entra_login": providerSchema.SingleNestedAttribute{
+			Description: "Connect using Microsoft Entra ID with the selected authentication method. The attributes required depend on the method.",
+			MarkdownDescription: "Connect using Microsoft Entra ID with the selected authentication method. The attributes required depend on the method:\n\n" +
+				"  - `ActiveDirectoryServicePrincipal`, `ActiveDirectoryApplication`: `client_id` and `client_secret`, optionally `tenant_id`.\n" +
+				"  - `ActiveDirectoryPassword`: `client_id`, `username` and `password`.\n" +
+				"  - `ActiveDirectoryManagedIdentity`, `ActiveDirectoryMSI`: optionally `client_id` or `resource_id` of a user-assigned identity.\n" +
+				"  - `ActiveDirectoryInteractive`: `client_id`, optionally `username` as a login hint.\n" +
+				"  - `ActiveDirectoryDeviceCode`: optionally `client_id`.\n" +
+				"  - `ActiveDirectoryDefault`, `ActiveDirectoryAzCli`: none.",
+			Optional: true,
+			Attributes: map[string]providerSchema.Attribute{
+				"method": providerSchema.StringAttribute{
+					Description:         "The Entra ID authentication method. One of " + strings.Join(entraLoginMethodNames(), ", ") + ". Can also be set with the MSSQL_ENTRA_METHOD environment variable.",
+					MarkdownDescription: "The Entra ID authentication method. One of `" + strings.Join(entraLoginMethodNames(), "`, `") + "`. Can also be set with the `MSSQL_ENTRA_METHOD` environment variable.",
+					Optional:            true,
+				},
+				"client_id": providerSchema.StringAttribute{
+					Description:         "The client ID of the Azure AD application or user-assigned managed identity. Can also be set with the ARM_CLIENT_ID environment variable.",
+					MarkdownDescription: "The client ID of the Azure AD application or user-assigned managed identity. Can also be set with the `ARM_CLIENT_ID` environment variable.",
+					Optional:            true,
+				},
+				"tenant_id": providerSchema.StringAttribute{
+					Description:         "The Azure AD tenant ID. Can also be set with the ARM_TENANT_ID environment variable.",
+					MarkdownDescription: "The Azure AD tenant ID. Can also be set with the `ARM_TENANT_ID` environment variable.",
+					Optional:            true,
+				},
+				"client_secret": providerSchema.StringAttribute{
+					Description:         "The Azure AD application client secret. Can also be set with the ARM_CLIENT_SECRET environment variable.",
+					MarkdownDescription: "The Azure AD application client secret. Can also be set with the `ARM_CLIENT_SECRET` environment variable.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"username": providerSchema.StringAttribute{
+					Description:         "The Entra ID user principal name. Can also be set with the MSSQL_ENTRA_USERNAME environment variable.",
+					MarkdownDescription: "The Entra ID user principal name. Can also be set with the `MSSQL_ENTRA_USERNAME` environment variable.",
+					Optional:            true,
+				},
+				"password": providerSchema.StringAttribute{
+					Description:         "The Entra ID user password. Can also be set with the MSSQL_ENTRA_PASSWORD environment variable.",
+					MarkdownDescription: "The Entra ID user password. Can also be set with the `MSSQL_ENTRA_PASSWORD` environment variable.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"resource_id": providerSchema.StringAttribute{
+					Description:         "The resource ID of a user-assigned managed identity. Can also be set with the ARM_MSI_RESOURCE_ID environment variable.",
+					MarkdownDescription: "The resource ID of a user-assigned managed identity. Can also be set with the `ARM_MSI_RESOURCE_ID` environment variable.",
+					Optional:            true,
+				},
+			},
+		},
+		"access_token": providerSchema.StringAttribute{
+			Description:         "A pre-acquired Entra ID access token for the database. Conflicts with access_token_file and the login blocks. Can also be set with the MSSQL_ACCESS_TOKEN environment variable.",
+			MarkdownDescription: "A pre-acquired Entra ID access token for the database. Conflicts with `access_token_file` and the login blocks. Can also be set with the `MSSQL_ACCESS_TOKEN` environment variable.",
+			Optional:            true,
+			Sensitive:           true,
+		},
+		"access_token_file": providerSchema.StringAttribute{
+			Description:         "The path to a file containing a pre-acquired Entra ID access token. The file is read again for every new connection, so the token can be rotated during a long apply. Conflicts with access_token and the login blocks. Can also be set with the MSSQL_ACCESS_TOKEN_FILE environment variable.",
+			MarkdownDescription: "The path to a file containing a pre-acquired Entra ID access token. The file is read again for every new connection, so the token can be rotated during a long apply. Conflicts with `access_token` and the login blocks. Can also be set with the `MSSQL_ACCESS_TOKEN_FILE` environment variable.",
+			Optional:            true,
+		},
+		"execute_as_user": providerSchema.StringAttribute{
+			Description:         "A user of the provider database impersonated by every statement changing users, roles and permissions in it, with EXECUTE AS USER and REVERT. The provider can then connect with a low-privilege identity allowed to impersonate a dedicated user holding the rights. The resources targeting another database_name, and the logins managed in master, run as the connecting principal. Can also be set with the MSSQL_EXECUTE_AS_USER environment variable.",
+			MarkdownDescription: "A user of the provider database impersonated by every statement changing users, roles and permissions in it, with `EXECUTE AS USER` and `REVERT`. The provider can then connect with a low-privilege identity allowed to impersonate a dedicated user holding the rights. The resources targeting another `database_name`, and the logins managed in `master`, run as the connecting principal. Can also be set with the `MSSQL_EXECUTE_AS_USER` environment variable.",
+			Optional:            true,
+		},
+		"application_name": providerSchema.StringAttribute{
+			Description:         "The application name reported by every session, recorded by SQL Audit and Extended Events and shown in sys.dm_exec_sessions. Use it to tell pipelines or workspaces apart. Defaults to terraform-sql-provider. Can also be set with the MSSQL_APPLICATION_NAME environment variable.",
+			MarkdownDescription: "The application name reported by every session, recorded by SQL Audit and Extended Events and shown in `sys.dm_exec_sessions`. Use it to tell pipelines or workspaces apart. Defaults to `terraform-sql-provider`. Can also be set with the `MSSQL_APPLICATION_NAME` environment variable.",
+			Optional:            true,
+		},
+		"workstation_id": providerSchema.StringAttribute{
+			Description:         "The workstation id reported by every session instead of none, recorded by SQL Audit and Extended Events as the host name. Can also be set with the MSSQL_WORKSTATION_ID environment variable.",
+			MarkdownDescription: "The workstation id reported by every session instead of none, recorded by SQL Audit and Extended Events as the host name. Can also be set with the `MSSQL_WORKSTATION_ID` environment variable.",
+			Optional:            true,
+		},
+		"session_context": providerSchema.MapAttribute{
+			Description:         "Values written with sp_set_session_context on every session (e.g. a run id or a git commit), readable with SESSION_CONTEXT(N'key') by audits, triggers and Extended Events to attribute every statement to a Terraform run. Can also be set with the MSSQL_SESSION_CONTEXT environment variable, as a JSON object.",
+			MarkdownDescription: "Values written with `sp_set_session_context` on every session (e.g. a run id or a git commit), readable with `SESSION_CONTEXT(N'key')` by audits, triggers and Extended Events to attribute every statement to a Terraform run. Can also be set with the `MSSQL_SESSION_CONTEXT` environment variable, as a JSON object.",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"preflight_checks": providerSchema.BoolAttribute{
+			Description:         "Check that the principal the provider connects with, or the execute_as_user it impersonates on the provider database, holds the permissions needed by the resources (ALTER ANY USER, CREATE ROLE, ALTER ANY ROLE on the database, ALTER ANY LOGIN on the server, not checked on contained and Azure SQL databases or when master refuses the connection) when the provider is configured, and report the missing ones before any change runs. Defaults to false. Can also be set with the MSSQL_PREFLIGHT_CHECKS environment variable.",
+			MarkdownDescription: "Check that the principal the provider connects with, or the `execute_as_user` it impersonates on the provider database, holds the permissions needed by the resources (`ALTER ANY USER`, `CREATE ROLE`, `ALTER ANY ROLE` on the database, `ALTER ANY LOGIN` on the server, not checked on contained and Azure SQL databases or when `master` refuses the connection) when the provider is configured, and report the missing ones before any change runs. Defaults to `false`. Can also be set with the `MSSQL_PREFLIGHT_CHECKS` environment variable.",
+			Optional:            true,
+		},
+		"preflight_resource_types": providerSchema.SetAttribute{
+			Description:         "The resource types whose permissions preflight_checks checks, such as mssqlpermissions_user. Defaults to every resource type of the provider.",
+			MarkdownDescription: "The resource types whose permissions `preflight_checks` checks, such as `mssqlpermissions_user`. Defaults to every resource type of the provider.",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"max_open_connections": providerSchema.Int64Attribute{
+			Description:         "The maximum number of open connections to the database. Defaults to unlimited. Can also be set with the MSSQL_MAX_OPEN_CONNECTIONS environment variable.",
+			MarkdownDescription: "The maximum number of open connections to the database. Defaults to unlimited. Can also be set with the `MSSQL_MAX_OPEN_CONNECTIONS` environment variable.",
+			Optional:            true,
+		},
+		"max_idle_connections": providerSchema.Int64Attribute{
+			Description:         "The maximum number of idle connections kept in the pool. Defaults to 2. Can also be set with the MSSQL_MAX_IDLE_CONNECTIONS environment variable.",
+			MarkdownDescription: "The maximum number of idle connections kept in the pool. Defaults to `2`. Can also be set with the `MSSQL_MAX_IDLE_CONNECTIONS` environment variable.",
+			Optional:            true,
+		},
+		"connection_max_lifetime": providerSchema.StringAttribute{
+			Description:         "The maximum amount of time a connection may be reused, as a duration string (e.g. 30m). Defaults to no limit. Can also be set with the MSSQL_CONNECTION_MAX_LIFETIME environment variable.",
+			MarkdownDescription: "The maximum amount of time a connection may be reused, as a duration string (e.g. `30m`). Defaults to no limit. Can also be set with the `MSSQL_CONNECTION_MAX_LIFETIME` environment variable.",
+			Optional:            true,
+		},
+		"connect_timeout": providerSchema.StringAttribute{
+			Description:         "The maximum amount of time to open the connection pool and to dial a new connection, as a duration string (e.g. 1m). Defaults to 30s. Can also be set with the MSSQL_CONNECT_TIMEOUT environment variable.",
+			MarkdownDescription: "The maximum amount of time to open the connection pool and to dial a new connection, as a duration string (e.g. `1m`). Defaults to `30s`. Can also be set with the `MSSQL_CONNECT_TIMEOUT` environment variable.",
+			Optional:            true,
+		},
+		"statement_timeout": providerSchema.StringAttribute{
+			Description:         "The maximum amount of time of a single database operation, such as creating a user or granting a set of permissions, as a duration string (e.g. 5m). Defaults to no limit other than the resource timeouts. Can also be set with the MSSQL_STATEMENT_TIMEOUT environment variable.",
+			MarkdownDescription: "The maximum amount of time of a single database operation, such as creating a user or granting a set of permissions, as a duration string (e.g. `5m`). Defaults to no limit other than the resource `timeouts`. Can also be set with the `MSSQL_STATEMENT_TIMEOUT` environment variable.",
 			Optional:            true,
 		},
+		"max_retries": providerSchema.Int64Attribute{
+			Description:         "The maximum number of times a statement failing with a transient error (Azure SQL Database reconfiguration or throttling, deadlock, Entra ID principal not propagated yet) is retried. Set to 0 to disable retries. Defaults to 5. Can also be set with the MSSQL_MAX_RETRIES environment variable.",
+			MarkdownDescription: "The maximum number of times a statement failing with a transient error (Azure SQL Database reconfiguration or throttling, deadlock, Entra ID principal not propagated yet) is retried. Set to `0` to disable retries. Defaults to `5`. Can also be set with the `MSSQL_MAX_RETRIES` environment variable.",
+			Optional:            true,
+		},
+		"retry_min_backoff": providerSchema.StringAttribute{
+			Description:         "The wait before the first retry, as a duration string (e.g. 2s). It doubles for every retry, with a random jitter. Defaults to 1s. Can also be set with the MSSQL_RETRY_MIN_BACKOFF environment variable.",
+			MarkdownDescription: "The wait before the first retry, as a duration string (e.g. `2s`). It doubles for every retry, with a random jitter. Defaults to `1s`. Can also be set with the `MSSQL_RETRY_MIN_BACKOFF` environment variable.",
+			Optional:            true,
+		},
+		"retry_max_backoff": providerSchema.StringAttribute{
+			Description:         "The maximum wait between two retries, as a duration string (e.g. 1m). Defaults to 30s. Can also be set with the MSSQL_RETRY_MAX_BACKOFF environment variable.",
+			MarkdownDescription: "The maximum wait between two retries, as a duration string (e.g. `1m`). Defaults to `30s`. Can also be set with the `MSSQL_RETRY_MAX_BACKOFF` environment variable.",
+			Optional:            true,
+		},
+		"tls": providerSchema.SingleNestedAttribute{
+			Description:         "The encryption settings of the connection. When set, the server certificate is verified unless trust_server_certificate is true.",
+			MarkdownDescription: "The encryption settings of the connection. When set, the server certificate is verified unless `trust_server_certificate` is `true`.",
+			Optional:            true,
+			Attributes: map[string]providerSchema.Attribute{
+				"encrypt": providerSchema.StringAttribute{
+					Description:         "The encryption mode. One of " + strings.Join(encryptionModeNames(), ", ") + ". strict uses TDS 8.0 and always verifies the server certificate. Can also be set with the MSSQL_ENCRYPT environment variable.",
+					MarkdownDescription: "The encryption mode. One of `" + strings.Join(encryptionModeNames(), "`, `") + "`. `strict` uses TDS 8.0 and always verifies the server certificate. Can also be set with the `MSSQL_ENCRYPT` environment variable.",
+					Optional:            true,
+				},
+				"trust_server_certificate": providerSchema.BoolAttribute{
+					Description:         "Skip the verification of the server certificate. Conflicts with ca_certificate_path and the strict encryption mode. Can also be set with the MSSQL_TRUST_SERVER_CERTIFICATE environment variable.",
+					MarkdownDescription: "Skip the verification of the server certificate. Conflicts with `ca_certificate_path` and the `strict` encryption mode. Can also be set with the `MSSQL_TRUST_SERVER_CERTIFICATE` environment variable.",
+					Optional:            true,
+				},
+				"ca_certificate_path": providerSchema.StringAttribute{
+					Description:         "The path to a PEM bundle of the certificate authorities trusted to sign the server certificate, instead of the system roots. Can also be set with the MSSQL_CA_CERTIFICATE_PATH environment variable.",
+					MarkdownDescription: "The path to a PEM bundle of the certificate authorities trusted to sign the server certificate, instead of the system roots. Can also be set with the `MSSQL_CA_CERTIFICATE_PATH` environment variable.",
+					Optional:            true,
+				},
+				"host_name_in_certificate": providerSchema.StringAttribute{
+					Description:         "The host name expected in the server certificate, when it differs from server_fqdn (e.g. behind a private endpoint with custom DNS). Can also be set with the MSSQL_HOST_NAME_IN_CERTIFICATE environment variable.",
+					MarkdownDescription: "The host name expected in the server certificate, when it differs from `server_fqdn` (e.g. behind a private endpoint with custom DNS). Can also be set with the `MSSQL_HOST_NAME_IN_CERTIFICATE` environment variable.",
+					Optional:            true,
+				},
+				"min_tls_version": providerSchema.StringAttribute{
+					Description:         "The minimum TLS version. One of " + strings.Join(queries.TLSVersions, ", ") + ". Can also be set with the MSSQL_MIN_TLS_VERSION environment variable.",
+					MarkdownDescription: "The minimum TLS version. One of `" + strings.Join(queries.TLSVersions, "`, `") + "`. Can also be set with the `MSSQL_MIN_TLS_VERSION` environment variable.",
+					Optional:            true,
+				},
+			},
+		},
 	}
 }
 
@@ -114,11 +372,123 @@ func getConnector(config *model.ConfigModel) (*queries.Connector, diag.Diagnosti
 	var sqlLogin model.SQLLoginModel
 	var spnLogin model.SPNLoginModel
 	var msiLogin model.MSILoginModel
+	var federatedLogin model.FederatedLoginModel
+	var entraLogin model.EntraLoginModel
+	var tls model.TLSModel
 
 	connector := &queries.Connector{
-		Host:     config.ServerFqdn.ValueString(),
-		Port:     int(config.ServerPort.ValueInt64()),
-		Database: config.DatabaseName.ValueString(),
+		Host:                config.ServerFqdn.ValueString(),
+		Port:                int(config.ServerPort.ValueInt64()),
+		Database:            config.DatabaseName.ValueString(),
+		InstanceName:        config.InstanceName.ValueString(),
+		FailoverPartner:     config.FailoverPartner.ValueString(),
+		MultiSubnetFailover: config.MultiSubnetFailover.ValueBoolPointer(),
+	}
+
+	// The connection string replaces the structured settings, which are then all null.
+	if !config.ConnectionString.IsNull() && !config.ConnectionString.IsUnknown() {
+		var err error
+		connector, err = queries.ParseConnectionString(config.ConnectionString.ValueString())
+		if err != nil {
+			var diags diag.Diagnostics
+			diags.AddAttributeError(
+				path.Root("connection_string"),
+				"Invalid Connection String",
+				"The connection_string cannot be used: "+err.Error()+".",
+			)
+			return nil, diags
+		}
+
+		conflicts := []struct {
+			attribute string
+			key       string
+			value     basetypes.StringValue
+			set       bool
+		}{
+			{"connect_timeout", "dial timeout", config.ConnectTimeout, connector.Timeout > 0},
+			{"application_name", "app name", config.ApplicationName, connector.ApplicationName != ""},
+			{"workstation_id", "workstation id", config.WorkstationID, connector.WorkstationID != ""},
+		}
+		for _, conflict := range conflicts {
+			if conflict.set && !conflict.value.IsNull() {
+				var diags diag.Diagnostics
+				diags.AddAttributeError(
+					path.Root(conflict.attribute),
+					"Conflicting Connection Settings",
+					"The "+conflict.attribute+" cannot be set when the connection_string sets "+conflict.key+".",
+				)
+				return nil, diags
+			}
+		}
+	}
+
+	connector.ExecuteAsUser = config.ExecuteAsUser.ValueString()
+	if !config.ApplicationName.IsNull() {
+		connector.ApplicationName = config.ApplicationName.ValueString()
+	}
+	if !config.WorkstationID.IsNull() {
+		connector.WorkstationID = config.WorkstationID.ValueString()
+	}
+	if !config.SessionContext.IsNull() && !config.SessionContext.IsUnknown() {
+		diags := config.SessionContext.ElementsAs(ctx, &connector.SessionContext, false)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+	connector.MaxOpenConns = int(config.MaxOpenConnections.ValueInt64())
+	connector.MaxIdleConns = int(config.MaxIdleConnections.ValueInt64())
+
+	durations := []struct {
+		value     basetypes.StringValue
+		attribute string
+		summary   string
+		example   string
+		target    *time.Duration
+	}{
+		{config.ConnectionMaxLifetime, "connection_max_lifetime", "Invalid Connection Max Lifetime", "30m", &connector.ConnMaxLifetime},
+		{config.ConnectTimeout, "connect_timeout", "Invalid Connect Timeout", "1m", &connector.Timeout},
+		{config.StatementTimeout, "statement_timeout", "Invalid Statement Timeout", "5m", &connector.StatementTimeout},
+		{config.RetryMinBackoff, "retry_min_backoff", "Invalid Retry Min Backoff", "2s", &connector.RetryMinBackoff},
+		{config.RetryMaxBackoff, "retry_max_backoff", "Invalid Retry Max Backoff", "1m", &connector.RetryMaxBackoff},
+	}
+
+	for _, d := range durations {
+		if d.value.IsNull() || d.value.IsUnknown() {
+			continue
+		}
+
+		duration, err := time.ParseDuration(d.value.ValueString())
+		if err == nil && duration < 0 {
+			err = errors.New("duration must not be negative")
+		}
+		if err != nil {
+			var diags diag.Diagnostics
+			diags.AddAttributeError(
+				path.Root(d.attribute),
+				d.summary,
+				"The "+d.attribute+" must be a valid duration (e.g. "+d.example+"): "+err.Error(),
+			)
+			return nil, diags
+		}
+		*d.target = duration
+	}
+
+	if connector.RetryMinBackoff > 0 && connector.RetryMaxBackoff > 0 && connector.RetryMaxBackoff < connector.RetryMinBackoff {
+		var diags diag.Diagnostics
+		diags.AddAttributeError(
+			path.Root("retry_max_backoff"),
+			"Invalid Retry Max Backoff",
+			"The retry_max_backoff must not be shorter than retry_min_backoff.",
+		)
+		return nil, diags
+	}
+
+	// The connector disables retries with a negative value, zero keeps its default.
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		connector.MaxRetries = int(config.MaxRetries.ValueInt64())
+		if connector.MaxRetries == 0 {
+			connector.MaxRetries = -1
+		}
 	}
 
 	if !config.SQLLogin.IsNull() && !config.SQLLogin.IsUnknown() {
@@ -129,8 +499,15 @@ func getConnector(config *model.ConfigModel) (*queries.Connector, diag.Diagnosti
 		}
 
 		connector.LocalUserLogin = &queries.LocalUserLogin{
-			Username: sqlLogin.Username.ValueString(),
-			Password: sqlLogin.Password.ValueString(),
+			Username:     sqlLogin.Username.ValueString(),
+			Password:     sqlLogin.Password.ValueString(),
+			PasswordFile: sqlLogin.PasswordFile.ValueString(),
+		}
+		if !sqlLogin.PasswordCommand.IsNull() {
+			diags := sqlLogin.PasswordCommand.ElementsAs(ctx, &connector.LocalUserLogin.PasswordCommand, false)
+			if diags.HasError() {
+				return nil, diags
+			}
 		}
 	}
 
@@ -143,9 +520,19 @@ func getConnector(config *model.ConfigModel) (*queries.Connector, diag.Diagnosti
 		}
 
 		connector.AzureApplicationLogin = &queries.AzureApplicationLogin{
-			ClientId:     spnLogin.ClientID.ValueString(),
-			ClientSecret: spnLogin.ClientSecret.ValueString(),
-			TenantId:     spnLogin.TenantID.ValueString(),
+			ClientId:                  spnLogin.ClientID.ValueString(),
+			ClientSecret:              spnLogin.ClientSecret.ValueString(),
+			ClientSecretFile:          spnLogin.ClientSecretFile.ValueString(),
+			ClientCertificatePath:     spnLogin.ClientCertificatePath.ValueString(),
+			ClientCertificate:         spnLogin.ClientCertificate.ValueString(),
+			ClientCertificatePassword: spnLogin.ClientCertificatePassword.ValueString(),
+			TenantId:                  spnLogin.TenantID.ValueString(),
+		}
+		if !spnLogin.ClientSecretCommand.IsNull() {
+			diags := spnLogin.ClientSecretCommand.ElementsAs(ctx, &connector.AzureApplicationLogin.ClientSecretCommand, false)
+			if diags.HasError() {
+				return nil, diags
+			}
 		}
 	}
 
@@ -164,5 +551,64 @@ func getConnector(config *model.ConfigModel) (*queries.Connector, diag.Diagnosti
 		}
 	}
 
+	if !config.FederatedLogin.IsNull() && !config.FederatedLogin.IsUnknown() {
+
+		diags := config.FederatedLogin.As(ctx, &federatedLogin, basetypes.ObjectAsOptions{})
+
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		connector.FederatedLogin = &queries.FederatedLogin{
+			ClientId:          federatedLogin.ClientID.ValueString(),
+			TenantId:          federatedLogin.TenantID.ValueString(),
+			OIDCToken:         federatedLogin.OIDCToken.ValueString(),
+			OIDCTokenFilePath: federatedLogin.OIDCTokenFilePath.ValueString(),
+		}
+	}
+
+	if !config.EntraLogin.IsNull() && !config.EntraLogin.IsUnknown() {
+
+		diags := config.EntraLogin.As(ctx, &entraLogin, basetypes.ObjectAsOptions{})
+
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		connector.EntraLogin = &queries.EntraLogin{
+			Method:       queries.FedAuth(entraLogin.Method.ValueString()),
+			ClientId:     entraLogin.ClientID.ValueString(),
+			TenantId:     entraLogin.TenantID.ValueString(),
+			ClientSecret: entraLogin.ClientSecret.ValueString(),
+			Username:     entraLogin.Username.ValueString(),
+			Password:     entraLogin.Password.ValueString(),
+			ResourceId:   entraLogin.ResourceID.ValueString(),
+		}
+	}
+
+	if config.AccessToken.ValueString() != "" || config.AccessTokenFile.ValueString() != "" {
+		connector.AccessTokenLogin = &queries.AccessTokenLogin{
+			Token:         config.AccessToken.ValueString(),
+			TokenFilePath: config.AccessTokenFile.ValueString(),
+		}
+	}
+
+	if !config.TLS.IsNull() && !config.TLS.IsUnknown() {
+
+		diags := config.TLS.As(ctx, &tls, basetypes.ObjectAsOptions{})
+
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		connector.TLS = &queries.TLSConfig{
+			Encrypt:                queries.Encryption(tls.Encrypt.ValueString()),
+			TrustServerCertificate: tls.TrustServerCertificate.ValueBool(),
+			CACertificatePath:      tls.CACertificatePath.ValueString(),
+			HostNameInCertificate:  tls.HostNameInCertificate.ValueString(),
+			MinTLSVersion:          tls.MinTLSVersion.ValueString(),
+		}
+	}
+
 	return connector, nil
 }
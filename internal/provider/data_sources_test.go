@@ -48,7 +48,7 @@ func TestDatabaseRoleDataSource_Schema(t *testing.T) {
 	// Check for required attributes
 	requiredAttrs := []string{
 		"name", "members", "principal_id",
-		"type", "type_description", "owning_principal", "is_fixed_role",
+		"type", "type_description", "owning_principal", "is_fixed_role", "database_name",
 	}
 	for _, attr := range requiredAttrs {
 		if _, exists := resp.Schema.Attributes[attr]; !exists {
@@ -161,7 +161,7 @@ func TestUserDataSource_Schema(t *testing.T) {
 	// Check for required attributes based on the user data source structure
 	expectedAttrs := []string{
 		"name", "external", "principal_id",
-		"default_schema", "default_language", "sid", "object_id",
+		"default_schema", "default_language", "sid", "object_id", "login_name", "database_name",
 	}
 	for _, attr := range expectedAttrs {
 		if _, exists := resp.Schema.Attributes[attr]; !exists {
@@ -259,6 +259,92 @@ func createEmptyUserModel() model.UserDataModel {
 		DefaultLanguage: types.StringValue("English"),
 		SID:             types.StringValue("0x1111111111111111111111111111111111111111"),
 		ObjectID:        types.StringNull(), // Null object ID
+		LoginName:       types.StringNull(),
+	}
+}
+
+func TestLoginDataSource_Schema(t *testing.T) {
+	d := NewLoginDataSource()
+	ctx := context.Background()
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(ctx, datasource.SchemaRequest{}, resp)
+
+	expectedAttrs := []string{
+		"name", "external", "principal_id", "check_policy",
+		"check_expiration", "default_database", "default_language", "sid",
+	}
+	for _, attr := range expectedAttrs {
+		if _, exists := resp.Schema.Attributes[attr]; !exists {
+			t.Errorf("Expected attribute %s to be defined in schema", attr)
+		}
+	}
+
+	// The login is looked up by name only.
+	if nameAttr, ok := resp.Schema.Attributes["name"].(schema.StringAttribute); !ok || !nameAttr.Required {
+		t.Error("Expected name attribute to be a required StringAttribute")
+	}
+	for name, attr := range resp.Schema.Attributes {
+		if name != "name" && !attr.IsComputed() {
+			t.Errorf("Expected attribute %s to be computed", name)
+		}
+	}
+}
+
+func TestServerInfoDataSource_Schema(t *testing.T) {
+	d := NewServerInfoDataSource()
+	ctx := context.Background()
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(ctx, datasource.SchemaRequest{}, resp)
+
+	expectedAttrs := []string{
+		"database_name", "server_name", "version", "product_version", "major_version", "minor_version",
+		"build_number", "edition", "engine_edition", "engine_edition_name", "is_azure", "containment",
+		"compatibility_level", "collation", "default_language", "contained_database_authentication",
+		"default_language_supported", "login_name", "user_name",
+	}
+	for _, attr := range expectedAttrs {
+		if _, exists := resp.Schema.Attributes[attr]; !exists {
+			t.Errorf("Expected attribute %s to be defined in schema", attr)
+		}
+	}
+
+	// Only the database can be configured, and it reports the database actually read.
+	if databaseAttr, ok := resp.Schema.Attributes["database_name"].(schema.StringAttribute); !ok || !databaseAttr.Optional || !databaseAttr.Computed {
+		t.Error("Expected database_name attribute to be an optional and computed StringAttribute")
+	}
+	for name, attr := range resp.Schema.Attributes {
+		if !attr.IsComputed() {
+			t.Errorf("Expected attribute %s to be computed", name)
+		}
+		if name != "database_name" && attr.IsOptional() {
+			t.Errorf("Expected attribute %s not to be configurable", name)
+		}
+	}
+}
+
+func TestPreflightChecksDataSource_Schema(t *testing.T) {
+	d := NewPreflightChecksDataSource()
+	ctx := context.Background()
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(ctx, datasource.SchemaRequest{}, resp)
+
+	for _, attr := range []string{"database_name", "checks", "missing_permissions", "passed"} {
+		if _, exists := resp.Schema.Attributes[attr]; !exists {
+			t.Errorf("Expected attribute %s to be defined in schema", attr)
+		}
+	}
+
+	checksAttr, ok := resp.Schema.Attributes["checks"].(schema.ListNestedAttribute)
+	if !ok || !checksAttr.Computed {
+		t.Fatal("Expected checks attribute to be a computed ListNestedAttribute")
+	}
+	for _, attr := range []string{"resource_type", "permission", "securable_class", "granted"} {
+		if _, exists := checksAttr.NestedObject.Attributes[attr]; !exists {
+			t.Errorf("Expected checks attribute %s to be defined in schema", attr)
+		}
 	}
 }
 
@@ -43,6 +43,7 @@ func (d *databaseRoleDataSource) Schema(_ context.Context, req datasource.Schema
 		MarkdownDescription: "Database role data source.",
 
 		Attributes: map[string]schema.Attribute{
+			"database_name": databaseNameDataSourceAttribute(),
 			"name": schema.StringAttribute{
 				Description:         "The database role's name.",
 				MarkdownDescription: "The database role's name.",
@@ -117,14 +118,20 @@ func (d *databaseRoleDataSource) Read(ctx context.Context, req datasource.ReadRe
 	}
 
 	tflog.Debug(ctx, "databaseRoleDataSource: using provider connector")
+	connector, connectorDiags := getDatabaseConnector(ctx, d.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Set up the context and connect to the database.
-	dbCtx := context.Background()
+	// Connect to the database using the shared connection pool.
 	tflog.Debug(ctx, "databaseRoleDataSource: connect to the database")
-	db, err := d.connector.Connect()
+	db, err := connectToDatabase(ctx, connector)
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error connecting to the database", err.Error())
+		if !handleAuthenticationError(ctx, err, &resp.Diagnostics) {
+			resp.Diagnostics.AddError("Error connecting to the database", err.Error())
+		}
 		return
 	}
 
@@ -133,7 +140,7 @@ func (d *databaseRoleDataSource) Read(ctx context.Context, req datasource.ReadRe
 	}
 
 	tflog.Debug(ctx, "databaseRoleDataSource: get the user")
-	role, err = d.connector.GetDatabaseRole(dbCtx, db, role)
+	role, err = connector.GetDatabaseRole(ctx, db, role)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error getting database role", err.Error())
@@ -149,7 +156,7 @@ func (d *databaseRoleDataSource) Read(ctx context.Context, req datasource.ReadRe
 	state.IsFixedRole = types.BoolValue(role.IsFixedRole)
 
 	var members []*qmodel.User
-	members, err = d.connector.GetDatabaseRoleMembers(dbCtx, db, role)
+	members, err = connector.GetDatabaseRoleMembers(ctx, db, role)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error getting database role members", err.Error())
@@ -40,6 +40,7 @@ func (d *databaseRoleMembersDataSource) Schema(_ context.Context, req datasource
 		Description:         "Reads the members of a database role.",
 		MarkdownDescription: "Reads the members of a database role.",
 		Attributes: map[string]schema.Attribute{
+			"database_name": databaseNameDataSourceAttribute(),
 			"name": schema.StringAttribute{
 				Description:         "The database role name.",
 				MarkdownDescription: "The database role name.",
@@ -86,7 +87,12 @@ func (d *databaseRoleMembersDataSource) Read(ctx context.Context, req datasource
 		"role_name": data.Name.ValueString(),
 	})
 
-	connector := d.connector
+	// Use the provider connector, or the connector to the database of the data source
+	connector, connectorDiags := getDatabaseConnector(ctx, d.connector, data.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database
 	db, err := connectToDatabase(ctx, connector)
@@ -6,14 +6,15 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"terraform-provider-mssqlpermissions/internal/provider/model"
 	"terraform-provider-mssqlpermissions/internal/queries"
 	qmodel "terraform-provider-mssqlpermissions/internal/queries/model"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -21,6 +22,7 @@ import (
 var _ resource.Resource = &DatabaseRoleMembersResource{}
 var _ resource.ResourceWithImportState = &DatabaseRoleMembersResource{}
 var _ resource.ResourceWithConfigure = &DatabaseRoleMembersResource{}
+var _ resource.ResourceWithModifyPlan = &DatabaseRoleMembersResource{}
 
 func NewDatabaseRoleMembersResource() resource.Resource {
 	return &DatabaseRoleMembersResource{}
@@ -39,19 +41,17 @@ func (r *DatabaseRoleMembersResource) Metadata(_ context.Context, req resource.M
 
 // Schema is a method that sets the schema for the DatabaseRoleMembersResource.
 // It defines the attributes and their descriptions for the resource.
-func (r *DatabaseRoleMembersResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *DatabaseRoleMembersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Database Role Resource",
 
 		Attributes: map[string]schema.Attribute{
+			"database_name": databaseNameResourceAttribute(),
 			"name": schema.StringAttribute{
 				Description:         "The database role's name.",
 				MarkdownDescription: "The database role's name.",
 				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"members": schema.ListAttribute{
 				Description:         "The database role's members.",
@@ -60,9 +60,22 @@ func (r *DatabaseRoleMembersResource) Schema(_ context.Context, req resource.Sch
 				ElementType:         types.StringType,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
+// ModifyPlan replaces the resource when it moves to another database.
+func (r *DatabaseRoleMembersResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	requireReplaceOnDatabaseMove(ctx, r.connector, req, resp)
+}
+
 // Configure adds the provider-configured client to the resource.
 func (r *DatabaseRoleMembersResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -87,17 +100,31 @@ func (r *DatabaseRoleMembersResource) Configure(_ context.Context, req resource.
 // It updates the state object with the created role information.
 // If any error occurs during the process, it adds the error to the response diagnostics.
 func (r *DatabaseRoleMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var state model.RoleMembersModel
+	var state model.RoleMembersWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Bound the whole operation by the create timeout of the resource
+	createTimeout, timeoutDiags := state.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "DatabaseRoleMembersResource", "Create")
 
-	// Use provider connector
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -151,17 +178,31 @@ func (r *DatabaseRoleMembersResource) Create(ctx context.Context, req resource.C
 //
 // If there is an error connecting to the database or deleting the role, it adds an error diagnostic to the response.
 func (r *DatabaseRoleMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state model.RoleMembersModel
+	var state model.RoleMembersWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Bound the whole operation by the delete timeout of the resource
+	deleteTimeout, timeoutDiags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "DatabaseRoleMembersResource", "Delete")
 
-	// Use provider connector
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -201,17 +242,31 @@ func (r *DatabaseRoleMembersResource) Delete(ctx context.Context, req resource.D
 // If the role is not found, it creates an empty state object.
 // It returns any diagnostics encountered during the process.
 func (r *DatabaseRoleMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state model.RoleMembersModel
+	var state model.RoleMembersWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Bound the whole operation by the read timeout of the resource
+	readTimeout, timeoutDiags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "DatabaseRoleMembersResource", "Read")
 
-	// Use provider connector
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -301,17 +356,33 @@ func (r *DatabaseRoleMembersResource) Read(ctx context.Context, req resource.Rea
 // It also populates the state object with the updated role information.
 
 func (r *DatabaseRoleMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var state model.RoleMembersModel
+	var state model.RoleMembersWithTimeoutsModel
+	var previousState model.RoleMembersWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &previousState)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Bound the whole operation by the update timeout of the resource
+	updateTimeout, timeoutDiags := state.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "DatabaseRoleMembersResource", "Update")
 
-	// Use provider connector
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -330,6 +401,36 @@ func (r *DatabaseRoleMembersResource) Update(ctx context.Context, req resource.U
 		return
 	}
 
+	// When the resource moves to another role, the members of the state leave the previous one.
+	// A renamed role keeps its members.
+	previousRole := role
+	if role != nil {
+		previousRole, err = previousDatabaseRole(ctx, connector, db, previousState.Name, role)
+		if err != nil {
+			resp.Diagnostics.AddError("Error getting role", err.Error())
+			return
+		}
+	}
+
+	if previousRole != role {
+		previousMembers, convertDiags := convertStringListToSlice(ctx, previousState.Members)
+		if convertDiags != nil {
+			resp.Diagnostics.Append(*convertDiags...)
+			return
+		}
+
+		usersToRemove := make([]*qmodel.User, 0, len(previousMembers))
+		for _, memberName := range previousMembers {
+			usersToRemove = append(usersToRemove, &qmodel.User{Name: memberName})
+		}
+
+		err = connector.RemoveDatabaseRoleMembers(ctx, db, previousRole, usersToRemove)
+		if err != nil {
+			resp.Diagnostics.AddError("Error removing users from role", err.Error())
+			return
+		}
+	}
+
 	membersInDB, err := connector.GetDatabaseRoleMembers(ctx, db, role)
 	if err != nil {
 		resp.Diagnostics.AddError("Error getting role members", err.Error())
@@ -405,7 +506,18 @@ func (r *DatabaseRoleMembersResource) Update(ctx context.Context, req resource.U
 	logResourceOperationComplete(ctx, "DatabaseRoleMembersResource", "Update")
 }
 
-// ImportState implements resource.ResourceWithImportState.
+// ImportState imports the members of a role from an identifier of the form [database:]role.
+// Read then adopts every member of the role, in name order.
 func (r *DatabaseRoleMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	panic("not implemented")
+	database, roleName, err := splitImportDatabase(req.ID)
+	if err == nil && roleName == "" {
+		err = fmt.Errorf("import identifier %q has no role", req.ID)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("%s, expected [database:]role", err))
+		return
+	}
+
+	setImportDatabase(ctx, database, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), roleName)...)
 }
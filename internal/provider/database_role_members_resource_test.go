@@ -54,7 +54,7 @@ func TestDatabaseRoleMembersResource_Schema(t *testing.T) {
 		}
 	}
 
-	// Verify name attribute has RequiresReplace plan modifier
+	// Verify name attribute is updated in place, so that renaming the role keeps the resource
 	nameAttr, exists := resp.Schema.Attributes["name"]
 	if !exists {
 		t.Error("Expected name attribute to exist")
@@ -62,10 +62,9 @@ func TestDatabaseRoleMembersResource_Schema(t *testing.T) {
 	}
 
 	if stringAttr, ok := nameAttr.(schema.StringAttribute); ok {
-		if len(stringAttr.PlanModifiers) == 0 {
-			t.Error("Expected name attribute to have plan modifiers")
+		if len(stringAttr.PlanModifiers) != 0 {
+			t.Error("Expected name attribute to have no plan modifiers")
 		}
-		// Could check for specific RequiresReplace modifier if needed
 	} else {
 		t.Error("Expected name attribute to be a StringAttribute")
 	}
@@ -158,20 +157,32 @@ func TestDatabaseRoleMembersResource_Configure(t *testing.T) {
 }
 
 func TestDatabaseRoleMembersResource_ImportState(t *testing.T) {
-	r := &DatabaseRoleMembersResource{}
 	ctx := context.Background()
 
-	// Test that ImportState panics as expected (since it's not implemented)
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected ImportState to panic with 'not implemented'")
+	t.Run("Role", func(t *testing.T) {
+		resp := newImportStateResponse(&DatabaseRoleMembersResource{})
+		(&DatabaseRoleMembersResource{}).ImportState(ctx, resource.ImportStateRequest{ID: "sales:app_role"}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
 		}
-	}()
 
-	req := resource.ImportStateRequest{}
-	resp := &resource.ImportStateResponse{}
+		var state model.RoleMembersWithTimeoutsModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if state.Name.ValueString() != "app_role" || state.DatabaseName.ValueString() != "sales" {
+			t.Errorf("Expected role app_role in database sales, got %v in %v", state.Name, state.DatabaseName)
+		}
+	})
 
-	r.ImportState(ctx, req, resp)
+	for _, id := range []string{"", "sales:"} {
+		t.Run("Invalid "+id, func(t *testing.T) {
+			resp := newImportStateResponse(&DatabaseRoleMembersResource{})
+			(&DatabaseRoleMembersResource{}).ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+
+			if len(resp.Diagnostics.Errors()) != 1 || resp.Diagnostics.Errors()[0].Summary() != "Invalid Import Identifier" {
+				t.Errorf("Expected an Invalid Import Identifier error, got: %v", resp.Diagnostics)
+			}
+		})
+	}
 }
 
 // Test helper functions for creating test data
@@ -12,8 +12,11 @@ import (
 	"terraform-provider-mssqlpermissions/internal/queries"
 	qmodel "terraform-provider-mssqlpermissions/internal/queries/model"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -23,6 +26,7 @@ import (
 var _ resource.Resource = &DatabaseRoleResource{}
 var _ resource.ResourceWithImportState = &DatabaseRoleResource{}
 var _ resource.ResourceWithConfigure = &DatabaseRoleResource{}
+var _ resource.ResourceWithModifyPlan = &DatabaseRoleResource{}
 
 func NewDatabaseRoleResource() resource.Resource {
 	return &DatabaseRoleResource{}
@@ -94,6 +98,20 @@ func ensureDatabaseRoleDeleted(ctx context.Context, connector databaseRoleDelete
 	return nil
 }
 
+// requiresReplaceIfFixedRole replaces a fixed database role whose name changes, as fixed roles cannot be renamed.
+// They are managed in state only, so the replacement switches to another fixed role without touching the database.
+func requiresReplaceIfFixedRole(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var isFixedRole types.Bool
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("is_fixed_role"), &isFixedRole)...)
+	resp.RequiresReplace = isFixedRole.ValueBool()
+}
+
+// ModifyPlan replaces the resource when it moves to another database.
+func (r *DatabaseRoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	requireReplaceOnDatabaseMove(ctx, r.connector, req, resp)
+}
+
 // Configure is called by the framework to pass provider-level configuration to the resource.
 func (r *DatabaseRoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if provider has not been configured.
@@ -122,24 +140,32 @@ func (r *DatabaseRoleResource) Metadata(_ context.Context, req resource.Metadata
 
 // Schema is a method that sets the schema for the DatabaseRoleResource.
 // It defines the attributes and their descriptions for the resource.
-func (r *DatabaseRoleResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *DatabaseRoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Database Role Resource",
 
 		Attributes: map[string]schema.Attribute{
+			"database_name": databaseNameResourceAttribute(),
 			"name": schema.StringAttribute{
-				Description:         "The database role's name.",
-				MarkdownDescription: "The database role's name.",
+				Description:         "The database role's name. Renaming a role alters it in place, except for fixed roles which are replaced.",
+				MarkdownDescription: "The database role's name. Renaming a role alters it in place, except for fixed roles which are replaced.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfFixedRole,
+						"Fixed database roles cannot be renamed.",
+						"Fixed database roles cannot be renamed.",
+					),
 				},
 			},
 			"principal_id": schema.Int64Attribute{
-				Description:         "Database role principal id.",
-				MarkdownDescription: "Database role principal id.",
+				Description:         "Database role principal id. The role is read back by its principal id, so that a rename made outside of Terraform is detected.",
+				MarkdownDescription: "Database role principal id. The role is read back by its principal id, so that a rename made outside of Terraform is detected.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"type": schema.StringAttribute{
 				Description:         "Database role type.",
@@ -161,6 +187,22 @@ func (r *DatabaseRoleResource) Schema(_ context.Context, req resource.SchemaRequ
 				MarkdownDescription: "Is the database role a fixed role.",
 				Computed:            true,
 			},
+			"sid": schema.StringAttribute{
+				Description:         "Database role SID.",
+				MarkdownDescription: "Database role SID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -180,8 +222,22 @@ func (r *DatabaseRoleResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	// Use provider connector
-	connector := r.connector
+	// Bound the whole operation by the create timeout of the resource
+	createTimeout, timeoutDiags := state.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using proper context
 	db, err := connectToDatabase(ctx, connector)
@@ -206,6 +262,7 @@ func (r *DatabaseRoleResource) Create(ctx context.Context, req resource.CreateRe
 	state.TypeDescription = types.StringValue(role.TypeDescription)
 	state.OwningPrincipal = types.StringValue(role.OwningPrincipal)
 	state.IsFixedRole = types.BoolValue(role.IsFixedRole)
+	state.SID = types.StringValue(role.SID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	logResourceOperationComplete(ctx, "DatabaseRole", "Create")
@@ -225,10 +282,24 @@ func (r *DatabaseRoleResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	// Bound the whole operation by the delete timeout of the resource
+	deleteTimeout, timeoutDiags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "DatabaseRoleResource", "Delete")
 
-	// Use provider connector
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -262,10 +333,24 @@ func (r *DatabaseRoleResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	// Bound the whole operation by the read timeout of the resource
+	readTimeout, timeoutDiags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "DatabaseRoleResource", "Read")
 
-	// Use provider connector
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -274,8 +359,14 @@ func (r *DatabaseRoleResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	// A managed role is looked up by its principal id and SID rather than by its name, so that a rename made
+	// outside of Terraform is read back. An imported role only has its name, principal id or SID in state.
 	role := &qmodel.Role{
-		Name: state.Name.ValueString(),
+		PrincipalID: state.PrincipalID.ValueInt64(),
+		SID:         state.SID.ValueString(),
+	}
+	if state.PrincipalID.IsNull() || state.PrincipalID.IsUnknown() {
+		role.Name = state.Name.ValueString()
 	}
 
 	role, err = connector.GetDatabaseRole(ctx, db, role)
@@ -299,25 +390,41 @@ func (r *DatabaseRoleResource) Read(ctx context.Context, req resource.ReadReques
 	state.TypeDescription = types.StringValue(role.TypeDescription)
 	state.OwningPrincipal = types.StringValue(role.OwningPrincipal)
 	state.IsFixedRole = types.BoolValue(role.IsFixedRole)
+	state.SID = types.StringValue(role.SID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	logResourceOperationComplete(ctx, "DatabaseRoleResource", "Read")
 }
 
 // Update updates the database role based on the provided update request.
-// Since the name requires replacement, there are no updateable fields for this resource.
+// A new name renames the role in place, keeping its members and permissions.
 func (r *DatabaseRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var state model.RoleModel
+	var state, priorState model.RoleModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Bound the whole operation by the update timeout of the resource
+	updateTimeout, timeoutDiags := state.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "DatabaseRoleResource", "Update")
 
-	// Use provider connector
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -326,6 +433,15 @@ func (r *DatabaseRoleResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	if priorState.Name.ValueString() != state.Name.ValueString() {
+		tflog.Debug(ctx, "Renaming database role")
+		err = connector.RenameDatabaseRole(ctx, db, &qmodel.Role{Name: priorState.Name.ValueString()}, state.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error renaming role", err.Error())
+			return
+		}
+	}
+
 	role := &qmodel.Role{
 		Name: state.Name.ValueString(),
 	}
@@ -342,12 +458,13 @@ func (r *DatabaseRoleResource) Update(ctx context.Context, req resource.UpdateRe
 	state.TypeDescription = types.StringValue(role.TypeDescription)
 	state.OwningPrincipal = types.StringValue(role.OwningPrincipal)
 	state.IsFixedRole = types.BoolValue(role.IsFixedRole)
+	state.SID = types.StringValue(role.SID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	logResourceOperationComplete(ctx, "DatabaseRoleResource", "Update")
 }
 
-// ImportState implements resource.ResourceWithImportState.
+// ImportState imports a database role by name, principal id or SID, optionally prefixed by its database.
 func (r *DatabaseRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	panic("not implemented")
+	importPrincipalState(ctx, req, resp)
 }
@@ -13,6 +13,8 @@ import (
 )
 
 func TestAccDatabaseRoleResourceLocal(t *testing.T) {
+	var principalID string
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -23,13 +25,23 @@ func TestAccDatabaseRoleResourceLocal(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("mssqlpermissions_database_role.test", "name", "one"),
 					resource.TestCheckResourceAttr("mssqlpermissions_database_role.test", "owning_principal", "1"),
+					testAccCheckSamePrincipalID("mssqlpermissions_database_role.test", &principalID),
 				),
 			},
-			// Update and Read testing
+			// ImportState testing
+			{
+				ResourceName:                         "mssqlpermissions_database_role.test",
+				ImportState:                          true,
+				ImportStateId:                        "one",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+			// Rename in place and Read testing
 			{
 				Config: testAccDatabaseRoleResourceConfigLocalSQL("two"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("mssqlpermissions_database_role.test", "name", "two"),
+					testAccCheckSamePrincipalID("mssqlpermissions_database_role.test", &principalID),
 				),
 			},
 			// Update and Read testing
@@ -44,6 +56,21 @@ func TestAccDatabaseRoleResourceLocal(t *testing.T) {
 	})
 }
 
+// testAccCheckSamePrincipalID records the principal id of a resource on its first call, and checks that it
+// is unchanged on the next ones, that is, that the principal was altered in place rather than replaced.
+func testAccCheckSamePrincipalID(resourceName string, principalID *string) resource.TestCheckFunc {
+	return resource.TestCheckResourceAttrWith(resourceName, "principal_id", func(value string) error {
+		if *principalID == "" {
+			*principalID = value
+			return nil
+		}
+		if value != *principalID {
+			return fmt.Errorf("principal_id = %s, want %s: the principal was replaced", value, *principalID)
+		}
+		return nil
+	})
+}
+
 func testAccDatabaseRoleResourceConfigLocalSQL(name string) string {
 	return fmt.Sprintf(`
 provider "mssqlpermissions" {
@@ -79,6 +106,14 @@ func TestAccDatabaseRoleResourceAzure(t *testing.T) {
 					resource.TestCheckResourceAttr("mssqlpermissions_database_role.test", "owning_principal", "1"),
 				),
 			},
+			// ImportState testing
+			{
+				ResourceName:                         "mssqlpermissions_database_role.test",
+				ImportState:                          true,
+				ImportStateId:                        "one",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
 			// Update and Read testing
 			{
 				Config: testAccDatabaseRoleResourceConfigAzureSQL("two"),
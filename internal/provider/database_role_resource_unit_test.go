@@ -6,6 +6,10 @@ import (
 	"errors"
 	qmodel "terraform-provider-mssqlpermissions/internal/queries/model"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 )
 
 type mockDatabaseRoleCreateOperations struct {
@@ -171,3 +175,24 @@ func TestEnsureDatabaseRoleDeleted_unit(t *testing.T) {
 		}
 	})
 }
+
+func TestRequiresReplaceIfFixedRole_unit(t *testing.T) {
+	ctx := context.Background()
+
+	for _, isFixedRole := range []bool{true, false} {
+		state := newImportStateResponse(NewDatabaseRoleResource()).State
+		if diags := state.SetAttribute(ctx, path.Root("is_fixed_role"), isFixedRole); diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		resp := &stringplanmodifier.RequiresReplaceIfFuncResponse{}
+		requiresReplaceIfFixedRole(ctx, planmodifier.StringRequest{State: state}, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if resp.RequiresReplace != isFixedRole {
+			t.Errorf("expected RequiresReplace to be %v for is_fixed_role = %v", isFixedRole, isFixedRole)
+		}
+	}
+}
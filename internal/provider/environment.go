@@ -0,0 +1,428 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+// Environment variable fallback for the provider configuration.
+// Attributes left unset in the provider block are read from the environment,
+// and the authentication method is inferred from the variables present when
+// no login block is configured.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"terraform-provider-mssqlpermissions/internal/queries"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Environment variables read by the provider.
+const (
+	envConnectionString      = "MSSQL_CONNECTION_STRING"
+	envServerFqdn            = "MSSQL_SERVER_FQDN"
+	envServerPort            = "MSSQL_PORT"
+	envDatabaseName          = "MSSQL_DATABASE"
+	envInstanceName          = "MSSQL_INSTANCE_NAME"
+	envFailoverPartner       = "MSSQL_FAILOVER_PARTNER"
+	envMultiSubnetFailover   = "MSSQL_MULTI_SUBNET_FAILOVER"
+	envReadOnlyIntent        = "MSSQL_READ_ONLY_INTENT_FOR_DATA_SOURCES"
+	envMaxOpenConnections    = "MSSQL_MAX_OPEN_CONNECTIONS"
+	envMaxIdleConnections    = "MSSQL_MAX_IDLE_CONNECTIONS"
+	envConnectionMaxLifetime = "MSSQL_CONNECTION_MAX_LIFETIME"
+	envConnectTimeout        = "MSSQL_CONNECT_TIMEOUT"
+	envStatementTimeout      = "MSSQL_STATEMENT_TIMEOUT"
+	envMaxRetries            = "MSSQL_MAX_RETRIES"
+	envRetryMinBackoff       = "MSSQL_RETRY_MIN_BACKOFF"
+	envRetryMaxBackoff       = "MSSQL_RETRY_MAX_BACKOFF"
+	envPreflightChecks       = "MSSQL_PREFLIGHT_CHECKS"
+	envExecuteAsUser         = "MSSQL_EXECUTE_AS_USER"
+	envApplicationName       = "MSSQL_APPLICATION_NAME"
+	envWorkstationID         = "MSSQL_WORKSTATION_ID"
+	envSessionContext        = "MSSQL_SESSION_CONTEXT"
+
+	envEncrypt                = "MSSQL_ENCRYPT"
+	envTrustServerCertificate = "MSSQL_TRUST_SERVER_CERTIFICATE"
+	envCACertificatePath      = "MSSQL_CA_CERTIFICATE_PATH"
+	envHostNameInCertificate  = "MSSQL_HOST_NAME_IN_CERTIFICATE"
+	envMinTLSVersion          = "MSSQL_MIN_TLS_VERSION"
+
+	envAccessToken     = "MSSQL_ACCESS_TOKEN"
+	envAccessTokenFile = "MSSQL_ACCESS_TOKEN_FILE"
+	envSQLUsername     = "MSSQL_SQL_USERNAME"
+	envSQLPassword     = "MSSQL_SQL_PASSWORD"
+	envSQLPasswordFile = "MSSQL_SQL_PASSWORD_FILE"
+	envEntraMethod     = "MSSQL_ENTRA_METHOD"
+	envEntraUsername   = "MSSQL_ENTRA_USERNAME"
+	envEntraPassword   = "MSSQL_ENTRA_PASSWORD"
+
+	envClientID                  = "ARM_CLIENT_ID"
+	envClientSecret              = "ARM_CLIENT_SECRET"
+	envClientSecretFilePath      = "ARM_CLIENT_SECRET_FILE_PATH"
+	envClientCertificatePath     = "ARM_CLIENT_CERTIFICATE_PATH"
+	envClientCertificate         = "ARM_CLIENT_CERTIFICATE"
+	envClientCertificatePassword = "ARM_CLIENT_CERTIFICATE_PASSWORD"
+	envTenantID                  = "ARM_TENANT_ID"
+	envUseMSI                    = "ARM_USE_MSI"
+	envMSIResourceID             = "ARM_MSI_RESOURCE_ID"
+	envUseOIDC                   = "ARM_USE_OIDC"
+	envOIDCToken                 = "ARM_OIDC_TOKEN"
+	envOIDCTokenFilePath         = "ARM_OIDC_TOKEN_FILE_PATH"
+)
+
+// loginEnvironment maps the attributes of each login block to the environment variable they fall back to.
+var loginEnvironment = map[string]map[string]string{
+	"sql_login": {
+		"username":      envSQLUsername,
+		"password":      envSQLPassword,
+		"password_file": envSQLPasswordFile,
+	},
+	"spn_login": {
+		"client_id":                   envClientID,
+		"tenant_id":                   envTenantID,
+		"client_secret":               envClientSecret,
+		"client_secret_file":          envClientSecretFilePath,
+		"client_certificate_path":     envClientCertificatePath,
+		"client_certificate":          envClientCertificate,
+		"client_certificate_password": envClientCertificatePassword,
+	},
+	"msi_login": {
+		"user_id":     envClientID,
+		"resource_id": envMSIResourceID,
+	},
+	"federated_login": {
+		"client_id":            envClientID,
+		"tenant_id":            envTenantID,
+		"oidc_token":           envOIDCToken,
+		"oidc_token_file_path": envOIDCTokenFilePath,
+	},
+	"entra_login": {
+		"method":        envEntraMethod,
+		"client_id":     envClientID,
+		"tenant_id":     envTenantID,
+		"client_secret": envClientSecret,
+		"username":      envEntraUsername,
+		"password":      envEntraPassword,
+		"resource_id":   envMSIResourceID,
+	},
+}
+
+// tlsEnvironment maps the string attributes of the tls block to the environment variable they fall back to.
+var tlsEnvironment = map[string]string{
+	"encrypt":                  envEncrypt,
+	"ca_certificate_path":      envCACertificatePath,
+	"host_name_in_certificate": envHostNameInCertificate,
+	"min_tls_version":          envMinTLSVersion,
+}
+
+// loginExclusiveAttributes lists groups of mutually exclusive login attributes. An attribute of a group
+// falls back to the environment only when no attribute of its group is set in the configuration.
+var loginExclusiveAttributes = map[string][][]string{
+	"sql_login":       {{"password", "password_file", "password_command"}},
+	"spn_login":       {{"client_secret", "client_secret_file", "client_secret_command", "client_certificate_path", "client_certificate"}},
+	"msi_login":       {{"user_id", "resource_id"}},
+	"federated_login": {{"oidc_token", "oidc_token_file_path"}},
+}
+
+// applyEnvironment fills the attributes left unset in the provider configuration from the environment.
+// When no authentication method is configured, it is inferred from the environment variables present.
+// When a connection string is set, the variables of the connection settings it replaces are not read.
+func applyEnvironment(ctx context.Context, config *SqlPermissionsProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	config.ConnectionString = stringFromEnvironment(config.ConnectionString, envConnectionString)
+	config.ExecuteAsUser = stringFromEnvironment(config.ExecuteAsUser, envExecuteAsUser)
+	config.ApplicationName = stringFromEnvironment(config.ApplicationName, envApplicationName)
+	config.WorkstationID = stringFromEnvironment(config.WorkstationID, envWorkstationID)
+	config.SessionContext = stringMapFromEnvironment(config.SessionContext, envSessionContext, &diags)
+	config.ConnectionMaxLifetime = stringFromEnvironment(config.ConnectionMaxLifetime, envConnectionMaxLifetime)
+	config.ConnectTimeout = stringFromEnvironment(config.ConnectTimeout, envConnectTimeout)
+	config.StatementTimeout = stringFromEnvironment(config.StatementTimeout, envStatementTimeout)
+	config.RetryMinBackoff = stringFromEnvironment(config.RetryMinBackoff, envRetryMinBackoff)
+	config.RetryMaxBackoff = stringFromEnvironment(config.RetryMaxBackoff, envRetryMaxBackoff)
+	config.MaxOpenConnections = int64FromEnvironment(config.MaxOpenConnections, envMaxOpenConnections, &diags)
+	config.MaxIdleConnections = int64FromEnvironment(config.MaxIdleConnections, envMaxIdleConnections, &diags)
+	config.MaxRetries = int64FromEnvironment(config.MaxRetries, envMaxRetries, &diags)
+	config.ReadOnlyIntentForDataSources = boolFromEnvironment(config.ReadOnlyIntentForDataSources, envReadOnlyIntent, &diags)
+	config.PreflightChecks = boolFromEnvironment(config.PreflightChecks, envPreflightChecks, &diags)
+
+	// The connection string replaces the structured connection settings, their variables are not read.
+	if !config.ConnectionString.IsNull() {
+		return diags
+	}
+
+	config.ServerFqdn = stringFromEnvironment(config.ServerFqdn, envServerFqdn)
+	config.DatabaseName = stringFromEnvironment(config.DatabaseName, envDatabaseName)
+	config.InstanceName = stringFromEnvironment(config.InstanceName, envInstanceName)
+	config.FailoverPartner = stringFromEnvironment(config.FailoverPartner, envFailoverPartner)
+	config.ServerPort = int64FromEnvironment(config.ServerPort, envServerPort, &diags)
+	config.MultiSubnetFailover = boolFromEnvironment(config.MultiSubnetFailover, envMultiSubnetFailover, &diags)
+
+	config.TLS = tlsFromEnvironment(config.TLS, &diags)
+
+	if !hasAuthenticationMethod(config) {
+		inferAuthenticationMethod(ctx, config)
+	}
+
+	logins := map[string]*types.Object{
+		"sql_login":       &config.SQLLogin,
+		"spn_login":       &config.SPNLogin,
+		"msi_login":       &config.MSILogin,
+		"federated_login": &config.FederatedLogin,
+		"entra_login":     &config.EntraLogin,
+	}
+	for name, login := range logins {
+		if login.IsNull() || login.IsUnknown() {
+			continue
+		}
+		value, loginDiags := loginFromEnvironment(name, *login)
+		diags.Append(loginDiags...)
+		*login = value
+	}
+
+	return diags
+}
+
+// hasAuthenticationMethod reports whether the configuration sets any authentication method.
+func hasAuthenticationMethod(config *SqlPermissionsProviderModel) bool {
+	return !config.SQLLogin.IsNull() || !config.SPNLogin.IsNull() || !config.MSILogin.IsNull() ||
+		!config.FederatedLogin.IsNull() || !config.EntraLogin.IsNull() ||
+		!config.AccessToken.IsNull() || !config.AccessTokenFile.IsNull()
+}
+
+// inferAuthenticationMethod selects the authentication method from the environment variables present,
+// in order of precedence: access token, SQL login, Entra login, federated login, managed identity and
+// service principal. The configuration is left unchanged when none applies.
+func inferAuthenticationMethod(ctx context.Context, config *SqlPermissionsProviderModel) {
+	var inferred string
+
+	switch {
+	case os.Getenv(envAccessToken) != "":
+		config.AccessToken = types.StringValue(os.Getenv(envAccessToken))
+		inferred = "access_token"
+	case os.Getenv(envAccessTokenFile) != "":
+		config.AccessTokenFile = types.StringValue(os.Getenv(envAccessTokenFile))
+		inferred = "access_token_file"
+	case os.Getenv(envSQLUsername) != "":
+		config.SQLLogin = emptyBlockObject("sql_login")
+		inferred = "sql_login"
+	case os.Getenv(envEntraMethod) != "":
+		config.EntraLogin = emptyBlockObject("entra_login")
+		inferred = "entra_login"
+	case boolEnvironment(envUseOIDC) || os.Getenv(envOIDCToken) != "" || os.Getenv(envOIDCTokenFilePath) != "":
+		config.FederatedLogin = emptyBlockObject("federated_login")
+		inferred = "federated_login"
+	case boolEnvironment(envUseMSI):
+		config.MSILogin = emptyBlockObject("msi_login")
+		inferred = "msi_login"
+	case os.Getenv(envClientSecret) != "" || os.Getenv(envClientSecretFilePath) != "" ||
+		os.Getenv(envClientCertificatePath) != "" || os.Getenv(envClientCertificate) != "":
+		config.SPNLogin = emptyBlockObject("spn_login")
+		inferred = "spn_login"
+	default:
+		return
+	}
+
+	tflog.Debug(ctx, "Inferred authentication method from the environment", map[string]interface{}{
+		"authentication_method": inferred,
+	})
+}
+
+// loginFromEnvironment returns the login block with its unset attributes read from the environment.
+func loginFromEnvironment(name string, login types.Object) (types.Object, diag.Diagnostics) {
+	attrTypes := blockAttributeTypes(name)
+	attributes := make(map[string]attr.Value, len(attrTypes))
+	for key, value := range login.Attributes() {
+		attributes[key] = value
+	}
+
+	configured := login.Attributes()
+	isConfigured := func(values map[string]attr.Value) func(string) bool {
+		return func(key string) bool {
+			switch value := values[key].(type) {
+			case types.String:
+				return value.IsUnknown() || value.ValueString() != ""
+			case types.List:
+				return value.IsUnknown() || len(value.Elements()) > 0
+			}
+			return false
+		}
+	}
+
+	// Exclusive attributes only fall back when none of their group is set in the configuration.
+	canFallBack := func(key string) bool {
+		for _, group := range loginExclusiveAttributes[name] {
+			if slices.Contains(group, key) && slices.ContainsFunc(group, isConfigured(configured)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// The Entra method decides which attributes are used, so that unrelated ARM_* variables don't conflict.
+	isUsed := func(string) bool { return true }
+	if name == "entra_login" {
+		methodValue := stringFromEnvironment(attributes["method"].(types.String), envEntraMethod)
+		attributes["method"] = methodValue
+		if method, ok := entraLoginMethods[queries.FedAuth(methodValue.ValueString())]; ok {
+			isUsed = func(key string) bool {
+				return slices.Contains(method.required, key) || slices.Contains(method.optional, key)
+			}
+		}
+	}
+
+	for key, envVar := range loginEnvironment[name] {
+		value, ok := attributes[key].(types.String)
+		if !ok || !value.IsNull() || !canFallBack(key) || !isUsed(key) {
+			continue
+		}
+		attributes[key] = stringFromEnvironment(value, envVar)
+	}
+
+	// user_identity defaults to whether a user-assigned identity is selected.
+	if name == "msi_login" {
+		if userIdentity, ok := attributes["user_identity"].(types.Bool); ok && userIdentity.IsNull() {
+			selected := isConfigured(attributes)
+			attributes["user_identity"] = types.BoolValue(selected("user_id") || selected("resource_id"))
+		}
+	}
+
+	return types.ObjectValue(attrTypes, attributes)
+}
+
+// tlsFromEnvironment returns the tls block with its unset attributes read from the environment.
+// The block is created when it is not configured and any of its environment variables is set.
+func tlsFromEnvironment(tls types.Object, diags *diag.Diagnostics) types.Object {
+	if tls.IsUnknown() {
+		return tls
+	}
+	if tls.IsNull() {
+		envVars := append(slices.Collect(maps.Values(tlsEnvironment)), envTrustServerCertificate)
+		if !slices.ContainsFunc(envVars, func(envVar string) bool { return os.Getenv(envVar) != "" }) {
+			return tls
+		}
+		tls = emptyBlockObject("tls")
+	}
+
+	attributes := maps.Clone(tls.Attributes())
+	for key, envVar := range tlsEnvironment {
+		if value, ok := attributes[key].(types.String); ok {
+			attributes[key] = stringFromEnvironment(value, envVar)
+		}
+	}
+	if value, ok := attributes["trust_server_certificate"].(types.Bool); ok {
+		attributes["trust_server_certificate"] = boolFromEnvironment(value, envTrustServerCertificate, diags)
+	}
+
+	value, valueDiags := types.ObjectValue(blockAttributeTypes("tls"), attributes)
+	diags.Append(valueDiags...)
+	return value
+}
+
+// emptyBlockObject returns a nested block of the provider schema with all of its attributes unset.
+func emptyBlockObject(name string) types.Object {
+	attrTypes := blockAttributeTypes(name)
+	attributes := make(map[string]attr.Value, len(attrTypes))
+	for key, attrType := range attrTypes {
+		if listType, ok := attrType.(types.ListType); ok {
+			attributes[key] = types.ListNull(listType.ElemType)
+		} else if attrType == types.BoolType {
+			attributes[key] = types.BoolNull()
+		} else {
+			attributes[key] = types.StringNull()
+		}
+	}
+	return types.ObjectValueMust(attrTypes, attributes)
+}
+
+// blockAttributeTypes returns the attribute types of a nested block from the provider schema.
+func blockAttributeTypes(name string) map[string]attr.Type {
+	return getProviderConfigSchema()[name].GetType().(types.ObjectType).AttrTypes
+}
+
+// stringFromEnvironment returns value, or the environment variable when value is null.
+func stringFromEnvironment(value types.String, envVar string) types.String {
+	if !value.IsNull() {
+		return value
+	}
+	if env := os.Getenv(envVar); env != "" {
+		return types.StringValue(env)
+	}
+	return value
+}
+
+// int64FromEnvironment returns value, or the environment variable parsed as an integer when value is null.
+func int64FromEnvironment(value types.Int64, envVar string, diags *diag.Diagnostics) types.Int64 {
+	if !value.IsNull() {
+		return value
+	}
+	env := os.Getenv(envVar)
+	if env == "" {
+		return value
+	}
+	number, err := strconv.ParseInt(env, 10, 64)
+	if err != nil {
+		diags.AddError(
+			"Invalid Environment Variable",
+			"The "+envVar+" environment variable must be an integer: "+err.Error(),
+		)
+		return value
+	}
+	return types.Int64Value(number)
+}
+
+// boolFromEnvironment returns value, or the environment variable parsed as a boolean when value is null.
+func boolFromEnvironment(value types.Bool, envVar string, diags *diag.Diagnostics) types.Bool {
+	if !value.IsNull() {
+		return value
+	}
+	env := os.Getenv(envVar)
+	if env == "" {
+		return value
+	}
+	boolean, err := strconv.ParseBool(env)
+	if err != nil {
+		diags.AddError(
+			"Invalid Environment Variable",
+			"The "+envVar+" environment variable must be a boolean: "+err.Error(),
+		)
+		return value
+	}
+	return types.BoolValue(boolean)
+}
+
+// stringMapFromEnvironment returns value, or the environment variable parsed as a JSON object of strings
+// when value is null.
+func stringMapFromEnvironment(value types.Map, envVar string, diags *diag.Diagnostics) types.Map {
+	if !value.IsNull() {
+		return value
+	}
+	env := os.Getenv(envVar)
+	if env == "" {
+		return value
+	}
+	var elements map[string]string
+	if err := json.Unmarshal([]byte(env), &elements); err != nil {
+		diags.AddError(
+			"Invalid Environment Variable",
+			"The "+envVar+" environment variable must be a JSON object of strings (e.g. {\"run_id\": \"1234\"}): "+err.Error(),
+		)
+		return value
+	}
+	mapValue, mapDiags := types.MapValueFrom(context.Background(), types.StringType, elements)
+	diags.Append(mapDiags...)
+	return mapValue
+}
+
+// boolEnvironment reports whether the environment variable is set to a true value.
+func boolEnvironment(envVar string) bool {
+	value, err := strconv.ParseBool(os.Getenv(envVar))
+	return err == nil && value
+}
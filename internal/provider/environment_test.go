@@ -0,0 +1,409 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// clearProviderEnvironment unsets every environment variable read by the provider for the duration of the test.
+func clearProviderEnvironment(t *testing.T) {
+	t.Helper()
+
+	for _, envVar := range []string{
+		envConnectionString, envServerFqdn, envServerPort, envDatabaseName, envInstanceName, envFailoverPartner, envMultiSubnetFailover,
+		envReadOnlyIntent, envPreflightChecks, envExecuteAsUser, envApplicationName, envWorkstationID, envSessionContext, envMaxOpenConnections, envMaxIdleConnections,
+		envConnectionMaxLifetime, envConnectTimeout, envStatementTimeout,
+		envMaxRetries, envRetryMinBackoff, envRetryMaxBackoff, envAccessToken, envAccessTokenFile, envSQLUsername, envSQLPassword, envSQLPasswordFile,
+		envEntraMethod, envEntraUsername, envEntraPassword, envClientID, envClientSecret, envClientSecretFilePath,
+		envClientCertificatePath, envClientCertificate, envClientCertificatePassword, envTenantID,
+		envUseMSI, envMSIResourceID, envUseOIDC, envOIDCToken, envOIDCTokenFilePath,
+		envEncrypt, envTrustServerCertificate, envCACertificatePath, envHostNameInCertificate, envMinTLSVersion,
+	} {
+		t.Setenv(envVar, "")
+	}
+}
+
+func TestApplyEnvironment_ConnectionAttributes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("FallBackToEnvironment", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envServerFqdn, "env.database.windows.net")
+		t.Setenv(envDatabaseName, "envdb")
+		t.Setenv(envServerPort, "1444")
+		t.Setenv(envConnectTimeout, "1m")
+		t.Setenv(envStatementTimeout, "5m")
+		t.Setenv(envInstanceName, "SQL01")
+		t.Setenv(envMultiSubnetFailover, "false")
+		t.Setenv(envReadOnlyIntent, "true")
+
+		config := SqlPermissionsProviderModel{}
+		if diags := applyEnvironment(ctx, &config); diags.HasError() {
+			t.Fatalf("Expected no errors, got: %v", diags)
+		}
+
+		if config.ServerFqdn.ValueString() != "env.database.windows.net" {
+			t.Errorf("Expected server_fqdn from environment, got %s", config.ServerFqdn)
+		}
+		if config.DatabaseName.ValueString() != "envdb" {
+			t.Errorf("Expected database_name from environment, got %s", config.DatabaseName)
+		}
+		if config.ServerPort.ValueInt64() != 1444 {
+			t.Errorf("Expected server_port 1444 from environment, got %d", config.ServerPort.ValueInt64())
+		}
+		if config.ConnectTimeout.ValueString() != "1m" {
+			t.Errorf("Expected connect_timeout from environment, got %s", config.ConnectTimeout)
+		}
+		if config.StatementTimeout.ValueString() != "5m" {
+			t.Errorf("Expected statement_timeout from environment, got %s", config.StatementTimeout)
+		}
+		if config.InstanceName.ValueString() != "SQL01" {
+			t.Errorf("Expected instance_name from environment, got %s", config.InstanceName)
+		}
+		if config.MultiSubnetFailover.IsNull() || config.MultiSubnetFailover.ValueBool() {
+			t.Errorf("Expected multi_subnet_failover false from environment, got %s", config.MultiSubnetFailover)
+		}
+		if !config.ReadOnlyIntentForDataSources.ValueBool() {
+			t.Errorf("Expected read_only_intent_for_data_sources true from environment, got %s", config.ReadOnlyIntentForDataSources)
+		}
+	})
+
+	t.Run("ConfigurationTakesPrecedence", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envServerFqdn, "env.database.windows.net")
+
+		config := SqlPermissionsProviderModel{ServerFqdn: types.StringValue("hcl.database.windows.net")}
+		applyEnvironment(ctx, &config)
+
+		if config.ServerFqdn.ValueString() != "hcl.database.windows.net" {
+			t.Errorf("Expected server_fqdn from configuration, got %s", config.ServerFqdn)
+		}
+	})
+
+	t.Run("ConnectionStringIgnoresStructuredVariables", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envConnectionString, "server=sql.example.com;database=app;user id=admin;password=secret")
+		t.Setenv(envServerFqdn, "env.database.windows.net")
+		t.Setenv(envSQLUsername, "admin")
+		t.Setenv(envSQLPassword, "secret")
+		t.Setenv(envEncrypt, "strict")
+		t.Setenv(envMaxRetries, "2")
+
+		config := SqlPermissionsProviderModel{}
+		if diags := applyEnvironment(ctx, &config); diags.HasError() {
+			t.Fatalf("Expected no errors, got: %v", diags)
+		}
+
+		if config.ConnectionString.IsNull() {
+			t.Error("Expected connection_string from environment")
+		}
+		if !config.ServerFqdn.IsNull() || !config.SQLLogin.IsNull() || !config.TLS.IsNull() {
+			t.Errorf("Expected the structured connection settings to stay unset, got %s, %s and %s", config.ServerFqdn, config.SQLLogin, config.TLS)
+		}
+		if config.MaxRetries.ValueInt64() != 2 {
+			t.Errorf("Expected max_retries 2 from environment, got %d", config.MaxRetries.ValueInt64())
+		}
+		if diags := validateConnectionString(config); diags.HasError() {
+			t.Errorf("Expected no conflicts, got: %v", diags)
+		}
+	})
+
+	t.Run("Session", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envApplicationName, "terraform/production")
+		t.Setenv(envSessionContext, `{"run_id": "1234", "git_sha": "0c1d2e3"}`)
+
+		config := SqlPermissionsProviderModel{}
+		if diags := applyEnvironment(ctx, &config); diags.HasError() {
+			t.Fatalf("Expected no errors, got: %v", diags)
+		}
+
+		if config.ApplicationName.ValueString() != "terraform/production" {
+			t.Errorf("Expected application_name from environment, got %s", config.ApplicationName)
+		}
+		if len(config.SessionContext.Elements()) != 2 {
+			t.Errorf("Expected session_context from environment, got %s", config.SessionContext)
+		}
+	})
+
+	t.Run("InvalidSessionContext", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envSessionContext, "run_id=1234")
+
+		config := SqlPermissionsProviderModel{}
+		diags := applyEnvironment(ctx, &config)
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid Environment Variable" {
+			t.Errorf("Expected 'Invalid Environment Variable' error, got: %v", diags)
+		}
+	})
+
+	t.Run("InvalidPort", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envServerPort, "not-a-port")
+
+		config := SqlPermissionsProviderModel{}
+		diags := applyEnvironment(ctx, &config)
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid Environment Variable" {
+			t.Errorf("Expected 'Invalid Environment Variable' error, got: %v", diags)
+		}
+	})
+}
+
+func TestApplyEnvironment_InferAuthenticationMethod(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected string
+	}{
+		{
+			name:     "None",
+			env:      map[string]string{},
+			expected: "",
+		},
+		{
+			name:     "SQLLogin",
+			env:      map[string]string{envSQLUsername: "sa", envSQLPassword: "P@ssw0rd"},
+			expected: "sql_login",
+		},
+		{
+			name:     "ServicePrincipalSecret",
+			env:      map[string]string{envClientID: "client", envTenantID: "tenant", envClientSecret: "secret"},
+			expected: "spn_login",
+		},
+		{
+			name:     "FederatedTokenFile",
+			env:      map[string]string{envClientID: "client", envTenantID: "tenant", envOIDCTokenFilePath: "/var/run/secrets/token"},
+			expected: "federated_login",
+		},
+		{
+			name:     "ManagedIdentity",
+			env:      map[string]string{envUseMSI: "true"},
+			expected: "msi_login",
+		},
+		{
+			name:     "EntraMethod",
+			env:      map[string]string{envEntraMethod: "ActiveDirectoryAzCli"},
+			expected: "entra_login",
+		},
+		{
+			name:     "AccessTokenTakesPrecedence",
+			env:      map[string]string{envAccessToken: "token", envSQLUsername: "sa"},
+			expected: "access_token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearProviderEnvironment(t)
+			for envVar, value := range tt.env {
+				t.Setenv(envVar, value)
+			}
+
+			config := SqlPermissionsProviderModel{}
+			if diags := applyEnvironment(ctx, &config); diags.HasError() {
+				t.Fatalf("Expected no errors, got: %v", diags)
+			}
+
+			set := map[string]bool{
+				"sql_login":       !config.SQLLogin.IsNull(),
+				"spn_login":       !config.SPNLogin.IsNull(),
+				"msi_login":       !config.MSILogin.IsNull(),
+				"federated_login": !config.FederatedLogin.IsNull(),
+				"entra_login":     !config.EntraLogin.IsNull(),
+				"access_token":    !config.AccessToken.IsNull(),
+			}
+			for method, isSet := range set {
+				if isSet != (method == tt.expected) {
+					t.Errorf("Expected %s set = %v, got %v", method, method == tt.expected, isSet)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyEnvironment_LoginAttributes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ConfiguredBlockFallsBack", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envSQLPassword, "from-env")
+
+		config := SqlPermissionsProviderModel{
+			SQLLogin: createLoginObject(t, "sql_login", map[string]attr.Value{"username": types.StringValue("sa")}),
+		}
+		if diags := applyEnvironment(ctx, &config); diags.HasError() {
+			t.Fatalf("Expected no errors, got: %v", diags)
+		}
+
+		attributes := config.SQLLogin.Attributes()
+		if attributes["username"].(types.String).ValueString() != "sa" {
+			t.Errorf("Expected username from configuration, got %s", attributes["username"])
+		}
+		if attributes["password"].(types.String).ValueString() != "from-env" {
+			t.Errorf("Expected password from environment, got %s", attributes["password"])
+		}
+	})
+
+	t.Run("ConfiguredPasswordCommandIgnoresPassword", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envSQLPassword, "from-env")
+		t.Setenv(envSQLPasswordFile, "/run/secrets/sql")
+
+		config := SqlPermissionsProviderModel{
+			SQLLogin: createLoginObject(t, "sql_login", map[string]attr.Value{
+				"username":         types.StringValue("sa"),
+				"password_command": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("vault")}),
+			}),
+		}
+		applyEnvironment(ctx, &config)
+
+		if diags := validateSQLLogin(ctx, config.SQLLogin); diags.HasError() {
+			t.Errorf("Expected the environment passwords not to conflict with the configured command, got: %v", diags)
+		}
+	})
+
+	t.Run("ClientSecretFileInfersServicePrincipal", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envClientID, "client")
+		t.Setenv(envTenantID, "tenant")
+		t.Setenv(envClientSecretFilePath, "/run/secrets/client")
+
+		config := SqlPermissionsProviderModel{}
+		applyEnvironment(ctx, &config)
+
+		if config.SPNLogin.IsNull() {
+			t.Fatal("Expected spn_login to be inferred from the client secret file")
+		}
+		if got := config.SPNLogin.Attributes()["client_secret_file"].(types.String).ValueString(); got != "/run/secrets/client" {
+			t.Errorf("Expected client_secret_file from environment, got %s", got)
+		}
+		if diags := validateSPNLogin(ctx, config.SPNLogin); diags.HasError() {
+			t.Errorf("Expected no errors, got: %v", diags)
+		}
+	})
+
+	t.Run("ConfiguredCertificateIgnoresSecret", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envClientSecret, "secret")
+
+		config := SqlPermissionsProviderModel{
+			SPNLogin: createLoginObject(t, "spn_login", map[string]attr.Value{
+				"client_certificate_path": types.StringValue("/path/to/cert.pfx"),
+			}),
+		}
+		applyEnvironment(ctx, &config)
+
+		if diags := validateSPNLogin(ctx, config.SPNLogin); diags.HasError() {
+			t.Errorf("Expected the environment secret not to conflict with the configured certificate, got: %v", diags)
+		}
+	})
+
+	t.Run("EntraMethodOnlyUsesItsAttributes", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envEntraMethod, "ActiveDirectoryAzCli")
+		t.Setenv(envClientSecret, "secret")
+
+		config := SqlPermissionsProviderModel{}
+		applyEnvironment(ctx, &config)
+
+		if diags := validateEntraLogin(ctx, config.EntraLogin); diags.HasError() {
+			t.Errorf("Expected unrelated environment variables to be ignored, got: %v", diags)
+		}
+	})
+
+	t.Run("UserAssignedManagedIdentity", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envUseMSI, "true")
+		t.Setenv(envClientID, "client")
+
+		config := SqlPermissionsProviderModel{}
+		applyEnvironment(ctx, &config)
+
+		attributes := config.MSILogin.Attributes()
+		if !attributes["user_identity"].(types.Bool).ValueBool() {
+			t.Error("Expected user_identity to default to true when user_id is set")
+		}
+		if attributes["user_id"].(types.String).ValueString() != "client" {
+			t.Errorf("Expected user_id from environment, got %s", attributes["user_id"])
+		}
+	})
+}
+
+func TestApplyEnvironment_TLS(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("CreatedFromEnvironment", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envEncrypt, "strict")
+		t.Setenv(envHostNameInCertificate, "sql.internal")
+
+		config := SqlPermissionsProviderModel{}
+		if diags := applyEnvironment(ctx, &config); diags.HasError() {
+			t.Fatalf("Expected no errors, got: %v", diags)
+		}
+
+		attributes := config.TLS.Attributes()
+		if attributes["encrypt"].(types.String).ValueString() != "strict" {
+			t.Errorf("Expected encrypt from environment, got %s", attributes["encrypt"])
+		}
+		if attributes["host_name_in_certificate"].(types.String).ValueString() != "sql.internal" {
+			t.Errorf("Expected host_name_in_certificate from environment, got %s", attributes["host_name_in_certificate"])
+		}
+		if !attributes["trust_server_certificate"].IsNull() {
+			t.Errorf("Expected trust_server_certificate to stay unset, got %s", attributes["trust_server_certificate"])
+		}
+	})
+
+	t.Run("NotCreatedWithoutEnvironment", func(t *testing.T) {
+		clearProviderEnvironment(t)
+
+		config := SqlPermissionsProviderModel{}
+		applyEnvironment(ctx, &config)
+
+		if !config.TLS.IsNull() {
+			t.Errorf("Expected tls to stay unset, got %s", config.TLS)
+		}
+	})
+
+	t.Run("ConfigurationTakesPrecedence", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envEncrypt, "optional")
+		t.Setenv(envTrustServerCertificate, "true")
+
+		config := SqlPermissionsProviderModel{
+			TLS: createLoginObject(t, "tls", map[string]attr.Value{
+				"encrypt":                  types.StringValue("mandatory"),
+				"trust_server_certificate": types.BoolValue(false),
+			}),
+		}
+		applyEnvironment(ctx, &config)
+
+		attributes := config.TLS.Attributes()
+		if attributes["encrypt"].(types.String).ValueString() != "mandatory" {
+			t.Errorf("Expected encrypt from configuration, got %s", attributes["encrypt"])
+		}
+		if attributes["trust_server_certificate"].(types.Bool).ValueBool() {
+			t.Error("Expected trust_server_certificate from configuration")
+		}
+	})
+
+	t.Run("InvalidTrustServerCertificate", func(t *testing.T) {
+		clearProviderEnvironment(t)
+		t.Setenv(envTrustServerCertificate, "maybe")
+
+		config := SqlPermissionsProviderModel{}
+		diags := applyEnvironment(ctx, &config)
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid Environment Variable" {
+			t.Errorf("Expected 'Invalid Environment Variable' error, got: %v", diags)
+		}
+	})
+}
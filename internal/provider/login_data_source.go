@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-mssqlpermissions/internal/provider/model"
+	"terraform-provider-mssqlpermissions/internal/queries"
+	qmodel "terraform-provider-mssqlpermissions/internal/queries/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &loginDataSource{}
+	_ datasource.DataSourceWithConfigure = &loginDataSource{}
+)
+
+func NewLoginDataSource() datasource.DataSource {
+	return &loginDataSource{}
+}
+
+type loginDataSource struct {
+	connector *queries.Connector
+}
+
+// Metadata is a method that sets the metadata for the login data source.
+// It sets the TypeName field of the response to the concatenation of the ProviderTypeName from the request and "_login".
+func (d *loginDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_login"
+}
+
+// Schema defines the schema for the login data source.
+// The login is looked up by name and every other attribute is computed.
+func (d *loginDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Server login data source. Logins are read from the `master` database of the server.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description:         "The login name.",
+				MarkdownDescription: "The login name.",
+				Required:            true,
+			},
+			"external": schema.BoolAttribute{
+				Description:         "Is the login created from an external provider.",
+				MarkdownDescription: "Is the login created from an external provider.",
+				Computed:            true,
+			},
+			"principal_id": schema.Int64Attribute{
+				Description:         "The login principal id.",
+				MarkdownDescription: "The login principal id.",
+				Computed:            true,
+			},
+			"check_policy": schema.BoolAttribute{
+				Description:         "Are the Windows password policies enforced. Null for logins without a password.",
+				MarkdownDescription: "Are the Windows password policies enforced. Null for logins without a password.",
+				Computed:            true,
+			},
+			"check_expiration": schema.BoolAttribute{
+				Description:         "Is the password expiration policy enforced. Null for logins without a password.",
+				MarkdownDescription: "Is the password expiration policy enforced. Null for logins without a password.",
+				Computed:            true,
+			},
+			"default_database": schema.StringAttribute{
+				Description:         "The login default database.",
+				MarkdownDescription: "The login default database.",
+				Computed:            true,
+			},
+			"default_language": schema.StringAttribute{
+				Description:         "The login default language.",
+				MarkdownDescription: "The login default language.",
+				Computed:            true,
+			},
+			"sid": schema.StringAttribute{
+				Description:         "The login SID.",
+				MarkdownDescription: "The login SID.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure is called by the framework to pass provider-level configuration to the data source.
+func (d *loginDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*queries.Connector)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			"Expected *queries.Connector, got something else. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.connector = connector.ForDatabase(masterDatabase)
+}
+
+// Read is a method that reads the login data source.
+func (d *loginDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var state model.LoginDataModel
+	var err error
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to the master database using the shared connection pool.
+	tflog.Debug(ctx, "loginDataSource: connect to the database")
+	db, err := connectToDatabase(ctx, d.connector)
+
+	if err != nil {
+		if !handleAuthenticationError(ctx, err, &resp.Diagnostics) {
+			resp.Diagnostics.AddError("Error connecting to the database", err.Error())
+		}
+		return
+	}
+
+	login := &qmodel.Login{
+		Name: state.Name.ValueString(),
+	}
+
+	tflog.Debug(ctx, "loginDataSource: get the login")
+	login, err = d.connector.GetLogin(ctx, db, login)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting login", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "loginDataSource: populate the state object (model.LoginDataModel)")
+	state.Name = types.StringValue(login.Name)
+	state.External = types.BoolValue(login.External)
+	state.PrincipalID = types.Int64Value(login.PrincipalID)
+	state.CheckPolicy = types.BoolPointerValue(login.CheckPolicy)
+	state.CheckExpiration = types.BoolPointerValue(login.CheckExpiration)
+	state.DefaultDatabase = types.StringValue(login.DefaultDatabase)
+	state.DefaultLanguage = types.StringValue(login.DefaultLanguage)
+	state.SID = types.StringValue(login.SID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
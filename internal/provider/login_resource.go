@@ -0,0 +1,488 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-mssqlpermissions/internal/provider/model"
+	"terraform-provider-mssqlpermissions/internal/queries"
+	qmodel "terraform-provider-mssqlpermissions/internal/queries/model"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// masterDatabase is the database holding the server logins.
+const masterDatabase = "master"
+
+var _ resource.Resource = &LoginResource{}
+var _ resource.ResourceWithImportState = &LoginResource{}
+var _ resource.ResourceWithConfigure = &LoginResource{}
+
+func NewLoginResource() resource.Resource {
+	return &LoginResource{}
+}
+
+type LoginResource struct {
+	connector *queries.Connector
+}
+
+// Configure is called by the framework to pass provider-level configuration to the resource.
+// Logins live in the master database, so the resource uses a connector to master sharing the provider settings.
+func (r *LoginResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*queries.Connector)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *queries.Connector, got something else. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.connector = connector.ForDatabase(masterDatabase)
+}
+
+// Metadata is a method that sets the metadata for the LoginResource.
+// It sets the TypeName field of the response to the concatenation of the ProviderTypeName from the request and "_login".
+func (r *LoginResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_login"
+}
+
+// Schema is a method that sets the schema for the LoginResource.
+// The attributes include the login name, password, external flag, object id, password policy flags,
+// default database, default language, principal id, and SID.
+func (r *LoginResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Server login resource. Logins are managed in the `master` database of the server.",
+
+		Attributes: map[string]schema.Attribute{
+
+			"name": schema.StringAttribute{
+				Description:         "The login name.",
+				MarkdownDescription: "The login name.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description:         "The login password. Required for SQL logins.",
+				MarkdownDescription: "The login password. Required for SQL logins.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"external": schema.BoolAttribute{
+				Description:         "Is the login created FROM EXTERNAL PROVIDER (Microsoft Entra ID). Supported on Azure SQL Managed Instance and Azure SQL Database.",
+				MarkdownDescription: "Is the login created `FROM EXTERNAL PROVIDER` (Microsoft Entra ID). Supported on Azure SQL Managed Instance and Azure SQL Database.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"object_id": schema.StringAttribute{
+				Description:         "The Microsoft Entra ID object id of an external login.",
+				MarkdownDescription: "The Microsoft Entra ID object id of an external login.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principal_id": schema.Int64Attribute{
+				Description:         "The login principal id.",
+				MarkdownDescription: "The login principal id.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"check_policy": schema.BoolAttribute{
+				Description:         "Enforce the Windows password policies on the SQL login. Not supported on Azure SQL Database.",
+				MarkdownDescription: "Enforce the Windows password policies on the SQL login. Not supported on Azure SQL Database.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"check_expiration": schema.BoolAttribute{
+				Description:         "Enforce the password expiration policy on the SQL login. Requires check_policy, and is turned off along with it when not set. Not supported on Azure SQL Database.",
+				MarkdownDescription: "Enforce the password expiration policy on the SQL login. Requires `check_policy`, and is turned off along with it when not set. Not supported on Azure SQL Database.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+					checkExpirationFollowsPolicy{},
+				},
+			},
+			"default_database": schema.StringAttribute{
+				Description:         "The login default database. Not supported on Azure SQL Database.",
+				MarkdownDescription: "The login default database. Not supported on Azure SQL Database.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_language": schema.StringAttribute{
+				Description:         "The login default language. Not supported on Azure SQL Database.",
+				MarkdownDescription: "The login default language. Not supported on Azure SQL Database.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sid": schema.StringAttribute{
+				Description:         "The login SID.",
+				MarkdownDescription: "The login SID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// checkExpirationFollowsPolicy plans an unset check_expiration off when check_policy is planned off, as SQL Server
+// cannot disable the policy of a login without disabling its expiration check.
+type checkExpirationFollowsPolicy struct{}
+
+func (m checkExpirationFollowsPolicy) Description(_ context.Context) string {
+	return "check_expiration is turned off along with check_policy when it is not set."
+}
+
+func (m checkExpirationFollowsPolicy) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m checkExpirationFollowsPolicy) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var checkPolicy types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("check_policy"), &checkPolicy)...)
+	if !checkPolicy.IsNull() && !checkPolicy.IsUnknown() && !checkPolicy.ValueBool() {
+		resp.PlanValue = types.BoolValue(false)
+	}
+}
+
+// boolPointer returns nil for a null or unknown value, letting the server apply its default.
+func boolPointer(value types.Bool) *bool {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return value.ValueBoolPointer()
+}
+
+// populateLoginState copies the login retrieved from the server into the resource state.
+// The password and object id cannot be read back and are kept from the state.
+func populateLoginState(state *model.LoginResourceModel, login *qmodel.Login) {
+	state.Name = types.StringValue(login.Name)
+	state.External = types.BoolValue(login.External)
+	state.PrincipalID = types.Int64Value(login.PrincipalID)
+	state.CheckPolicy = types.BoolPointerValue(login.CheckPolicy)
+	state.CheckExpiration = types.BoolPointerValue(login.CheckExpiration)
+	state.DefaultDatabase = types.StringValue(login.DefaultDatabase)
+	state.DefaultLanguage = types.StringValue(login.DefaultLanguage)
+	state.SID = types.StringValue(login.SID)
+}
+
+// Create creates a new server login.
+// The method retrieves the plan, connects to the master database, creates the login, and populates the state with the created login's details.
+// If any errors occur during the process, they are added to the response's diagnostics.
+func (r *LoginResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+
+	var state model.LoginResourceModel
+	var err error
+
+	logResourceOperation(ctx, "Login", "Create")
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Bound the whole operation by the create timeout of the resource
+	createTimeout, timeoutDiags := state.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Use the provider connector to master
+	connector := r.connector
+
+	// Connect to database using proper context
+	db, err := connectToDatabase(ctx, connector)
+	if err != nil {
+		handleDatabaseConnectionError(ctx, err, &resp.Diagnostics)
+		return
+	}
+
+	login := &qmodel.Login{
+		Name:            state.Name.ValueString(),
+		Password:        state.Password.ValueString(),
+		External:        state.External.ValueBool(),
+		ObjectID:        state.ObjectID.ValueString(),
+		CheckPolicy:     boolPointer(state.CheckPolicy),
+		CheckExpiration: boolPointer(state.CheckExpiration),
+		DefaultDatabase: state.DefaultDatabase.ValueString(),
+		DefaultLanguage: state.DefaultLanguage.ValueString(),
+	}
+
+	tflog.Debug(ctx, "Creating login")
+	err = connector.CreateLogin(ctx, db, login)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating login", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieving created login")
+	login, err = connector.GetLogin(ctx, db, login)
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving the created login", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "Populating login state")
+	populateLoginState(&state, login)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	logResourceOperationComplete(ctx, "Login", "Create")
+}
+
+// Delete drops the server login.
+// If any errors occur during the process, they are added to the response's diagnostics.
+func (r *LoginResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state model.LoginResourceModel
+	var err error
+
+	logResourceOperation(ctx, "Login", "Delete")
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Bound the whole operation by the delete timeout of the resource
+	deleteTimeout, timeoutDiags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Use the provider connector to master
+	connector := r.connector
+
+	// Connect to database using proper context
+	db, err := connectToDatabase(ctx, connector)
+	if err != nil {
+		handleDatabaseConnectionError(ctx, err, &resp.Diagnostics)
+		return
+	}
+
+	login := &qmodel.Login{
+		Name: state.Name.ValueString(),
+	}
+
+	tflog.Debug(ctx, "Deleting login")
+	err = connector.DeleteLogin(ctx, db, login)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting login", err.Error())
+		return
+	}
+
+	logResourceOperationComplete(ctx, "Login", "Delete")
+}
+
+// Read refreshes the state with the server login.
+// The login is removed from the state when it no longer exists on the server.
+func (r *LoginResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state model.LoginResourceModel
+	var err error
+
+	logResourceOperation(ctx, "Login", "Read")
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Bound the whole operation by the read timeout of the resource
+	readTimeout, timeoutDiags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Use the provider connector to master
+	connector := r.connector
+
+	// Connect to database using proper context
+	db, err := connectToDatabase(ctx, connector)
+	if err != nil {
+		handleDatabaseConnectionError(ctx, err, &resp.Diagnostics)
+		return
+	}
+
+	login := &qmodel.Login{
+		Name:     state.Name.ValueString(),
+		ObjectID: state.ObjectID.ValueString(),
+	}
+
+	tflog.Debug(ctx, "Reading login from database")
+	login, err = connector.GetLogin(ctx, db, login)
+
+	// Use the centralized error handling logic
+	errorResult := HandleLoginReadError(err)
+	if errorResult.ShouldRemoveFromState {
+		tflog.Debug(ctx, "Login not found on the server, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if errorResult.ShouldAddError {
+		resp.Diagnostics.AddError(errorResult.ErrorMessage, err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "Populating login state")
+	populateLoginState(&state, login)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	logResourceOperationComplete(ctx, "Login", "Read")
+}
+
+// Update alters the server login.
+// Only the settings changed since the last apply are sent, so an unchanged password is not reset.
+// If any errors occur during the process, they are added to the response's diagnostics.
+func (r *LoginResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+
+	var plan, state model.LoginResourceModel
+	var err error
+
+	logResourceOperation(ctx, "Login", "Update")
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Bound the whole operation by the update timeout of the resource
+	updateTimeout, timeoutDiags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Use the provider connector to master
+	connector := r.connector
+
+	// Connect to database using proper context
+	db, err := connectToDatabase(ctx, connector)
+	if err != nil {
+		handleDatabaseConnectionError(ctx, err, &resp.Diagnostics)
+		return
+	}
+
+	login := &qmodel.Login{
+		Name:     plan.Name.ValueString(),
+		External: plan.External.ValueBool(),
+		ObjectID: plan.ObjectID.ValueString(),
+	}
+	if !plan.Password.Equal(state.Password) {
+		login.Password = plan.Password.ValueString()
+	}
+	if !plan.CheckPolicy.Equal(state.CheckPolicy) {
+		login.CheckPolicy = boolPointer(plan.CheckPolicy)
+	}
+	if !plan.CheckExpiration.Equal(state.CheckExpiration) {
+		login.CheckExpiration = boolPointer(plan.CheckExpiration)
+	}
+	if !plan.DefaultDatabase.Equal(state.DefaultDatabase) {
+		login.DefaultDatabase = plan.DefaultDatabase.ValueString()
+	}
+	if !plan.DefaultLanguage.Equal(state.DefaultLanguage) {
+		login.DefaultLanguage = plan.DefaultLanguage.ValueString()
+	}
+
+	tflog.Debug(ctx, "Updating login")
+	err = connector.UpdateLogin(ctx, db, login)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating login", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "Retrieving updated login")
+	login, err = connector.GetLogin(ctx, db, login)
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving the updated login", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "Populating updated login state")
+	populateLoginState(&plan, login)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	logResourceOperationComplete(ctx, "Login", "Update")
+}
+
+// ImportState imports an existing server login by name.
+// The password of a SQL login cannot be read back and must be set in the configuration after import.
+func (r *LoginResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccLoginResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccLoginResourceConfig("tf_login", "P@ssw0rd1", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mssqlpermissions_login.test", "name", "tf_login"),
+					resource.TestCheckResourceAttr("mssqlpermissions_login.test", "external", "false"),
+					resource.TestCheckResourceAttr("mssqlpermissions_login.test", "check_policy", "true"),
+					resource.TestCheckResourceAttr("mssqlpermissions_login.test", "check_expiration", "false"),
+					resource.TestCheckResourceAttr("mssqlpermissions_login.test", "default_database", "master"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "mssqlpermissions_login.test",
+				ImportState:                          true,
+				ImportStateId:                        "tf_login",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerifyIgnore:              []string{"password"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccLoginResourceConfig("tf_login", "P@ssw0rd2", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mssqlpermissions_login.test", "password", "P@ssw0rd2"),
+					resource.TestCheckResourceAttr("mssqlpermissions_login.test", "check_expiration", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccLoginResourceConfig(name string, password string, checkExpiration bool) string {
+	return fmt.Sprintf(`
+provider "mssqlpermissions" {
+	server_fqdn   = %q
+	server_port   = %q
+	database_name = "ApplicationDB"
+
+	sql_login = {
+		username = "sa"
+		password = "P@ssw0rd"
+	}
+}
+
+resource "mssqlpermissions_login" "test" {
+	name             = %q
+	password         = %q
+	check_policy     = true
+	check_expiration = %t
+	default_database = "master"
+}
+`, os.Getenv("LOCAL_SQL_HOST"), os.Getenv("LOCAL_SQL_PORT"), name, password, checkExpiration)
+}
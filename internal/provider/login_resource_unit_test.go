@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCheckExpirationFollowsPolicy_unit(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		checkPolicy types.Bool
+		config      types.Bool
+		planned     types.Bool
+		want        types.Bool
+	}{
+		{"PolicyOff", types.BoolValue(false), types.BoolNull(), types.BoolValue(true), types.BoolValue(false)},
+		{"PolicyOn", types.BoolValue(true), types.BoolNull(), types.BoolValue(true), types.BoolValue(true)},
+		{"PolicyUnset", types.BoolNull(), types.BoolNull(), types.BoolValue(true), types.BoolValue(true)},
+		{"ExpirationSet", types.BoolValue(false), types.BoolValue(true), types.BoolValue(true), types.BoolValue(true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newImportStateResponse(NewLoginResource()).State
+			if diags := state.SetAttribute(ctx, path.Root("check_policy"), tt.checkPolicy); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+
+			resp := &planmodifier.BoolResponse{PlanValue: tt.planned}
+			checkExpirationFollowsPolicy{}.PlanModifyBool(ctx, planmodifier.BoolRequest{Plan: plan, ConfigValue: tt.config, PlanValue: tt.planned}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if !resp.PlanValue.Equal(tt.want) {
+				t.Errorf("expected check_expiration to be planned %v, got %v", tt.want, resp.PlanValue)
+			}
+		})
+	}
+}
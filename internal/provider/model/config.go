@@ -21,23 +21,58 @@ type ConfigModel struct {
 	SPNLogin       types.Object `tfsdk:"spn_login"`
 	MSILogin       types.Object `tfsdk:"msi_login"`
 	FederatedLogin types.Object `tfsdk:"federated_login"`
+	EntraLogin     types.Object `tfsdk:"entra_login"`
+
+	ConnectionString types.String `tfsdk:"connection_string"`
+
+	AccessToken     types.String `tfsdk:"access_token"`
+	AccessTokenFile types.String `tfsdk:"access_token_file"`
+
+	InstanceName        types.String `tfsdk:"instance_name"`
+	FailoverPartner     types.String `tfsdk:"failover_partner"`
+	MultiSubnetFailover types.Bool   `tfsdk:"multi_subnet_failover"`
+
+	ExecuteAsUser types.String `tfsdk:"execute_as_user"`
+
+	ApplicationName types.String `tfsdk:"application_name"`
+	WorkstationID   types.String `tfsdk:"workstation_id"`
+	SessionContext  types.Map    `tfsdk:"session_context"`
+
+	MaxOpenConnections    types.Int64  `tfsdk:"max_open_connections"`
+	MaxIdleConnections    types.Int64  `tfsdk:"max_idle_connections"`
+	ConnectionMaxLifetime types.String `tfsdk:"connection_max_lifetime"`
+	ConnectTimeout        types.String `tfsdk:"connect_timeout"`
+	StatementTimeout      types.String `tfsdk:"statement_timeout"`
+
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryMinBackoff types.String `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff types.String `tfsdk:"retry_max_backoff"`
+
+	TLS types.Object `tfsdk:"tls"`
 }
 
 // SQLLoginModel represents the SQL login model for the provider.
 // It contains the necessary fields to configure the SQL login credentials,
-// including the username and password.
+// including the username and a password given inline, read from a file or printed by a command.
 type SQLLoginModel struct {
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Username        types.String `tfsdk:"username"`
+	Password        types.String `tfsdk:"password"`
+	PasswordFile    types.String `tfsdk:"password_file"`
+	PasswordCommand types.List   `tfsdk:"password_command"`
 }
 
 // SPNLoginModel represents the SPN login model for the provider.
 // It contains the necessary fields to configure the SPN login credentials,
-// including the client ID, client secret, and tenant ID.
+// including the client ID, tenant ID, and either a client secret or a client certificate.
 type SPNLoginModel struct {
-	ClientID     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
-	TenantID     types.String `tfsdk:"tenant_id"`
+	ClientID                  types.String `tfsdk:"client_id"`
+	ClientSecret              types.String `tfsdk:"client_secret"`
+	ClientSecretFile          types.String `tfsdk:"client_secret_file"`
+	ClientSecretCommand       types.List   `tfsdk:"client_secret_command"`
+	ClientCertificatePath     types.String `tfsdk:"client_certificate_path"`
+	ClientCertificate         types.String `tfsdk:"client_certificate"`
+	ClientCertificatePassword types.String `tfsdk:"client_certificate_password"`
+	TenantID                  types.String `tfsdk:"tenant_id"`
 }
 
 // MSILoginModel represents the MSI login model for the provider.
@@ -50,4 +85,34 @@ type MSILoginModel struct {
 }
 
 // FederatedLoginModel represents the federated login model for the provider.
-type FederatedLoginModel struct{}
+// It contains the necessary fields to configure workload identity federation,
+// including the client ID, tenant ID, and the OIDC token or the file containing it.
+type FederatedLoginModel struct {
+	ClientID          types.String `tfsdk:"client_id"`
+	TenantID          types.String `tfsdk:"tenant_id"`
+	OIDCToken         types.String `tfsdk:"oidc_token"`
+	OIDCTokenFilePath types.String `tfsdk:"oidc_token_file_path"`
+}
+
+// EntraLoginModel represents the Entra ID login model for the provider.
+// It contains the authentication method and the credentials it requires,
+// which depend on the selected method.
+type EntraLoginModel struct {
+	Method       types.String `tfsdk:"method"`
+	ClientID     types.String `tfsdk:"client_id"`
+	TenantID     types.String `tfsdk:"tenant_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Username     types.String `tfsdk:"username"`
+	Password     types.String `tfsdk:"password"`
+	ResourceID   types.String `tfsdk:"resource_id"`
+}
+
+// TLSModel represents the encryption settings model for the provider.
+// It contains the encryption mode and the settings used to verify the server certificate.
+type TLSModel struct {
+	Encrypt                types.String `tfsdk:"encrypt"`
+	TrustServerCertificate types.Bool   `tfsdk:"trust_server_certificate"`
+	CACertificatePath      types.String `tfsdk:"ca_certificate_path"`
+	HostNameInCertificate  types.String `tfsdk:"host_name_in_certificate"`
+	MinTLSVersion          types.String `tfsdk:"min_tls_version"`
+}
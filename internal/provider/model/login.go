@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// LoginDataModel is the model for the login data source.
+type LoginDataModel struct {
+	Name            types.String `tfsdk:"name"`
+	External        types.Bool   `tfsdk:"external"`
+	PrincipalID     types.Int64  `tfsdk:"principal_id"`
+	CheckPolicy     types.Bool   `tfsdk:"check_policy"`
+	CheckExpiration types.Bool   `tfsdk:"check_expiration"`
+	DefaultDatabase types.String `tfsdk:"default_database"`
+	DefaultLanguage types.String `tfsdk:"default_language"`
+	SID             types.String `tfsdk:"sid"`
+}
+
+// LoginResourceModel is the model for the login resource.
+// It contains the necessary fields to configure the server login.
+type LoginResourceModel struct {
+	Name            types.String   `tfsdk:"name"`
+	Password        types.String   `tfsdk:"password"`
+	External        types.Bool     `tfsdk:"external"`
+	ObjectID        types.String   `tfsdk:"object_id"`
+	PrincipalID     types.Int64    `tfsdk:"principal_id"`
+	CheckPolicy     types.Bool     `tfsdk:"check_policy"`
+	CheckExpiration types.Bool     `tfsdk:"check_expiration"`
+	DefaultDatabase types.String   `tfsdk:"default_database"`
+	DefaultLanguage types.String   `tfsdk:"default_language"`
+	SID             types.String   `tfsdk:"sid"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PasswordEphemeralModel is the model for the password ephemeral resource.
+type PasswordEphemeralModel struct {
+	Length            types.Int64  `tfsdk:"length"`
+	Lower             types.Bool   `tfsdk:"lower"`
+	Upper             types.Bool   `tfsdk:"upper"`
+	Numeric           types.Bool   `tfsdk:"numeric"`
+	Special           types.Bool   `tfsdk:"special"`
+	ExcludeCharacters types.String `tfsdk:"exclude_characters"`
+	UserName          types.String `tfsdk:"user_name"`
+	Result            types.String `tfsdk:"result"`
+}
@@ -5,6 +5,7 @@
 package model
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -25,6 +26,14 @@ type PermissionModel struct {
 
 // PermissionResourceModel is the model for the permission resource.
 type PermissionResourceModel struct {
-	Permissions types.List   `tfsdk:"permissions"`
-	RoleName    types.String `tfsdk:"role_name"`
+	Permissions  types.List   `tfsdk:"permissions"`
+	RoleName     types.String `tfsdk:"role_name"`
+	DatabaseName types.String `tfsdk:"database_name"`
+}
+
+// PermissionResourceWithTimeoutsModel is the model for the permissions resource.
+// It adds the operation timeouts to the model shared with the data source.
+type PermissionResourceWithTimeoutsModel struct {
+	PermissionResourceModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
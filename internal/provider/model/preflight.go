@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PreflightCheckModel is the model for a permission check included in the preflight_checks data source.
+type PreflightCheckModel struct {
+	ResourceType   types.String `tfsdk:"resource_type"`
+	Permission     types.String `tfsdk:"permission"`
+	SecurableClass types.String `tfsdk:"securable_class"`
+	Granted        types.Bool   `tfsdk:"granted"`
+}
+
+// PreflightChecksDataModel is the model for the preflight_checks data source.
+type PreflightChecksDataModel struct {
+	DatabaseName       types.String          `tfsdk:"database_name"`
+	ResourceTypes      []types.String        `tfsdk:"resource_types"`
+	Checks             []PreflightCheckModel `tfsdk:"checks"`
+	MissingPermissions []types.String        `tfsdk:"missing_permissions"`
+	Passed             types.Bool            `tfsdk:"passed"`
+}
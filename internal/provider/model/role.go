@@ -5,18 +5,22 @@
 package model
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // RoleModel is the model for the role resource.
 // It contains the necessary fields to configure the role.
 type RoleModel struct {
-	Name            types.String `tfsdk:"name"`
-	PrincipalID     types.Int64  `tfsdk:"principal_id"`
-	Type            types.String `tfsdk:"type"`
-	TypeDescription types.String `tfsdk:"type_description"`
-	OwningPrincipal types.String `tfsdk:"owning_principal"`
-	IsFixedRole     types.Bool   `tfsdk:"is_fixed_role"`
+	Name            types.String   `tfsdk:"name"`
+	PrincipalID     types.Int64    `tfsdk:"principal_id"`
+	Type            types.String   `tfsdk:"type"`
+	TypeDescription types.String   `tfsdk:"type_description"`
+	OwningPrincipal types.String   `tfsdk:"owning_principal"`
+	IsFixedRole     types.Bool     `tfsdk:"is_fixed_role"`
+	SID             types.String   `tfsdk:"sid"`
+	DatabaseName    types.String   `tfsdk:"database_name"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 // RoleDataSourceModel is the model for the role data source.
@@ -29,4 +33,5 @@ type RoleDataSourceModel struct {
 	TypeDescription types.String `tfsdk:"type_description"`
 	OwningPrincipal types.String `tfsdk:"owning_principal"`
 	IsFixedRole     types.Bool   `tfsdk:"is_fixed_role"`
+	DatabaseName    types.String `tfsdk:"database_name"`
 }
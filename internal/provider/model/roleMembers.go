@@ -5,12 +5,21 @@
 package model
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // RoleMembersModel is the model for the role resource.
 // It contains the necessary fields to configure the role.
 type RoleMembersModel struct {
-	Name    types.String `tfsdk:"name"`
-	Members types.List   `tfsdk:"members"`
+	Name         types.String `tfsdk:"name"`
+	Members      types.List   `tfsdk:"members"`
+	DatabaseName types.String `tfsdk:"database_name"`
+}
+
+// RoleMembersWithTimeoutsModel is the model for the role members resource.
+// It adds the operation timeouts to the model shared with the data source.
+type RoleMembersWithTimeoutsModel struct {
+	RoleMembersModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
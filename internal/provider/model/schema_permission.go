@@ -5,13 +5,22 @@
 package model
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // SchemaPermissionResourceModel is the model for the schema permission resource.
 // It extends the standard permission model to include schema-specific context.
 type SchemaPermissionResourceModel struct {
-	SchemaName  types.String `tfsdk:"schema_name"`
-	RoleName    types.String `tfsdk:"role_name"`
-	Permissions types.List   `tfsdk:"permissions"`
+	SchemaName   types.String `tfsdk:"schema_name"`
+	RoleName     types.String `tfsdk:"role_name"`
+	Permissions  types.List   `tfsdk:"permissions"`
+	DatabaseName types.String `tfsdk:"database_name"`
+}
+
+// SchemaPermissionResourceWithTimeoutsModel is the model for the schema permissions resource.
+// It adds the operation timeouts to the model shared with the data source.
+type SchemaPermissionResourceWithTimeoutsModel struct {
+	SchemaPermissionResourceModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
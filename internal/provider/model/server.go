@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ServerInfoDataModel is the model for the server_info data source.
+type ServerInfoDataModel struct {
+	DatabaseName                    types.String `tfsdk:"database_name"`
+	ServerName                      types.String `tfsdk:"server_name"`
+	Version                         types.String `tfsdk:"version"`
+	ProductVersion                  types.String `tfsdk:"product_version"`
+	MajorVersion                    types.Int64  `tfsdk:"major_version"`
+	MinorVersion                    types.Int64  `tfsdk:"minor_version"`
+	BuildNumber                     types.Int64  `tfsdk:"build_number"`
+	Edition                         types.String `tfsdk:"edition"`
+	EngineEdition                   types.Int64  `tfsdk:"engine_edition"`
+	EngineEditionName               types.String `tfsdk:"engine_edition_name"`
+	IsAzure                         types.Bool   `tfsdk:"is_azure"`
+	Containment                     types.String `tfsdk:"containment"`
+	CompatibilityLevel              types.Int64  `tfsdk:"compatibility_level"`
+	Collation                       types.String `tfsdk:"collation"`
+	DefaultLanguage                 types.String `tfsdk:"default_language"`
+	ContainedDatabaseAuthentication types.Bool   `tfsdk:"contained_database_authentication"`
+	DefaultLanguageSupported        types.Bool   `tfsdk:"default_language_supported"`
+	LoginName                       types.String `tfsdk:"login_name"`
+	UserName                        types.String `tfsdk:"user_name"`
+}
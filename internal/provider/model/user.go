@@ -5,6 +5,7 @@
 package model
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -16,18 +17,29 @@ type UserDataModel struct {
 	DefaultSchema   types.String `tfsdk:"default_schema"`
 	DefaultLanguage types.String `tfsdk:"default_language"`
 	ObjectID        types.String `tfsdk:"object_id"`
+	LoginName       types.String `tfsdk:"login_name"`
 	SID             types.String `tfsdk:"sid"`
+	DatabaseName    types.String `tfsdk:"database_name"`
 }
 
 // UserResourceModel is the model for the user resource.
 // It contains the necessary fields to configure the user.
 type UserResourceModel struct {
-	Name            types.String `tfsdk:"name"`
-	Password        types.String `tfsdk:"password"`
-	External        types.Bool   `tfsdk:"external"`
-	PrincipalID     types.Int64  `tfsdk:"principal_id"`
-	DefaultSchema   types.String `tfsdk:"default_schema"`
-	DefaultLanguage types.String `tfsdk:"default_language"`
-	ObjectID        types.String `tfsdk:"object_id"`
-	SID             types.String `tfsdk:"sid"`
+	Name              types.String   `tfsdk:"name"`
+	Password          types.String   `tfsdk:"password"`
+	PasswordWO        types.String   `tfsdk:"password_wo"`
+	PasswordVersion   types.Int64    `tfsdk:"password_wo_version"`
+	OldPasswordWO     types.String   `tfsdk:"old_password_wo"`
+	RotationTrigger   types.String   `tfsdk:"rotation_trigger"`
+	RotateAfter       types.String   `tfsdk:"rotate_after"`
+	PasswordRotatedAt types.String   `tfsdk:"password_rotated_at"`
+	External          types.Bool     `tfsdk:"external"`
+	PrincipalID       types.Int64    `tfsdk:"principal_id"`
+	DefaultSchema     types.String   `tfsdk:"default_schema"`
+	DefaultLanguage   types.String   `tfsdk:"default_language"`
+	ObjectID          types.String   `tfsdk:"object_id"`
+	LoginName         types.String   `tfsdk:"login_name"`
+	SID               types.String   `tfsdk:"sid"`
+	DatabaseName      types.String   `tfsdk:"database_name"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-mssqlpermissions/internal/provider/model"
+	"terraform-provider-mssqlpermissions/internal/queries"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource                   = &passwordEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &passwordEphemeralResource{}
+)
+
+func NewPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &passwordEphemeralResource{}
+}
+
+// passwordEphemeralResource generates passwords without a database connection.
+type passwordEphemeralResource struct{}
+
+// Metadata sets the type name of the password ephemeral resource.
+func (e *passwordEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_password"
+}
+
+// Schema defines the policy of the generated password. Every character class is enabled by default.
+func (e *passwordEphemeralResource) Schema(_ context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Password ephemeral resource. Generates a password meeting the SQL Server complexity rules, for the `password_wo` attribute of `mssqlpermissions_user` and the write-only attributes of secret stores. " +
+			"Passwords are generated here rather than by `mssqlpermissions_user`, which could only return them through its state. " +
+			"The password is never stored in the plan or state, so it cannot be kept from one run to the next: a new one is generated on every run, and the user only takes it on the runs its `password_wo_version`, `rotation_trigger` or `rotate_after` rotate the password. " +
+			"To hand the password over, write it in the same run to a write-only attribute versioned by the `password_rotated_at` of the user, which only changes on those runs. Requires Terraform 1.10 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"length": schema.Int64Attribute{
+				Description:         fmt.Sprintf("The length of the password, between 8 and 128. Defaults to %d.", queries.DefaultPasswordLength),
+				MarkdownDescription: fmt.Sprintf("The length of the password, between 8 and 128. Defaults to `%d`.", queries.DefaultPasswordLength),
+				Optional:            true,
+			},
+			"lower": schema.BoolAttribute{
+				Description:         "Include lowercase letters. Defaults to true.",
+				MarkdownDescription: "Include lowercase letters. Defaults to `true`.",
+				Optional:            true,
+			},
+			"upper": schema.BoolAttribute{
+				Description:         "Include uppercase letters. Defaults to true.",
+				MarkdownDescription: "Include uppercase letters. Defaults to `true`.",
+				Optional:            true,
+			},
+			"numeric": schema.BoolAttribute{
+				Description:         "Include digits. Defaults to true.",
+				MarkdownDescription: "Include digits. Defaults to `true`.",
+				Optional:            true,
+			},
+			"special": schema.BoolAttribute{
+				Description:         "Include symbols. Defaults to true.",
+				MarkdownDescription: "Include symbols. Defaults to `true`.",
+				Optional:            true,
+			},
+			"exclude_characters": schema.StringAttribute{
+				Description:         fmt.Sprintf("The characters never used in the password. Defaults to %s, which break connection strings.", queries.DefaultExcludedPasswordCharacters),
+				MarkdownDescription: fmt.Sprintf("The characters never used in the password. Defaults to `%s`, which break connection strings.", queries.DefaultExcludedPasswordCharacters),
+				Optional:            true,
+			},
+			"user_name": schema.StringAttribute{
+				Description:         "The name of the user or login the password is for. SQL Server rejects a password containing it, so the password never does.",
+				MarkdownDescription: "The name of the user or login the password is for. SQL Server rejects a password containing it, so the password never does.",
+				Required:            true,
+			},
+			"result": schema.StringAttribute{
+				Description:         "The generated password.",
+				MarkdownDescription: "The generated password.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+// passwordPolicy returns the policy of the configuration, with the defaults of the unset attributes.
+func passwordPolicy(config model.PasswordEphemeralModel) queries.PasswordPolicy {
+	policy := queries.PasswordPolicy{
+		Length:            queries.DefaultPasswordLength,
+		Lower:             config.Lower.IsNull() || config.Lower.ValueBool(),
+		Upper:             config.Upper.IsNull() || config.Upper.ValueBool(),
+		Numeric:           config.Numeric.IsNull() || config.Numeric.ValueBool(),
+		Special:           config.Special.IsNull() || config.Special.ValueBool(),
+		ExcludeCharacters: queries.DefaultExcludedPasswordCharacters,
+	}
+	if !config.Length.IsNull() {
+		policy.Length = int(config.Length.ValueInt64())
+	}
+	if !config.ExcludeCharacters.IsNull() {
+		policy.ExcludeCharacters = config.ExcludeCharacters.ValueString()
+	}
+
+	return policy
+}
+
+// ValidateConfig checks the length of the password, as far as it is known.
+func (e *passwordEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var config model.PasswordEphemeralModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Length.IsNull() && !config.Length.IsUnknown() && (config.Length.ValueInt64() < 8 || config.Length.ValueInt64() > 128) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("length"),
+			"Invalid Password Length",
+			fmt.Sprintf("The length of a SQL Server password must be between 8 and 128, got %d.", config.Length.ValueInt64()),
+		)
+	}
+}
+
+// Open generates a password meeting the SQL Server complexity rules for the user.
+func (e *passwordEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config model.PasswordEphemeralModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password, err := queries.GeneratePassword(passwordPolicy(config), config.UserName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Generating Password", err.Error())
+		return
+	}
+
+	config.Result = types.StringValue(password)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newPasswordConfig returns a password ephemeral resource configuration holding the given attribute values.
+func newPasswordConfig(values map[string]tftypes.Value) tfsdk.Config {
+	ctx := context.Background()
+
+	schemaResp := &ephemeral.SchemaResponse{}
+	NewPasswordEphemeralResource().Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+}
+
+func TestPasswordEphemeralResource_Open(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		values      map[string]tftypes.Value
+		wantLength  int
+		wantSummary string
+	}{
+		{
+			name: "Defaults",
+			values: map[string]tftypes.Value{
+				"user_name": tftypes.NewValue(tftypes.String, "app_user"),
+			},
+			wantLength: 32,
+		},
+		{
+			name: "Policy",
+			values: map[string]tftypes.Value{
+				"length":    tftypes.NewValue(tftypes.Number, 16),
+				"special":   tftypes.NewValue(tftypes.Bool, false),
+				"user_name": tftypes.NewValue(tftypes.String, "app_user"),
+			},
+			wantLength: 16,
+		},
+		{
+			name: "TooFewClasses",
+			values: map[string]tftypes.Value{
+				"upper":     tftypes.NewValue(tftypes.Bool, false),
+				"special":   tftypes.NewValue(tftypes.Bool, false),
+				"user_name": tftypes.NewValue(tftypes.String, "app_user"),
+			},
+			wantSummary: "Error Generating Password",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newPasswordConfig(tt.values)
+			resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: config.Schema, Raw: config.Raw.Copy()}}
+
+			NewPasswordEphemeralResource().Open(ctx, ephemeral.OpenRequest{Config: config}, resp)
+
+			if tt.wantSummary != "" {
+				if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != tt.wantSummary {
+					t.Errorf("Expected a single %q error, got %v", tt.wantSummary, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var result types.String
+			resp.Result.GetAttribute(ctx, path.Root("result"), &result)
+			if len(result.ValueString()) != tt.wantLength {
+				t.Errorf("Expected a password of %d characters, got %d", tt.wantLength, len(result.ValueString()))
+			}
+			if strings.ContainsAny(result.ValueString(), `;'"{}=`) {
+				t.Errorf("Expected the connection string delimiters to be excluded, got %q", result.ValueString())
+			}
+		})
+	}
+}
+
+func TestPasswordEphemeralResource_ValidateConfig(t *testing.T) {
+	ctx := context.Background()
+
+	for _, length := range []int{7, 129} {
+		config := newPasswordConfig(map[string]tftypes.Value{"length": tftypes.NewValue(tftypes.Number, length)})
+		resp := &ephemeral.ValidateConfigResponse{}
+
+		NewPasswordEphemeralResource().(*passwordEphemeralResource).ValidateConfig(ctx, ephemeral.ValidateConfigRequest{Config: config}, resp)
+
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Invalid Password Length" {
+			t.Errorf("Expected an Invalid Password Length error for length %d, got %v", length, resp.Diagnostics)
+		}
+	}
+}
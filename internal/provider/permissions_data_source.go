@@ -40,6 +40,7 @@ func (d *permissionsDataSource) Schema(_ context.Context, req datasource.SchemaR
 		Description:         "Reads database-level permissions assigned to a role.",
 		MarkdownDescription: "Reads database-level permissions assigned to a role.",
 		Attributes: map[string]schema.Attribute{
+			"database_name": databaseNameDataSourceAttribute(),
 			"role_name": schema.StringAttribute{
 				Description:         "The database role name.",
 				MarkdownDescription: "The database role name.",
@@ -129,7 +130,12 @@ func (d *permissionsDataSource) Read(ctx context.Context, req datasource.ReadReq
 		"role_name": data.RoleName.ValueString(),
 	})
 
-	connector := d.connector
+	// Use the provider connector, or the connector to the database of the data source
+	connector, connectorDiags := getDatabaseConnector(ctx, d.connector, data.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database
 	db, err := connectToDatabase(ctx, connector)
@@ -6,18 +6,19 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"terraform-provider-mssqlpermissions/internal/provider/model"
 	"terraform-provider-mssqlpermissions/internal/queries"
 	qmodel "terraform-provider-mssqlpermissions/internal/queries/model"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -26,6 +27,7 @@ var _ resource.Resource = &PermissionsResource{}
 var _ resource.ResourceWithValidateConfig = &PermissionsResource{}
 var _ resource.ResourceWithImportState = &PermissionsResource{}
 var _ resource.ResourceWithConfigure = &PermissionsResource{}
+var _ resource.ResourceWithModifyPlan = &PermissionsResource{}
 
 func NewPermissionsResource() resource.Resource {
 	return &PermissionsResource{}
@@ -44,11 +46,12 @@ func (r *PermissionsResource) Metadata(_ context.Context, req resource.MetadataR
 
 // Schema is a method that sets the schema for the PermissionsResource.
 // It defines the attributes and their properties for the resource.
-func (r *PermissionsResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *PermissionsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description:         "Permissions.",
 		MarkdownDescription: "Permissions.",
 		Attributes: map[string]schema.Attribute{
+			"database_name": databaseNameResourceAttribute(),
 			"permissions": schema.ListNestedAttribute{
 				Description:         "A list of permissions.",
 				MarkdownDescription: "A list of permissions.",
@@ -115,11 +118,16 @@ func (r *PermissionsResource) Schema(_ context.Context, req resource.SchemaReque
 				Description:         "The database role's name.",
 				MarkdownDescription: "The database role's name.",
 				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -127,7 +135,7 @@ func (r *PermissionsResource) Schema(_ context.Context, req resource.SchemaReque
 // It checks that the role name is not empty and validates permissions configuration.
 // If validation fails, it adds appropriate errors to the response diagnostics.
 func (r *PermissionsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var config model.PermissionResourceModel
+	var config model.PermissionResourceWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 
@@ -193,6 +201,11 @@ func (r *PermissionsResource) ValidateConfig(ctx context.Context, req resource.V
 	}
 }
 
+// ModifyPlan replaces the resource when it moves to another database.
+func (r *PermissionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	requireReplaceOnDatabaseMove(ctx, r.connector, req, resp)
+}
+
 // Configure configures the resource with the provider configuration.
 func (r *PermissionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -216,17 +229,31 @@ func (r *PermissionsResource) Configure(ctx context.Context, req resource.Config
 // It updates the state with the newly created permissions.
 // If any error occurs during the process, it adds the error to the response diagnostics.
 func (r *PermissionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var state model.PermissionResourceModel
+	var state model.PermissionResourceWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Bound the whole operation by the create timeout of the resource
+	createTimeout, timeoutDiags := state.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "PermissionsResource", "Create")
 
-	// Use provider connector
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -307,17 +334,31 @@ func (r *PermissionsResource) Create(ctx context.Context, req resource.CreateReq
 // It removes the permissions associated with the role from the database.
 // If any error occurs during the deletion process, it adds an error to the response diagnostics.
 func (r *PermissionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state model.PermissionResourceModel
+	var state model.PermissionResourceWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Bound the whole operation by the delete timeout of the resource
+	deleteTimeout, timeoutDiags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "PermissionsResource", "Delete")
 
-	// Use provider connector
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -374,17 +415,31 @@ func (r *PermissionsResource) Delete(ctx context.Context, req resource.DeleteReq
 // retrieves the permissions for the role, and updates the state with the retrieved permissions.
 // If any errors occur during the process, they are added to the response diagnostics.
 func (r *PermissionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state model.PermissionResourceModel
+	var state model.PermissionResourceWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Bound the whole operation by the read timeout of the resource
+	readTimeout, timeoutDiags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "PermissionsResource", "Read")
 
-	// Use provider connector
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -415,6 +470,23 @@ func (r *PermissionsResource) Read(ctx context.Context, req resource.ReadRequest
 	// Get the permissions for the role.
 	var readPermissions []model.PermissionModel
 
+	// An imported resource has no permissions until its first read, which adopts every database
+	// permission granted or denied to the role.
+	if state.Permissions.IsNull() {
+		rolePermissions, err := connector.GetDatabasePermissionsForRole(ctx, db, role)
+		if err != nil {
+			resp.Diagnostics.AddError("Error getting permissions for role", err.Error())
+			return
+		}
+
+		for _, permission := range rolePermissions {
+			// Permissions on schemas and objects are managed by other resources.
+			if permission.ClassDesc == "DATABASE" {
+				readPermissions = append(readPermissions, newPermissionModel(&permission))
+			}
+		}
+	}
+
 	// Convert permissions list to slice for processing
 	permissions, diags := convertPermissionsListToSlice(ctx, state.Permissions)
 	if diags != nil {
@@ -472,8 +544,8 @@ func (r *PermissionsResource) Read(ctx context.Context, req resource.ReadRequest
 // and then grants the updated permissions to the role.
 // Finally, it updates the state of the PermissionsResource and returns any diagnostics encountered during the process.
 func (r *PermissionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var state model.PermissionResourceModel
-	var plan model.PermissionResourceModel
+	var state model.PermissionResourceWithTimeoutsModel
+	var plan model.PermissionResourceWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -481,10 +553,24 @@ func (r *PermissionsResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	// Bound the whole operation by the update timeout of the resource
+	updateTimeout, timeoutDiags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "PermissionsResource", "Update")
 
-	// Use provider connector
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, plan.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -495,7 +581,7 @@ func (r *PermissionsResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Confirm that the role exists.
 	role := &qmodel.Role{
-		Name: state.RoleName.ValueString(),
+		Name: plan.RoleName.ValueString(),
 	}
 
 	role, err = connector.GetDatabaseRole(ctx, db, role)
@@ -504,6 +590,14 @@ func (r *PermissionsResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	// The permissions of the state are revoked from the role they were granted to,
+	// which is the same role when it was only renamed.
+	previousRole, err := previousDatabaseRole(ctx, connector, db, state.RoleName, role)
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting role", err.Error())
+		return
+	}
+
 	// As the permissions are defined in a list, the order is not guaranteed.
 	// Therefore, we need to delete all permissions and then re-add them.
 	// We take all the permissions in the current state and remove them.
@@ -520,7 +614,7 @@ func (r *PermissionsResource) Update(ctx context.Context, req resource.UpdateReq
 			Name: permissionState.Name.ValueString(),
 		}
 
-		err = connector.RevokePermissionFromRole(ctx, db, role, permission)
+		err = connector.RevokePermissionFromRole(ctx, db, previousRole, permission)
 		if err != nil {
 			resp.Diagnostics.AddError("Error revoking permission from role", err.Error())
 			return
@@ -579,15 +673,82 @@ func (r *PermissionsResource) Update(ctx context.Context, req resource.UpdateReq
 		resp.Diagnostics.Append(*diags...)
 		return
 	}
-	state.Permissions = updatedPermissionsList
+	plan.Permissions = updatedPermissionsList
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	logResourceOperationComplete(ctx, "PermissionsResource", "Update")
 }
 
-// ImportState implements resource.ResourceWithImportState.
+// ImportState imports the permissions of a role from an identifier of the form [database:]role, adopting every
+// database permission granted or denied to the role, or [database:]role/PERMISSION,PERMISSION.
 func (r *PermissionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	panic("not implemented")
+	database, roleName, permissionNames, err := parsePermissionsImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", err.Error())
+		return
+	}
+
+	setImportDatabase(ctx, database, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_name"), roleName)...)
+
+	// Without a list of permissions, the permissions stay null and Read adopts all of them.
+	if len(permissionNames) == 0 {
+		return
+	}
+
+	permissions := make([]model.PermissionModel, 0, len(permissionNames))
+	for _, name := range permissionNames {
+		permissions = append(permissions, model.PermissionModel{Name: types.StringValue(name)})
+	}
+
+	permissionsList, diags := convertPermissionsSliceToList(ctx, permissions)
+	if diags != nil {
+		resp.Diagnostics.Append(*diags...)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("permissions"), permissionsList)...)
+}
+
+// parsePermissionsImportID parses an import identifier of the form [database:]role[/PERMISSION,PERMISSION].
+// The role name ends at the last slash, since permission names never contain one.
+func parsePermissionsImportID(id string) (database, roleName string, permissionNames []string, err error) {
+	database, roleName, err = splitImportDatabase(id)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("%w, expected [database:]role or [database:]role/PERMISSION,PERMISSION", err)
+	}
+
+	if i := strings.LastIndex(roleName, "/"); i >= 0 {
+		for _, name := range strings.Split(roleName[i+1:], ",") {
+			name = strings.ToUpper(strings.TrimSpace(name))
+			if name == "" {
+				return "", "", nil, fmt.Errorf("import identifier %q has an empty permission name", id)
+			}
+			permissionNames = append(permissionNames, name)
+		}
+		roleName = roleName[:i]
+	}
+
+	if roleName == "" {
+		return "", "", nil, fmt.Errorf("import identifier %q has no role, expected [database:]role or [database:]role/PERMISSION,PERMISSION", id)
+	}
+
+	return database, roleName, permissionNames, nil
+}
+
+// newPermissionModel returns the state of a permission read from the database.
+func newPermissionModel(permission *qmodel.Permission) model.PermissionModel {
+	return model.PermissionModel{
+		Class:              types.StringValue(permission.Class),
+		ClassDesc:          types.StringValue(permission.ClassDesc),
+		MajorID:            types.Int64Value(permission.MajorID),
+		MinorID:            types.Int64Value(permission.MinorID),
+		GranteePrincipalID: types.Int64Value(permission.GranteePrincipalID),
+		GrantorPrincipalID: types.Int64Value(permission.GrantorPrincipalID),
+		Type:               types.StringValue(permission.Type),
+		Name:               types.StringValue(permission.Name),
+		State:              types.StringValue(permission.State),
+		StateDesc:          types.StringValue(permission.StateDesc),
+	}
 }
 
 // getPermissionAttrTypes returns the attribute types for the permission model
@@ -65,7 +65,7 @@ func TestPermissionsResource_Schema(t *testing.T) {
 		t.Error("Expected permissions to be a ListNestedAttribute")
 	}
 
-	// Verify role_name has required plan modifier (requires replacement)
+	// Verify role_name is updated in place, so that renaming the role keeps the resource
 	roleNameAttr, exists := resp.Schema.Attributes["role_name"]
 	if !exists {
 		t.Error("Expected role_name attribute to exist")
@@ -73,8 +73,8 @@ func TestPermissionsResource_Schema(t *testing.T) {
 	}
 
 	if stringAttr, ok := roleNameAttr.(schema.StringAttribute); ok {
-		if len(stringAttr.PlanModifiers) == 0 {
-			t.Error("Expected role_name to have plan modifiers")
+		if len(stringAttr.PlanModifiers) != 0 {
+			t.Error("Expected role_name to have no plan modifiers")
 		}
 	}
 }
@@ -178,20 +178,67 @@ func TestPermissionsResource_Configure(t *testing.T) {
 }
 
 func TestPermissionsResource_ImportState(t *testing.T) {
-	r := &PermissionsResource{}
 	ctx := context.Background()
 
-	// Test that ImportState panics as expected (since it's not implemented)
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected ImportState to panic with 'not implemented'")
+	t.Run("AllPermissions", func(t *testing.T) {
+		resp := newImportStateResponse(&PermissionsResource{})
+		(&PermissionsResource{}).ImportState(ctx, resource.ImportStateRequest{ID: "sales:app_role"}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var state model.PermissionResourceWithTimeoutsModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if state.RoleName.ValueString() != "app_role" || state.DatabaseName.ValueString() != "sales" {
+			t.Errorf("Expected role app_role in database sales, got %v in %v", state.RoleName, state.DatabaseName)
+		}
+		// Read adopts every permission of the role when the permissions are null.
+		if !state.Permissions.IsNull() {
+			t.Errorf("Expected null permissions, got %v", state.Permissions)
+		}
+	})
+
+	t.Run("ListedPermissions", func(t *testing.T) {
+		resp := newImportStateResponse(&PermissionsResource{})
+		(&PermissionsResource{}).ImportState(ctx, resource.ImportStateRequest{ID: "app_role/select, view definition"}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var state model.PermissionResourceWithTimeoutsModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		permissions, diags := convertPermissionsListToSlice(ctx, state.Permissions)
+		if diags != nil {
+			t.Fatalf("Unexpected diagnostics: %v", *diags)
+		}
+		if len(permissions) != 2 || permissions[0].Name.ValueString() != "SELECT" || permissions[1].Name.ValueString() != "VIEW DEFINITION" {
+			t.Errorf("Expected SELECT and VIEW DEFINITION, got %v", permissions)
+		}
+		if !state.DatabaseName.IsNull() {
+			t.Errorf("Expected no database_name, got %v", state.DatabaseName)
 		}
-	}()
+	})
+
+	for _, id := range []string{"", "/SELECT", "app_role/SELECT,", ":app_role"} {
+		t.Run("Invalid "+id, func(t *testing.T) {
+			resp := newImportStateResponse(&PermissionsResource{})
+			(&PermissionsResource{}).ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
 
-	req := resource.ImportStateRequest{}
-	resp := &resource.ImportStateResponse{}
+			if len(resp.Diagnostics.Errors()) != 1 || resp.Diagnostics.Errors()[0].Summary() != "Invalid Import Identifier" {
+				t.Errorf("Expected an Invalid Import Identifier error, got: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
 
-	r.ImportState(ctx, req, resp)
+func TestParsePermissionsImportID(t *testing.T) {
+	database, roleName, permissionNames, err := parsePermissionsImportID("sales:team/readers/CONNECT")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if database != "sales" || roleName != "team/readers" || len(permissionNames) != 1 || permissionNames[0] != "CONNECT" {
+		t.Errorf("Unexpected result %q, %q, %v", database, roleName, permissionNames)
+	}
 }
 
 // Test helper functions for creating test data
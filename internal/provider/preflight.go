@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+// Preflight checks of the permissions of the connecting principal.
+// The permissions needed by each registered resource type, or by the chosen ones, are checked before any change runs,
+// so that a missing grant is reported once instead of failing halfway through an apply.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"terraform-provider-mssqlpermissions/internal/queries"
+	qmodel "terraform-provider-mssqlpermissions/internal/queries/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// resourcePermissions lists the permissions of the connecting principal needed by each resource type.
+// The permissions resources need every permission they grant WITH GRANT OPTION, or CONTROL on the
+// securable, which cannot be checked without the configuration, so they have no entry.
+var resourcePermissions = map[string][]qmodel.PermissionCheck{
+	"mssqlpermissions_user": {
+		{Class: queries.SecurableClassDatabase, Permission: "ALTER ANY USER"},
+	},
+	"mssqlpermissions_database_role": {
+		{Class: queries.SecurableClassDatabase, Permission: "CREATE ROLE"},
+		{Class: queries.SecurableClassDatabase, Permission: "ALTER ANY ROLE"},
+	},
+	"mssqlpermissions_database_role_members": {
+		{Class: queries.SecurableClassDatabase, Permission: "ALTER ANY ROLE"},
+	},
+	"mssqlpermissions_login": {
+		{Class: queries.SecurableClassServer, Permission: "ALTER ANY LOGIN"},
+	},
+}
+
+// preflightCheck is the result of the check of a permission needed by a resource type.
+type preflightCheck struct {
+	ResourceType string
+	Database     string // The database of a DATABASE permission
+	qmodel.PermissionCheck
+}
+
+// registeredResourceTypes returns the type names of the resources registered by the provider.
+func registeredResourceTypes(ctx context.Context, p *SqlPermissionsProvider) []string {
+	var typeNames []string
+	for _, newResource := range p.Resources(ctx) {
+		resp := &resource.MetadataResponse{}
+		newResource().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "mssqlpermissions"}, resp)
+		typeNames = append(typeNames, resp.TypeName)
+	}
+	slices.Sort(typeNames)
+	return typeNames
+}
+
+// preflightResourceTypes returns the resource types to check: the chosen ones, or every registered type
+// when none is chosen. A chosen type the provider does not register is reported as an error on attribute.
+func preflightResourceTypes(ctx context.Context, p *SqlPermissionsProvider, chosen []string, attribute path.Path) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	registered := registeredResourceTypes(ctx, p)
+	if len(chosen) == 0 {
+		return registered, diags
+	}
+
+	for _, resourceType := range chosen {
+		if !slices.Contains(registered, resourceType) {
+			diags.AddAttributeError(
+				attribute,
+				"Invalid Preflight Resource Type",
+				fmt.Sprintf("%q is not a resource type of the provider, expected one of: %s.", resourceType, strings.Join(registered, ", ")),
+			)
+		}
+	}
+
+	resourceTypes := slices.Clone(chosen)
+	slices.Sort(resourceTypes)
+	return slices.Compact(resourceTypes), diags
+}
+
+// runPreflightChecks checks the permissions needed by the resource types. DATABASE permissions are
+// checked on the database of connector and SERVER permissions on the master database. Each permission
+// is checked once, however many resource types need it.
+// The SERVER permissions are left out, with a warning, when the database is contained or an Azure SQL
+// Database, whose principals may not exist in master, or when master refuses the connection.
+func runPreflightChecks(ctx context.Context, connector *queries.Connector, resourceTypes []string) ([]preflightCheck, diag.Diagnostics, error) {
+	var diags diag.Diagnostics
+
+	// The connection to the database also tells whether it is contained
+	db, err := connectToDatabase(ctx, connector)
+	if err != nil {
+		return nil, diags, err
+	}
+
+	var checks []preflightCheck
+	granted := map[qmodel.PermissionCheck]bool{}
+	for _, class := range []string{queries.SecurableClassDatabase, queries.SecurableClassServer} {
+		var classChecks []preflightCheck
+		var permissions []qmodel.PermissionCheck
+		for _, resourceType := range resourceTypes {
+			for _, permission := range resourcePermissions[resourceType] {
+				if permission.Class != class {
+					continue
+				}
+				classChecks = append(classChecks, preflightCheck{ResourceType: resourceType, PermissionCheck: permission})
+				if !slices.Contains(permissions, permission) {
+					permissions = append(permissions, permission)
+				}
+			}
+		}
+		if len(permissions) == 0 {
+			continue
+		}
+
+		classConnector, classDB := connector, db
+		if class == queries.SecurableClassServer {
+			if connector.IsContainedDatabase() {
+				diags.AddWarning(
+					"Server Permissions Not Checked",
+					fmt.Sprintf("The database %s is contained or an Azure SQL Database, whose principals may not be able to connect to master. Not checked:\n%s",
+						connector.Database, preflightCheckList(classChecks)),
+				)
+				continue
+			}
+
+			classConnector = connector.ForDatabase(masterDatabase)
+			classDB, err = connectToDatabase(ctx, classConnector)
+			if err != nil {
+				diags.AddWarning(
+					"Server Permissions Not Checked",
+					fmt.Sprintf("Cannot connect to master to check the server permissions: %s. Not checked:\n%s", err, preflightCheckList(classChecks)),
+				)
+				continue
+			}
+		}
+
+		results, err := classConnector.HasPermissions(ctx, classDB, permissions)
+		if err != nil {
+			return nil, diags, err
+		}
+		for _, result := range results {
+			granted[qmodel.PermissionCheck{Class: result.Class, Permission: result.Permission}] = result.Granted
+		}
+		checks = append(checks, classChecks...)
+	}
+
+	for i := range checks {
+		checks[i].Granted = granted[checks[i].PermissionCheck]
+		if checks[i].Class == queries.SecurableClassDatabase {
+			checks[i].Database = connector.Database
+		}
+	}
+	return checks, diags, nil
+}
+
+// preflightCheckList lists the checks, one per line.
+func preflightCheckList(checks []preflightCheck) string {
+	lines := make([]string, 0, len(checks))
+	for _, check := range checks {
+		lines = append(lines, "  - "+check.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// preflightDiagnostics reports the permissions missing from the checks as a single error.
+func preflightDiagnostics(checks []preflightCheck) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var missing []preflightCheck
+	for _, check := range checks {
+		if !check.Granted {
+			missing = append(missing, check)
+		}
+	}
+	if len(missing) == 0 {
+		return diags
+	}
+
+	diags.AddError(
+		"Missing Permissions",
+		"The principal the provider connects with lacks permissions needed by the checked resources:\n"+
+			preflightCheckList(missing)+
+			"\n\nGrant them before applying, leave the resource types not managed out of preflight_resource_types, or set preflight_checks to false to skip this check.",
+	)
+	return diags
+}
+
+// String describes the permission and the resource type needing it.
+func (c preflightCheck) String() string {
+	if c.Class == queries.SecurableClassServer {
+		return fmt.Sprintf("%s on the server, needed by %s", c.Permission, c.ResourceType)
+	}
+	return fmt.Sprintf("%s on the database %s, needed by %s", c.Permission, c.Database, c.ResourceType)
+}
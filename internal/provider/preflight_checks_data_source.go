@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-mssqlpermissions/internal/provider/model"
+	"terraform-provider-mssqlpermissions/internal/queries"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &preflightChecksDataSource{}
+	_ datasource.DataSourceWithConfigure = &preflightChecksDataSource{}
+)
+
+func NewPreflightChecksDataSource() datasource.DataSource {
+	return &preflightChecksDataSource{}
+}
+
+type preflightChecksDataSource struct {
+	connector *queries.Connector
+}
+
+// Metadata is a method that sets the metadata for the preflight_checks data source.
+// It sets the TypeName field of the response to the concatenation of the ProviderTypeName from the request and "_preflight_checks".
+func (d *preflightChecksDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_preflight_checks"
+}
+
+// Schema defines the schema for the preflight_checks data source.
+func (d *preflightChecksDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	databaseName := databaseNameDataSourceAttribute()
+	databaseName.Description = "The database to check the database permissions on. Defaults to the database_name of the provider."
+	databaseName.MarkdownDescription = "The database to check the database permissions on. Defaults to the `database_name` of the provider."
+	databaseName.Computed = true
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Preflight checks data source. Checks that the principal the provider connects with holds the permissions needed by each resource type, as the `preflight_checks` provider attribute does, without failing when some are missing. The server permissions are left out, with a warning, on contained and Azure SQL databases and when `master` refuses the connection.",
+
+		Attributes: map[string]schema.Attribute{
+			"database_name": databaseName,
+			"resource_types": schema.SetAttribute{
+				Description:         "The resource types to check the permissions of, such as mssqlpermissions_user. Defaults to every resource type of the provider.",
+				MarkdownDescription: "The resource types to check the permissions of, such as `mssqlpermissions_user`. Defaults to every resource type of the provider.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"checks": schema.ListNestedAttribute{
+				Description:         "The permission checks, one for each permission needed by each resource type.",
+				MarkdownDescription: "The permission checks, one for each permission needed by each resource type.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"resource_type": schema.StringAttribute{
+							MarkdownDescription: "The resource type needing the permission.",
+							Computed:            true,
+						},
+						"permission": schema.StringAttribute{
+							MarkdownDescription: "The permission name.",
+							Computed:            true,
+						},
+						"securable_class": schema.StringAttribute{
+							MarkdownDescription: "The class of the securable the permission is checked on, `DATABASE` or `SERVER`.",
+							Computed:            true,
+						},
+						"granted": schema.BoolAttribute{
+							MarkdownDescription: "Does the principal hold the permission, directly, through a covering permission or through a role membership.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"missing_permissions": schema.ListAttribute{
+				Description:         "The permissions the principal lacks, with the resource type needing them.",
+				MarkdownDescription: "The permissions the principal lacks, with the resource type needing them.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"passed": schema.BoolAttribute{
+				Description:         "Does the principal hold every permission checked.",
+				MarkdownDescription: "Does the principal hold every permission checked.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure is called by the framework to pass provider-level configuration to the data source.
+func (d *preflightChecksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*queries.Connector)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			"Expected *queries.Connector, got something else. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.connector = connector
+}
+
+// Read is a method that runs the preflight checks.
+func (d *preflightChecksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var state model.PreflightChecksDataModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connector, connectorDiags := getDatabaseConnector(ctx, d.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var chosen []string
+	for _, resourceType := range state.ResourceTypes {
+		chosen = append(chosen, resourceType.ValueString())
+	}
+	resourceTypes, diags := preflightResourceTypes(ctx, &SqlPermissionsProvider{}, chosen, path.Root("resource_types"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "preflightChecksDataSource: check the permissions of the resources")
+	checks, diags, err := runPreflightChecks(ctx, connector, resourceTypes)
+	resp.Diagnostics.Append(diags...)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error running preflight checks", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "preflightChecksDataSource: populate the state object (model.PreflightChecksDataModel)")
+	state.DatabaseName = types.StringValue(connector.Database)
+	state.Checks = make([]model.PreflightCheckModel, 0, len(checks))
+	state.MissingPermissions = []types.String{}
+	for _, check := range checks {
+		state.Checks = append(state.Checks, model.PreflightCheckModel{
+			ResourceType:   types.StringValue(check.ResourceType),
+			Permission:     types.StringValue(check.Permission),
+			SecurableClass: types.StringValue(check.Class),
+			Granted:        types.BoolValue(check.Granted),
+		})
+		if !check.Granted {
+			state.MissingPermissions = append(state.MissingPermissions, types.StringValue(check.String()))
+		}
+	}
+	state.Passed = types.BoolValue(len(state.MissingPermissions) == 0)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPreflightChecksDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccPreflightChecksDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssqlpermissions_preflight_checks.test", "database_name", "ApplicationDB"),
+					resource.TestCheckResourceAttr("data.mssqlpermissions_preflight_checks.test", "passed", "true"),
+					resource.TestCheckResourceAttr("data.mssqlpermissions_preflight_checks.test", "missing_permissions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPreflightChecksDataSourceConfig() string {
+	return fmt.Sprintf(`
+provider "mssqlpermissions" {
+	server_fqdn      = %q
+	server_port      = %q
+	database_name    = "ApplicationDB"
+	preflight_checks = true
+
+	sql_login = {
+		username = "sa"
+		password = "P@ssw0rd"
+	}
+}
+
+data "mssqlpermissions_preflight_checks" "test" {}
+`, os.Getenv("LOCAL_SQL_HOST"), os.Getenv("LOCAL_SQL_PORT"))
+}
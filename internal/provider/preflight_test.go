@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"terraform-provider-mssqlpermissions/internal/queries"
+	qmodel "terraform-provider-mssqlpermissions/internal/queries/model"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestRegisteredResourceTypes(t *testing.T) {
+	resourceTypes := registeredResourceTypes(context.Background(), &SqlPermissionsProvider{})
+
+	if !slices.IsSorted(resourceTypes) {
+		t.Errorf("Expected sorted resource types, got %v", resourceTypes)
+	}
+	if !slices.Contains(resourceTypes, "mssqlpermissions_user") {
+		t.Errorf("Expected mssqlpermissions_user to be registered, got %v", resourceTypes)
+	}
+
+	// Every resource type with required permissions must be registered, or its checks never run.
+	for resourceType := range resourcePermissions {
+		if !slices.Contains(resourceTypes, resourceType) {
+			t.Errorf("Expected resource type %s to be registered", resourceType)
+		}
+	}
+}
+
+func TestPreflightDiagnostics(t *testing.T) {
+	alterAnyUser := qmodel.PermissionCheck{Class: queries.SecurableClassDatabase, Permission: "ALTER ANY USER", Granted: true}
+	alterAnyLogin := qmodel.PermissionCheck{Class: queries.SecurableClassServer, Permission: "ALTER ANY LOGIN"}
+
+	t.Run("AllGranted", func(t *testing.T) {
+		diags := preflightDiagnostics([]preflightCheck{
+			{ResourceType: "mssqlpermissions_user", Database: "appdb", PermissionCheck: alterAnyUser},
+		})
+		if diags.HasError() {
+			t.Errorf("Expected no errors, got: %v", diags)
+		}
+	})
+
+	t.Run("MissingPermissions", func(t *testing.T) {
+		alterAnyRole := qmodel.PermissionCheck{Class: queries.SecurableClassDatabase, Permission: "ALTER ANY ROLE"}
+		diags := preflightDiagnostics([]preflightCheck{
+			{ResourceType: "mssqlpermissions_user", Database: "appdb", PermissionCheck: alterAnyUser},
+			{ResourceType: "mssqlpermissions_database_role_members", Database: "appdb", PermissionCheck: alterAnyRole},
+			{ResourceType: "mssqlpermissions_login", PermissionCheck: alterAnyLogin},
+		})
+
+		if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Missing Permissions" {
+			t.Fatalf("Expected a single 'Missing Permissions' error, got: %v", diags)
+		}
+		detail := diags.Errors()[0].Detail()
+		for _, want := range []string{
+			"ALTER ANY ROLE on the database appdb, needed by mssqlpermissions_database_role_members",
+			"ALTER ANY LOGIN on the server, needed by mssqlpermissions_login",
+		} {
+			if !strings.Contains(detail, want) {
+				t.Errorf("Expected the error to report %q, got: %s", want, detail)
+			}
+		}
+		if strings.Contains(detail, "ALTER ANY USER") {
+			t.Errorf("Expected the granted permissions not to be reported, got: %s", detail)
+		}
+	})
+}
+
+func TestPreflightResourceTypes(t *testing.T) {
+	ctx := context.Background()
+	attribute := path.Root("preflight_resource_types")
+
+	t.Run("Default", func(t *testing.T) {
+		resourceTypes, diags := preflightResourceTypes(ctx, &SqlPermissionsProvider{}, nil, attribute)
+		if diags.HasError() {
+			t.Fatalf("Unexpected diagnostics: %v", diags)
+		}
+		if !slices.Equal(resourceTypes, registeredResourceTypes(ctx, &SqlPermissionsProvider{})) {
+			t.Errorf("Expected every registered resource type, got %v", resourceTypes)
+		}
+	})
+
+	t.Run("Chosen", func(t *testing.T) {
+		resourceTypes, diags := preflightResourceTypes(ctx, &SqlPermissionsProvider{}, []string{"mssqlpermissions_user", "mssqlpermissions_database_role", "mssqlpermissions_user"}, attribute)
+		if diags.HasError() {
+			t.Fatalf("Unexpected diagnostics: %v", diags)
+		}
+		if !slices.Equal(resourceTypes, []string{"mssqlpermissions_database_role", "mssqlpermissions_user"}) {
+			t.Errorf("Expected the chosen resource types, sorted and once each, got %v", resourceTypes)
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, diags := preflightResourceTypes(ctx, &SqlPermissionsProvider{}, []string{"mssqlpermissions_users"}, attribute)
+		if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Invalid Preflight Resource Type" {
+			t.Errorf("Expected a single 'Invalid Preflight Resource Type' error, got: %v", diags)
+		}
+	})
+}
+
+func TestPreflightCheckList(t *testing.T) {
+	list := preflightCheckList([]preflightCheck{
+		{ResourceType: "mssqlpermissions_login", PermissionCheck: qmodel.PermissionCheck{Class: queries.SecurableClassServer, Permission: "ALTER ANY LOGIN"}},
+		{ResourceType: "mssqlpermissions_user", Database: "appdb", PermissionCheck: qmodel.PermissionCheck{Class: queries.SecurableClassDatabase, Permission: "ALTER ANY USER"}},
+	})
+
+	want := "  - ALTER ANY LOGIN on the server, needed by mssqlpermissions_login\n  - ALTER ANY USER on the database appdb, needed by mssqlpermissions_user"
+	if list != want {
+		t.Errorf("Expected %q, got %q", want, list)
+	}
+}
@@ -6,19 +6,29 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"terraform-provider-mssqlpermissions/internal/provider/model"
+	"terraform-provider-mssqlpermissions/internal/queries"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure ScaffoldingProvider satisfies various provider interfaces.
 var _ provider.Provider = &SqlPermissionsProvider{}
+var _ provider.ProviderWithEphemeralResources = &SqlPermissionsProvider{}
 
 // SqlPermissionsProvider defines the provider implementation.
 type SqlPermissionsProvider struct {
@@ -26,6 +36,10 @@ type SqlPermissionsProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// connector is the connector handed to resources and data sources by the last Configure call.
+	// Its connection pool is shared by every operation of this provider instance.
+	connector *queries.Connector
 }
 
 // SqlPermissionsProviderModel describes the provider data model.
@@ -37,6 +51,38 @@ type SqlPermissionsProviderModel struct {
 	SPNLogin       types.Object `tfsdk:"spn_login"`
 	MSILogin       types.Object `tfsdk:"msi_login"`
 	FederatedLogin types.Object `tfsdk:"federated_login"`
+	EntraLogin     types.Object `tfsdk:"entra_login"`
+
+	ConnectionString types.String `tfsdk:"connection_string"`
+
+	AccessToken     types.String `tfsdk:"access_token"`
+	AccessTokenFile types.String `tfsdk:"access_token_file"`
+
+	InstanceName                 types.String `tfsdk:"instance_name"`
+	FailoverPartner              types.String `tfsdk:"failover_partner"`
+	MultiSubnetFailover          types.Bool   `tfsdk:"multi_subnet_failover"`
+	ReadOnlyIntentForDataSources types.Bool   `tfsdk:"read_only_intent_for_data_sources"`
+
+	ExecuteAsUser types.String `tfsdk:"execute_as_user"`
+
+	ApplicationName types.String `tfsdk:"application_name"`
+	WorkstationID   types.String `tfsdk:"workstation_id"`
+	SessionContext  types.Map    `tfsdk:"session_context"`
+
+	PreflightChecks        types.Bool `tfsdk:"preflight_checks"`
+	PreflightResourceTypes types.Set  `tfsdk:"preflight_resource_types"`
+
+	MaxOpenConnections    types.Int64  `tfsdk:"max_open_connections"`
+	MaxIdleConnections    types.Int64  `tfsdk:"max_idle_connections"`
+	ConnectionMaxLifetime types.String `tfsdk:"connection_max_lifetime"`
+	ConnectTimeout        types.String `tfsdk:"connect_timeout"`
+	StatementTimeout      types.String `tfsdk:"statement_timeout"`
+
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryMinBackoff types.String `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff types.String `tfsdk:"retry_max_backoff"`
+
+	TLS types.Object `tfsdk:"tls"`
 }
 
 // Metadata retrieves the metadata for the mssqlpermissions provider.
@@ -69,20 +115,32 @@ func (p *SqlPermissionsProvider) Configure(ctx context.Context, req provider.Con
 		return
 	}
 
+	// Fill unset attributes from the environment
+	resp.Diagnostics.Append(applyEnvironment(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A connection string replaces the structured connection settings
+	hasConnectionString := !config.ConnectionString.IsNull()
+	if hasConnectionString {
+		resp.Diagnostics.Append(validateConnectionString(config)...)
+	}
+
 	// Validate required fields are not empty
-	if config.ServerFqdn.IsNull() || config.ServerFqdn.ValueString() == "" {
+	if !hasConnectionString && (config.ServerFqdn.IsNull() || config.ServerFqdn.ValueString() == "") {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("server_fqdn"),
 			"Missing Server FQDN",
-			"The server_fqdn is required and cannot be empty.",
+			"The server_fqdn is required and cannot be empty. Set it in the provider block or with the "+envServerFqdn+" environment variable.",
 		)
 	}
 
-	if config.DatabaseName.IsNull() || config.DatabaseName.ValueString() == "" {
+	if !hasConnectionString && (config.DatabaseName.IsNull() || config.DatabaseName.ValueString() == "") {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("database_name"),
 			"Missing Database Name",
-			"The database_name is required and cannot be empty.",
+			"The database_name is required and cannot be empty. Set it in the provider block or with the "+envDatabaseName+" environment variable.",
 		)
 	}
 
@@ -98,6 +156,32 @@ func (p *SqlPermissionsProvider) Configure(ctx context.Context, req provider.Con
 		}
 	}
 
+	// Validate connection pool limits
+	if !config.MaxOpenConnections.IsNull() && !config.MaxOpenConnections.IsUnknown() && config.MaxOpenConnections.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_open_connections"),
+			"Invalid Max Open Connections",
+			"The max_open_connections must be greater than or equal to 0.",
+		)
+	}
+
+	if !config.MaxIdleConnections.IsNull() && !config.MaxIdleConnections.IsUnknown() && config.MaxIdleConnections.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_idle_connections"),
+			"Invalid Max Idle Connections",
+			"The max_idle_connections must be greater than or equal to 0.",
+		)
+	}
+
+	// Validate retry policy
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() && config.MaxRetries.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Max Retries",
+			"The max_retries must be greater than or equal to 0.",
+		)
+	}
+
 	// Validate authentication method mutual exclusivity
 	authMethods := 0
 	authMethodNames := []string{}
@@ -118,11 +202,24 @@ func (p *SqlPermissionsProvider) Configure(ctx context.Context, req provider.Con
 		authMethods++
 		authMethodNames = append(authMethodNames, "federated_login")
 	}
+	if !config.EntraLogin.IsNull() && !config.EntraLogin.IsUnknown() {
+		authMethods++
+		authMethodNames = append(authMethodNames, "entra_login")
+	}
+	if !config.AccessToken.IsNull() {
+		authMethods++
+		authMethodNames = append(authMethodNames, "access_token")
+	}
+	if !config.AccessTokenFile.IsNull() {
+		authMethods++
+		authMethodNames = append(authMethodNames, "access_token_file")
+	}
 
-	if authMethods == 0 {
+	if authMethods == 0 && !hasConnectionString {
 		resp.Diagnostics.AddError(
 			"Missing Authentication Method",
-			"At least one authentication method must be specified (sql_login, spn_login, msi_login, or federated_login).",
+			"At least one authentication method must be specified (sql_login, spn_login, msi_login, federated_login, entra_login, access_token, access_token_file, or connection_string), "+
+				"either in the provider block or through environment variables.",
 		)
 	}
 
@@ -133,6 +230,30 @@ func (p *SqlPermissionsProvider) Configure(ctx context.Context, req provider.Con
 		)
 	}
 
+	if !config.SQLLogin.IsNull() && !config.SQLLogin.IsUnknown() {
+		resp.Diagnostics.Append(validateRequiredLoginAttributes("sql_login", config.SQLLogin, "username")...)
+		resp.Diagnostics.Append(validateSQLLogin(ctx, config.SQLLogin)...)
+	}
+
+	if !config.SPNLogin.IsNull() && !config.SPNLogin.IsUnknown() {
+		resp.Diagnostics.Append(validateRequiredLoginAttributes("spn_login", config.SPNLogin, "client_id", "tenant_id")...)
+		resp.Diagnostics.Append(validateSPNLogin(ctx, config.SPNLogin)...)
+	}
+
+	if !config.FederatedLogin.IsNull() && !config.FederatedLogin.IsUnknown() {
+		resp.Diagnostics.Append(validateRequiredLoginAttributes("federated_login", config.FederatedLogin, "client_id", "tenant_id")...)
+		resp.Diagnostics.Append(validateFederatedLogin(ctx, config.FederatedLogin)...)
+	}
+
+	if !config.EntraLogin.IsNull() && !config.EntraLogin.IsUnknown() {
+		resp.Diagnostics.Append(validateRequiredLoginAttributes("entra_login", config.EntraLogin, "method")...)
+		resp.Diagnostics.Append(validateEntraLogin(ctx, config.EntraLogin)...)
+	}
+
+	if !config.TLS.IsNull() && !config.TLS.IsUnknown() {
+		resp.Diagnostics.Append(validateTLS(ctx, config.TLS)...)
+	}
+
 	// Return early if any validation errors occurred
 	if resp.Diagnostics.HasError() {
 		return
@@ -147,6 +268,34 @@ func (p *SqlPermissionsProvider) Configure(ctx context.Context, req provider.Con
 		SPNLogin:       config.SPNLogin,
 		MSILogin:       config.MSILogin,
 		FederatedLogin: config.FederatedLogin,
+		EntraLogin:     config.EntraLogin,
+
+		ConnectionString: config.ConnectionString,
+
+		AccessToken:     config.AccessToken,
+		AccessTokenFile: config.AccessTokenFile,
+
+		InstanceName:        config.InstanceName,
+		FailoverPartner:     config.FailoverPartner,
+		MultiSubnetFailover: config.MultiSubnetFailover,
+
+		ExecuteAsUser: config.ExecuteAsUser,
+
+		ApplicationName: config.ApplicationName,
+		WorkstationID:   config.WorkstationID,
+		SessionContext:  config.SessionContext,
+
+		MaxOpenConnections:    config.MaxOpenConnections,
+		MaxIdleConnections:    config.MaxIdleConnections,
+		ConnectionMaxLifetime: config.ConnectionMaxLifetime,
+		ConnectTimeout:        config.ConnectTimeout,
+		StatementTimeout:      config.StatementTimeout,
+
+		MaxRetries:      config.MaxRetries,
+		RetryMinBackoff: config.RetryMinBackoff,
+		RetryMaxBackoff: config.RetryMaxBackoff,
+
+		TLS: config.TLS,
 	}
 
 	// Create connector from configuration
@@ -156,9 +305,403 @@ func (p *SqlPermissionsProvider) Configure(ctx context.Context, req provider.Con
 		return
 	}
 
-	// Make connector available to resources via ResourceData and DataSourceData
+	// Release the pool of a previous configuration before replacing its connector
+	if p.connector != nil {
+		if err := p.connector.Close(); err != nil {
+			tflog.Warn(ctx, "Failed to close the previous database connection pool", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+	p.connector = connector
+
+	// Make connector available to resources via ResourceData and DataSourceData.
+	// Data sources only read, so they may be routed to a readable secondary replica.
 	resp.ResourceData = connector
 	resp.DataSourceData = connector
+	if config.ReadOnlyIntentForDataSources.ValueBool() {
+		resp.DataSourceData = connector.ReadOnly()
+	}
+
+	// Report the permissions missing from the connecting principal before any change runs
+	if config.PreflightChecks.ValueBool() {
+		var chosen []string
+		if !config.PreflightResourceTypes.IsNull() && !config.PreflightResourceTypes.IsUnknown() {
+			resp.Diagnostics.Append(config.PreflightResourceTypes.ElementsAs(ctx, &chosen, false)...)
+		}
+		resourceTypes, diags := preflightResourceTypes(ctx, p, chosen, path.Root("preflight_resource_types"))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		checks, diags, err := runPreflightChecks(ctx, connector, resourceTypes)
+		resp.Diagnostics.Append(diags...)
+		if err != nil {
+			if !handleAuthenticationError(ctx, err, &resp.Diagnostics) {
+				resp.Diagnostics.AddError("Preflight Checks Failed", err.Error())
+			}
+			return
+		}
+		resp.Diagnostics.Append(preflightDiagnostics(checks)...)
+	}
+}
+
+// validateConnectionString checks that the connection_string is not combined with the structured
+// connection settings it replaces.
+func validateConnectionString(config SqlPermissionsProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	structured := []struct {
+		name  string
+		value attr.Value
+	}{
+		{"server_fqdn", config.ServerFqdn},
+		{"server_port", config.ServerPort},
+		{"instance_name", config.InstanceName},
+		{"database_name", config.DatabaseName},
+		{"failover_partner", config.FailoverPartner},
+		{"multi_subnet_failover", config.MultiSubnetFailover},
+		{"sql_login", config.SQLLogin},
+		{"spn_login", config.SPNLogin},
+		{"msi_login", config.MSILogin},
+		{"federated_login", config.FederatedLogin},
+		{"entra_login", config.EntraLogin},
+		{"access_token", config.AccessToken},
+		{"access_token_file", config.AccessTokenFile},
+		{"tls", config.TLS},
+	}
+
+	conflicts := []string{}
+	for _, setting := range structured {
+		if !setting.value.IsNull() {
+			conflicts = append(conflicts, setting.name)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		diags.AddAttributeError(
+			path.Root("connection_string"),
+			"Conflicting Connection Settings",
+			"The connection_string cannot be combined with the connection settings it replaces. Found: "+strings.Join(conflicts, ", ")+
+				". Set them in the connection string instead.",
+		)
+	}
+
+	return diags
+}
+
+// validateRequiredLoginAttributes checks that the given attributes of a login block are set,
+// either in the configuration or through their environment variable.
+func validateRequiredLoginAttributes(block string, login types.Object, names ...string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	attributes := login.Attributes()
+	for _, name := range names {
+		value, ok := attributes[name].(types.String)
+		if ok && (value.IsUnknown() || value.ValueString() != "") {
+			continue
+		}
+
+		detail := fmt.Sprintf("The %s.%s attribute is required and cannot be empty.", block, name)
+		if envVar, ok := loginEnvironment[block][name]; ok {
+			detail += " Set it in the provider block or with the " + envVar + " environment variable."
+		}
+		diags.AddAttributeError(path.Root(block).AtName(name), "Missing Login Attribute", detail)
+	}
+
+	return diags
+}
+
+// validateSQLLogin checks that the sql_login block sets exactly one password source:
+// an inline password, a password file, or a password command.
+func validateSQLLogin(ctx context.Context, sqlLogin types.Object) diag.Diagnostics {
+	var login model.SQLLoginModel
+	diags := sqlLogin.As(ctx, &login, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})
+	if diags.HasError() {
+		return diags
+	}
+
+	sources := []string{}
+	if login.Password.ValueString() != "" || login.Password.IsUnknown() {
+		sources = append(sources, "password")
+	}
+	if login.PasswordFile.ValueString() != "" || login.PasswordFile.IsUnknown() {
+		sources = append(sources, "password_file")
+	}
+	if len(login.PasswordCommand.Elements()) > 0 || login.PasswordCommand.IsUnknown() {
+		sources = append(sources, "password_command")
+	}
+
+	switch {
+	case len(sources) == 0:
+		diags.AddAttributeError(
+			path.Root("sql_login"),
+			"Missing SQL Login Password",
+			"The sql_login block requires one of password, password_file, or password_command. "+
+				"Set it in the provider block or with the "+envSQLPassword+" or "+envSQLPasswordFile+" environment variable.",
+		)
+	case len(sources) > 1:
+		diags.AddAttributeError(
+			path.Root("sql_login"),
+			"Conflicting SQL Login Passwords",
+			"Only one of password, password_file, or password_command can be specified. Found: "+strings.Join(sources, ", "),
+		)
+	}
+
+	return diags
+}
+
+// validateSPNLogin checks that the spn_login block sets exactly one credential: a client secret,
+// given inline, in a file or by a command, a client certificate path, or an inline client certificate.
+func validateSPNLogin(ctx context.Context, spnLogin types.Object) diag.Diagnostics {
+	var login model.SPNLoginModel
+	diags := spnLogin.As(ctx, &login, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})
+	if diags.HasError() {
+		return diags
+	}
+
+	credentials := []string{}
+	if login.ClientSecret.ValueString() != "" || login.ClientSecret.IsUnknown() {
+		credentials = append(credentials, "client_secret")
+	}
+	if login.ClientSecretFile.ValueString() != "" || login.ClientSecretFile.IsUnknown() {
+		credentials = append(credentials, "client_secret_file")
+	}
+	if len(login.ClientSecretCommand.Elements()) > 0 || login.ClientSecretCommand.IsUnknown() {
+		credentials = append(credentials, "client_secret_command")
+	}
+	if login.ClientCertificatePath.ValueString() != "" || login.ClientCertificatePath.IsUnknown() {
+		credentials = append(credentials, "client_certificate_path")
+	}
+	if login.ClientCertificate.ValueString() != "" || login.ClientCertificate.IsUnknown() {
+		credentials = append(credentials, "client_certificate")
+	}
+
+	switch {
+	case len(credentials) == 0:
+		diags.AddAttributeError(
+			path.Root("spn_login"),
+			"Missing Service Principal Credential",
+			"The spn_login block requires one of client_secret, client_secret_file, client_secret_command, client_certificate_path, or client_certificate.",
+		)
+	case len(credentials) > 1:
+		diags.AddAttributeError(
+			path.Root("spn_login"),
+			"Conflicting Service Principal Credentials",
+			"Only one of client_secret, client_secret_file, client_secret_command, client_certificate_path, or client_certificate can be specified. Found: "+strings.Join(credentials, ", "),
+		)
+	}
+
+	return diags
+}
+
+// validateFederatedLogin checks that the federated_login block sets exactly one OIDC token source.
+func validateFederatedLogin(ctx context.Context, federatedLogin types.Object) diag.Diagnostics {
+	var login model.FederatedLoginModel
+	diags := federatedLogin.As(ctx, &login, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})
+	if diags.HasError() {
+		return diags
+	}
+
+	hasToken := login.OIDCToken.ValueString() != "" || login.OIDCToken.IsUnknown()
+	hasTokenFile := login.OIDCTokenFilePath.ValueString() != "" || login.OIDCTokenFilePath.IsUnknown()
+
+	switch {
+	case !hasToken && !hasTokenFile:
+		diags.AddAttributeError(
+			path.Root("federated_login"),
+			"Missing OIDC Token",
+			"The federated_login block requires one of oidc_token or oidc_token_file_path.",
+		)
+	case hasToken && hasTokenFile:
+		diags.AddAttributeError(
+			path.Root("federated_login"),
+			"Conflicting OIDC Token Sources",
+			"Only one of oidc_token or oidc_token_file_path can be specified.",
+		)
+	}
+
+	return diags
+}
+
+// entraLoginMethod lists the entra_login attributes a FedAuth mode requires and the ones it accepts.
+type entraLoginMethod struct {
+	required []string
+	optional []string
+}
+
+// entraLoginMethods maps every FedAuth mode declared in the queries package to its entra_login attributes.
+var entraLoginMethods = map[queries.FedAuth]entraLoginMethod{
+	queries.ActiveDirectoryServicePrincipal: {required: []string{"client_id", "client_secret"}, optional: []string{"tenant_id"}},
+	queries.ActiveDirectoryApplication:      {required: []string{"client_id", "client_secret"}, optional: []string{"tenant_id"}},
+	queries.ActiveDirectoryPassword:         {required: []string{"client_id", "username", "password"}},
+	queries.ActiveDirectoryDefault:          {},
+	queries.ActiveDirectoryManagedIdentity:  {optional: []string{"client_id", "resource_id"}},
+	queries.ActiveDirectoryMSI:              {optional: []string{"client_id", "resource_id"}},
+	queries.ActiveDirectoryInteractive:      {required: []string{"client_id"}, optional: []string{"username"}},
+	queries.ActiveDirectoryDeviceCode:       {optional: []string{"client_id"}},
+	queries.ActiveDirectoryAzCli:            {},
+}
+
+// entraLoginMethodNames returns the supported entra_login methods, sorted by name.
+func entraLoginMethodNames() []string {
+	names := make([]string, 0, len(entraLoginMethods))
+	for method := range entraLoginMethods {
+		names = append(names, method.String())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateEntraLogin checks that the entra_login method is supported and that exactly
+// the attributes used by that method are set.
+func validateEntraLogin(ctx context.Context, entraLogin types.Object) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	attributes := entraLogin.Attributes()
+	methodValue, ok := attributes["method"].(types.String)
+	if !ok || methodValue.IsUnknown() || methodValue.ValueString() == "" {
+		return diags
+	}
+
+	method, ok := entraLoginMethods[queries.FedAuth(methodValue.ValueString())]
+	if !ok {
+		diags.AddAttributeError(
+			path.Root("entra_login").AtName("method"),
+			"Invalid Entra Authentication Method",
+			"The method must be one of: "+strings.Join(entraLoginMethodNames(), ", ")+". Got: "+methodValue.ValueString(),
+		)
+		return diags
+	}
+
+	isSet := func(name string) bool {
+		value, ok := attributes[name].(types.String)
+		return ok && (value.IsUnknown() || value.ValueString() != "")
+	}
+
+	for _, name := range method.required {
+		if !isSet(name) {
+			diags.AddAttributeError(
+				path.Root("entra_login").AtName(name),
+				"Missing Entra Login Attribute",
+				fmt.Sprintf("The %s attribute is required when method is %s.", name, methodValue.ValueString()),
+			)
+		}
+	}
+
+	for name := range attributes {
+		if name == "method" || slices.Contains(method.required, name) || slices.Contains(method.optional, name) {
+			continue
+		}
+		if isSet(name) {
+			diags.AddAttributeError(
+				path.Root("entra_login").AtName(name),
+				"Unsupported Entra Login Attribute",
+				fmt.Sprintf("The %s attribute cannot be used when method is %s.", name, methodValue.ValueString()),
+			)
+		}
+	}
+
+	if isSet("client_id") && isSet("resource_id") {
+		diags.AddAttributeError(
+			path.Root("entra_login"),
+			"Conflicting Managed Identity Attributes",
+			"Only one of client_id or resource_id can be specified to select a user-assigned managed identity.",
+		)
+	}
+
+	return diags
+}
+
+// encryptionModeNames returns the supported tls encryption modes.
+func encryptionModeNames() []string {
+	names := make([]string, 0, len(queries.EncryptionModes))
+	for _, mode := range queries.EncryptionModes {
+		names = append(names, mode.String())
+	}
+	return names
+}
+
+// validateTLS checks the tls encryption mode and TLS version, that the CA certificate can be loaded,
+// and that the settings don't contradict each other.
+func validateTLS(ctx context.Context, tlsConfig types.Object) diag.Diagnostics {
+	var tls model.TLSModel
+	diags := tlsConfig.As(ctx, &tls, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})
+	if diags.HasError() {
+		return diags
+	}
+
+	encrypt := queries.Encryption(tls.Encrypt.ValueString())
+	if encrypt != "" && !slices.Contains(queries.EncryptionModes, encrypt) {
+		diags.AddAttributeError(
+			path.Root("tls").AtName("encrypt"),
+			"Invalid Encryption Mode",
+			"The encrypt must be one of: "+strings.Join(encryptionModeNames(), ", ")+". Got: "+encrypt.String(),
+		)
+	}
+
+	if version := tls.MinTLSVersion.ValueString(); version != "" && !slices.Contains(queries.TLSVersions, version) {
+		diags.AddAttributeError(
+			path.Root("tls").AtName("min_tls_version"),
+			"Invalid Minimum TLS Version",
+			"The min_tls_version must be one of: "+strings.Join(queries.TLSVersions, ", ")+". Got: "+version,
+		)
+	}
+
+	trustServerCertificate := tls.TrustServerCertificate.ValueBool()
+	hasCACertificate := tls.CACertificatePath.ValueString() != "" || tls.CACertificatePath.IsUnknown()
+
+	if encrypt == queries.EncryptDisable {
+		settings := []string{}
+		if trustServerCertificate {
+			settings = append(settings, "trust_server_certificate")
+		}
+		if hasCACertificate {
+			settings = append(settings, "ca_certificate_path")
+		}
+		if tls.HostNameInCertificate.ValueString() != "" || tls.HostNameInCertificate.IsUnknown() {
+			settings = append(settings, "host_name_in_certificate")
+		}
+		if tls.MinTLSVersion.ValueString() != "" || tls.MinTLSVersion.IsUnknown() {
+			settings = append(settings, "min_tls_version")
+		}
+		if len(settings) > 0 {
+			diags.AddAttributeError(
+				path.Root("tls"),
+				"Conflicting TLS Settings",
+				"TLS settings cannot be used when encrypt is disable. Found: "+strings.Join(settings, ", "),
+			)
+		}
+	}
+
+	if encrypt == queries.EncryptStrict && trustServerCertificate {
+		diags.AddAttributeError(
+			path.Root("tls").AtName("trust_server_certificate"),
+			"Conflicting TLS Settings",
+			"The trust_server_certificate attribute cannot be used when encrypt is strict, as strict encryption always verifies the server certificate.",
+		)
+	}
+
+	if trustServerCertificate && hasCACertificate {
+		diags.AddAttributeError(
+			path.Root("tls"),
+			"Conflicting TLS Settings",
+			"Only one of trust_server_certificate or ca_certificate_path can be specified.",
+		)
+	}
+
+	if caPath := tls.CACertificatePath.ValueString(); caPath != "" {
+		if err := queries.ValidateCACertificate(caPath); err != nil {
+			diags.AddAttributeError(
+				path.Root("tls").AtName("ca_certificate_path"),
+				"Invalid CA Certificate",
+				"The ca_certificate_path must point to a readable PEM certificate bundle: "+err.Error(),
+			)
+		}
+	}
+
+	return diags
 }
 
 // Resources returns a slice of functions that create resource objects.
@@ -167,6 +710,7 @@ func (p *SqlPermissionsProvider) Resources(ctx context.Context) []func() resourc
 	return []func() resource.Resource{
 		NewDatabaseRoleMembersResource,
 		NewDatabaseRoleResource,
+		NewLoginResource,
 		NewPermissionsResource,
 		NewSchemaPermissionsResource,
 		NewUserResource,
@@ -179,12 +723,22 @@ func (p *SqlPermissionsProvider) DataSources(ctx context.Context) []func() datas
 	return []func() datasource.DataSource{
 		NewDatabaseRoleDataSource,
 		NewDatabaseRoleMembersDataSource,
+		NewLoginDataSource,
 		NewPermissionsDataSource,
+		NewPreflightChecksDataSource,
 		NewSchemaPermissionsDataSource,
+		NewServerInfoDataSource,
 		NewUserDataSource,
 	}
 }
 
+// EphemeralResources returns a slice of functions that create the ephemeral resources of the SQL permissions provider.
+func (p *SqlPermissionsProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewPasswordEphemeralResource,
+	}
+}
+
 // New returns a function that creates a new instance of the SqlPermissionsProvider.
 // The version parameter specifies the version of the provider.
 // The returned function can be called to create a new instance of the provider.
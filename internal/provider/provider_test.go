@@ -6,8 +6,23 @@ package provider
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-mssqlpermissions/internal/provider/model"
+	"terraform-provider-mssqlpermissions/internal/queries"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -66,7 +81,12 @@ func TestSqlPermissionsProvider_Schema(t *testing.T) {
 	// Check for required provider attributes
 	requiredAttrs := []string{
 		"server_fqdn", "server_port", "database_name",
-		"sql_login", "spn_login", "msi_login", "federated_login",
+		"sql_login", "spn_login", "msi_login", "federated_login", "entra_login",
+		"access_token", "access_token_file", "tls", "connect_timeout", "statement_timeout",
+		"max_retries", "retry_min_backoff", "retry_max_backoff",
+		"instance_name", "failover_partner", "multi_subnet_failover", "read_only_intent_for_data_sources",
+		"preflight_checks", "preflight_resource_types", "execute_as_user", "connection_string",
+		"application_name", "workstation_id", "session_context",
 	}
 	for _, attr := range requiredAttrs {
 		if _, exists := resp.Schema.Attributes[attr]; !exists {
@@ -217,6 +237,23 @@ func TestSqlPermissionsProvider_DataSources(t *testing.T) {
 	}
 }
 
+func TestSqlPermissionsProvider_EphemeralResources(t *testing.T) {
+	p := &SqlPermissionsProvider{}
+	ctx := context.Background()
+
+	ephemeralResources := p.EphemeralResources(ctx)
+
+	if len(ephemeralResources) == 0 {
+		t.Error("Expected at least one ephemeral resource to be defined")
+	}
+
+	for i, ephemeralResourceFunc := range ephemeralResources {
+		if ephemeralResourceFunc() == nil {
+			t.Errorf("Ephemeral resource function at index %d returned nil", i)
+		}
+	}
+}
+
 func TestNew(t *testing.T) {
 	version := "test"
 	providerFunc := New(version)
@@ -240,6 +277,707 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestValidateSQLLogin(t *testing.T) {
+	ctx := context.Background()
+	command := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("vault"), types.StringValue("read")})
+
+	tests := []struct {
+		name         string
+		values       map[string]attr.Value
+		wantErr      bool
+		errorSummary string
+	}{
+		{
+			name:    "Password",
+			values:  map[string]attr.Value{"password": types.StringValue("secret")},
+			wantErr: false,
+		},
+		{
+			name:    "PasswordFile",
+			values:  map[string]attr.Value{"password_file": types.StringValue("/run/secrets/sql")},
+			wantErr: false,
+		},
+		{
+			name:    "PasswordCommand",
+			values:  map[string]attr.Value{"password_command": command},
+			wantErr: false,
+		},
+		{
+			name:         "NoPassword",
+			values:       map[string]attr.Value{"password_command": types.ListValueMust(types.StringType, []attr.Value{})},
+			wantErr:      true,
+			errorSummary: "Missing SQL Login Password",
+		},
+		{
+			name: "PasswordAndCommand",
+			values: map[string]attr.Value{
+				"password":         types.StringValue("secret"),
+				"password_command": command,
+			},
+			wantErr:      true,
+			errorSummary: "Conflicting SQL Login Passwords",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateSQLLogin(ctx, createLoginObject(t, "sql_login", tt.values))
+
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("Expected error = %v, got diagnostics: %v", tt.wantErr, diags)
+			}
+			if tt.wantErr && diags.Errors()[0].Summary() != tt.errorSummary {
+				t.Errorf("Expected error summary '%s', got: %s", tt.errorSummary, diags.Errors()[0].Summary())
+			}
+		})
+	}
+}
+
+func TestValidateSPNLogin(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		values       map[string]attr.Value
+		wantErr      bool
+		errorSummary string
+	}{
+		{
+			name:    "ClientSecret",
+			values:  map[string]attr.Value{"client_secret": types.StringValue("secret")},
+			wantErr: false,
+		},
+		{
+			name:    "ClientSecretFile",
+			values:  map[string]attr.Value{"client_secret_file": types.StringValue("/run/secrets/client")},
+			wantErr: false,
+		},
+		{
+			name:    "ClientCertificatePath",
+			values:  map[string]attr.Value{"client_certificate_path": types.StringValue("/path/to/cert.pfx")},
+			wantErr: false,
+		},
+		{
+			name: "InlineClientCertificateWithPassword",
+			values: map[string]attr.Value{
+				"client_certificate":          types.StringValue("MIIC"),
+				"client_certificate_password": types.StringValue("password"),
+			},
+			wantErr: false,
+		},
+		{
+			name:         "NoCredential",
+			values:       map[string]attr.Value{},
+			wantErr:      true,
+			errorSummary: "Missing Service Principal Credential",
+		},
+		{
+			name: "SecretAndCertificate",
+			values: map[string]attr.Value{
+				"client_secret":           types.StringValue("secret"),
+				"client_certificate_path": types.StringValue("/path/to/cert.pfx"),
+			},
+			wantErr:      true,
+			errorSummary: "Conflicting Service Principal Credentials",
+		},
+		{
+			name: "SecretFileAndSecretCommand",
+			values: map[string]attr.Value{
+				"client_secret_file":    types.StringValue("/run/secrets/client"),
+				"client_secret_command": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("vault")}),
+			},
+			wantErr:      true,
+			errorSummary: "Conflicting Service Principal Credentials",
+		},
+		{
+			name: "CertificatePathAndInlineCertificate",
+			values: map[string]attr.Value{
+				"client_certificate_path": types.StringValue("/path/to/cert.pfx"),
+				"client_certificate":      types.StringValue("MIIC"),
+			},
+			wantErr:      true,
+			errorSummary: "Conflicting Service Principal Credentials",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateSPNLogin(ctx, createLoginObject(t, "spn_login", tt.values))
+
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("Expected error = %v, got diagnostics: %v", tt.wantErr, diags)
+			}
+			if tt.wantErr && diags.Errors()[0].Summary() != tt.errorSummary {
+				t.Errorf("Expected error summary '%s', got: %s", tt.errorSummary, diags.Errors()[0].Summary())
+			}
+		})
+	}
+}
+
+func TestValidateFederatedLogin(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		values       map[string]attr.Value
+		wantErr      bool
+		errorSummary string
+	}{
+		{
+			name:    "OIDCToken",
+			values:  map[string]attr.Value{"oidc_token": types.StringValue("eyJ0eXAi")},
+			wantErr: false,
+		},
+		{
+			name:    "OIDCTokenFilePath",
+			values:  map[string]attr.Value{"oidc_token_file_path": types.StringValue("/var/run/secrets/token")},
+			wantErr: false,
+		},
+		{
+			name:    "UnknownOIDCToken",
+			values:  map[string]attr.Value{"oidc_token": types.StringUnknown()},
+			wantErr: false,
+		},
+		{
+			name:         "NoTokenSource",
+			values:       map[string]attr.Value{},
+			wantErr:      true,
+			errorSummary: "Missing OIDC Token",
+		},
+		{
+			name: "BothTokenSources",
+			values: map[string]attr.Value{
+				"oidc_token":           types.StringValue("eyJ0eXAi"),
+				"oidc_token_file_path": types.StringValue("/var/run/secrets/token"),
+			},
+			wantErr:      true,
+			errorSummary: "Conflicting OIDC Token Sources",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateFederatedLogin(ctx, createLoginObject(t, "federated_login", tt.values))
+
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("Expected error = %v, got diagnostics: %v", tt.wantErr, diags)
+			}
+			if tt.wantErr && diags.Errors()[0].Summary() != tt.errorSummary {
+				t.Errorf("Expected error summary '%s', got: %s", tt.errorSummary, diags.Errors()[0].Summary())
+			}
+		})
+	}
+}
+
+func TestValidateEntraLogin(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		values        map[string]attr.Value
+		wantErr       bool
+		errorSummary  string
+		errorAttrPath string
+	}{
+		{
+			name:    "AzCliWithoutAttributes",
+			values:  map[string]attr.Value{"method": types.StringValue("ActiveDirectoryAzCli")},
+			wantErr: false,
+		},
+		{
+			name: "ServicePrincipalWithSecret",
+			values: map[string]attr.Value{
+				"method":        types.StringValue("ActiveDirectoryServicePrincipal"),
+				"client_id":     types.StringValue("client-id"),
+				"client_secret": types.StringValue("secret"),
+				"tenant_id":     types.StringValue("tenant-id"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "PasswordWithAllAttributes",
+			values: map[string]attr.Value{
+				"method":    types.StringValue("ActiveDirectoryPassword"),
+				"client_id": types.StringValue("client-id"),
+				"username":  types.StringValue("user@example.com"),
+				"password":  types.StringValue("password"),
+			},
+			wantErr: false,
+		},
+		{
+			name:          "InvalidMethod",
+			values:        map[string]attr.Value{"method": types.StringValue("ActiveDirectoryKerberos")},
+			wantErr:       true,
+			errorSummary:  "Invalid Entra Authentication Method",
+			errorAttrPath: "entra_login.method",
+		},
+		{
+			name: "PasswordMissingUsername",
+			values: map[string]attr.Value{
+				"method":    types.StringValue("ActiveDirectoryPassword"),
+				"client_id": types.StringValue("client-id"),
+				"password":  types.StringValue("password"),
+			},
+			wantErr:       true,
+			errorSummary:  "Missing Entra Login Attribute",
+			errorAttrPath: "entra_login.username",
+		},
+		{
+			name: "AzCliWithClientSecret",
+			values: map[string]attr.Value{
+				"method":        types.StringValue("ActiveDirectoryAzCli"),
+				"client_secret": types.StringValue("secret"),
+			},
+			wantErr:       true,
+			errorSummary:  "Unsupported Entra Login Attribute",
+			errorAttrPath: "entra_login.client_secret",
+		},
+		{
+			name: "ManagedIdentityWithClientAndResourceID",
+			values: map[string]attr.Value{
+				"method":      types.StringValue("ActiveDirectoryManagedIdentity"),
+				"client_id":   types.StringValue("client-id"),
+				"resource_id": types.StringValue("/subscriptions/test/resourceGroups/test/providers/Microsoft.ManagedIdentity/userAssignedIdentities/test"),
+			},
+			wantErr:       true,
+			errorSummary:  "Conflicting Managed Identity Attributes",
+			errorAttrPath: "entra_login",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateEntraLogin(ctx, createLoginObject(t, "entra_login", tt.values))
+
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("Expected error = %v, got diagnostics: %v", tt.wantErr, diags)
+			}
+			if !tt.wantErr {
+				return
+			}
+
+			errDiag := diags.Errors()[0]
+			if errDiag.Summary() != tt.errorSummary {
+				t.Errorf("Expected error summary '%s', got: %s", tt.errorSummary, errDiag.Summary())
+			}
+			if withPath, ok := errDiag.(diag.DiagnosticWithPath); !ok || withPath.Path().String() != tt.errorAttrPath {
+				t.Errorf("Expected error on attribute %s, got: %v", tt.errorAttrPath, errDiag)
+			}
+		})
+	}
+}
+
+func TestEntraLoginMethods(t *testing.T) {
+	// Every FedAuth mode declared in the queries package must be reachable from entra_login
+	declared := []queries.FedAuth{
+		queries.ActiveDirectoryServicePrincipal,
+		queries.ActiveDirectoryApplication,
+		queries.ActiveDirectoryPassword,
+		queries.ActiveDirectoryDefault,
+		queries.ActiveDirectoryManagedIdentity,
+		queries.ActiveDirectoryMSI,
+		queries.ActiveDirectoryInteractive,
+		queries.ActiveDirectoryDeviceCode,
+		queries.ActiveDirectoryAzCli,
+	}
+
+	for _, method := range declared {
+		if _, ok := entraLoginMethods[method]; !ok {
+			t.Errorf("Expected FedAuth mode %s to be supported by entra_login", method)
+		}
+	}
+	if len(entraLoginMethodNames()) != len(declared) {
+		t.Errorf("Expected %d entra_login methods, got %d", len(declared), len(entraLoginMethodNames()))
+	}
+}
+
+func TestValidateTLS(t *testing.T) {
+	ctx := context.Background()
+	caPath := writeTestCACertificate(t)
+
+	tests := []struct {
+		name         string
+		values       map[string]attr.Value
+		wantErr      bool
+		errorSummary string
+	}{
+		{
+			name: "StrictWithCACertificate",
+			values: map[string]attr.Value{
+				"encrypt":                  types.StringValue("strict"),
+				"ca_certificate_path":      types.StringValue(caPath),
+				"host_name_in_certificate": types.StringValue("sql.internal"),
+				"min_tls_version":          types.StringValue("1.2"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "MandatoryTrustServerCertificate",
+			values: map[string]attr.Value{
+				"encrypt":                  types.StringValue("mandatory"),
+				"trust_server_certificate": types.BoolValue(true),
+			},
+			wantErr: false,
+		},
+		{
+			name:         "InvalidEncryptionMode",
+			values:       map[string]attr.Value{"encrypt": types.StringValue("always")},
+			wantErr:      true,
+			errorSummary: "Invalid Encryption Mode",
+		},
+		{
+			name:         "InvalidMinimumTLSVersion",
+			values:       map[string]attr.Value{"min_tls_version": types.StringValue("1.4")},
+			wantErr:      true,
+			errorSummary: "Invalid Minimum TLS Version",
+		},
+		{
+			name: "DisableWithTLSSettings",
+			values: map[string]attr.Value{
+				"encrypt":         types.StringValue("disable"),
+				"min_tls_version": types.StringValue("1.2"),
+			},
+			wantErr:      true,
+			errorSummary: "Conflicting TLS Settings",
+		},
+		{
+			name: "StrictTrustServerCertificate",
+			values: map[string]attr.Value{
+				"encrypt":                  types.StringValue("strict"),
+				"trust_server_certificate": types.BoolValue(true),
+			},
+			wantErr:      true,
+			errorSummary: "Conflicting TLS Settings",
+		},
+		{
+			name: "TrustServerCertificateWithCACertificate",
+			values: map[string]attr.Value{
+				"trust_server_certificate": types.BoolValue(true),
+				"ca_certificate_path":      types.StringValue(caPath),
+			},
+			wantErr:      true,
+			errorSummary: "Conflicting TLS Settings",
+		},
+		{
+			name:         "MissingCACertificate",
+			values:       map[string]attr.Value{"ca_certificate_path": types.StringValue(filepath.Join(t.TempDir(), "missing.pem"))},
+			wantErr:      true,
+			errorSummary: "Invalid CA Certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateTLS(ctx, createLoginObject(t, "tls", tt.values))
+
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("Expected error = %v, got diagnostics: %v", tt.wantErr, diags)
+			}
+			if tt.wantErr && diags.Errors()[0].Summary() != tt.errorSummary {
+				t.Errorf("Expected error summary '%s', got: %s", tt.errorSummary, diags.Errors()[0].Summary())
+			}
+		})
+	}
+}
+
+func TestGetConnector_Durations(t *testing.T) {
+	tests := []struct {
+		name                 string
+		config               model.ConfigModel
+		wantConnMaxLifetime  time.Duration
+		wantTimeout          time.Duration
+		wantStatementTimeout time.Duration
+		wantRetryMinBackoff  time.Duration
+		wantRetryMaxBackoff  time.Duration
+		errorSummary         string
+	}{
+		{
+			name:   "Defaults",
+			config: model.ConfigModel{},
+		},
+		{
+			name: "AllDurations",
+			config: model.ConfigModel{
+				ConnectionMaxLifetime: types.StringValue("30m"),
+				ConnectTimeout:        types.StringValue("1m"),
+				StatementTimeout:      types.StringValue("5m"),
+			},
+			wantConnMaxLifetime:  30 * time.Minute,
+			wantTimeout:          time.Minute,
+			wantStatementTimeout: 5 * time.Minute,
+		},
+		{
+			name:         "InvalidConnectTimeout",
+			config:       model.ConfigModel{ConnectTimeout: types.StringValue("one minute")},
+			errorSummary: "Invalid Connect Timeout",
+		},
+		{
+			name:         "NegativeStatementTimeout",
+			config:       model.ConfigModel{StatementTimeout: types.StringValue("-5m")},
+			errorSummary: "Invalid Statement Timeout",
+		},
+		{
+			name: "RetryBackoffs",
+			config: model.ConfigModel{
+				RetryMinBackoff: types.StringValue("2s"),
+				RetryMaxBackoff: types.StringValue("1m"),
+			},
+			wantRetryMinBackoff: 2 * time.Second,
+			wantRetryMaxBackoff: time.Minute,
+		},
+		{
+			name: "RetryMaxBackoffShorterThanMin",
+			config: model.ConfigModel{
+				RetryMinBackoff: types.StringValue("1m"),
+				RetryMaxBackoff: types.StringValue("2s"),
+			},
+			errorSummary: "Invalid Retry Max Backoff",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector, diags := getConnector(&tt.config)
+
+			if tt.errorSummary != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != tt.errorSummary {
+					t.Fatalf("Expected '%s' error, got: %v", tt.errorSummary, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("Expected no errors, got: %v", diags)
+			}
+
+			if connector.ConnMaxLifetime != tt.wantConnMaxLifetime {
+				t.Errorf("Expected ConnMaxLifetime %v, got %v", tt.wantConnMaxLifetime, connector.ConnMaxLifetime)
+			}
+			if connector.Timeout != tt.wantTimeout {
+				t.Errorf("Expected Timeout %v, got %v", tt.wantTimeout, connector.Timeout)
+			}
+			if connector.StatementTimeout != tt.wantStatementTimeout {
+				t.Errorf("Expected StatementTimeout %v, got %v", tt.wantStatementTimeout, connector.StatementTimeout)
+			}
+			if connector.RetryMinBackoff != tt.wantRetryMinBackoff || connector.RetryMaxBackoff != tt.wantRetryMaxBackoff {
+				t.Errorf("Expected retry backoffs %v-%v, got %v-%v", tt.wantRetryMinBackoff, tt.wantRetryMaxBackoff, connector.RetryMinBackoff, connector.RetryMaxBackoff)
+			}
+		})
+	}
+}
+
+func TestGetConnector_MaxRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries types.Int64
+		want       int
+	}{
+		{name: "Default", maxRetries: types.Int64Null(), want: 0},
+		{name: "Custom", maxRetries: types.Int64Value(8), want: 8},
+		{name: "Disabled", maxRetries: types.Int64Value(0), want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector, diags := getConnector(&model.ConfigModel{MaxRetries: tt.maxRetries})
+			if diags.HasError() {
+				t.Fatalf("Expected no errors, got: %v", diags)
+			}
+			if connector.MaxRetries != tt.want {
+				t.Errorf("Expected MaxRetries %d, got %d", tt.want, connector.MaxRetries)
+			}
+		})
+	}
+}
+
+func TestGetConnector_HighAvailability(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		connector, diags := getConnector(&model.ConfigModel{})
+		if diags.HasError() {
+			t.Fatalf("Expected no errors, got: %v", diags)
+		}
+		if connector.InstanceName != "" || connector.FailoverPartner != "" || connector.MultiSubnetFailover != nil {
+			t.Errorf("Expected the driver defaults, got %+v", connector)
+		}
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		connector, diags := getConnector(&model.ConfigModel{
+			InstanceName:        types.StringValue("SQL01"),
+			FailoverPartner:     types.StringValue("sql-mirror.example.com:1533"),
+			MultiSubnetFailover: types.BoolValue(false),
+		})
+		if diags.HasError() {
+			t.Fatalf("Expected no errors, got: %v", diags)
+		}
+		if connector.InstanceName != "SQL01" {
+			t.Errorf("Expected InstanceName SQL01, got %s", connector.InstanceName)
+		}
+		if connector.FailoverPartner != "sql-mirror.example.com:1533" {
+			t.Errorf("Expected FailoverPartner sql-mirror.example.com:1533, got %s", connector.FailoverPartner)
+		}
+		if connector.MultiSubnetFailover == nil || *connector.MultiSubnetFailover {
+			t.Errorf("Expected MultiSubnetFailover disabled, got %v", connector.MultiSubnetFailover)
+		}
+	})
+}
+
+func TestGetConnector_ExecuteAsUser(t *testing.T) {
+	connector, diags := getConnector(&model.ConfigModel{ExecuteAsUser: types.StringValue("security_admin")})
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got: %v", diags)
+	}
+	if connector.ExecuteAsUser != "security_admin" {
+		t.Errorf("Expected ExecuteAsUser security_admin, got %s", connector.ExecuteAsUser)
+	}
+	if sibling := connector.ForDatabase("reporting"); sibling.ExecuteAsUser != "" {
+		t.Errorf("Expected the connectors to other databases not to impersonate the user, got %q", sibling.ExecuteAsUser)
+	}
+}
+
+func TestGetConnector_Session(t *testing.T) {
+	sessionContext, diags := types.MapValueFrom(context.Background(), types.StringType, map[string]string{"run_id": "1234"})
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got: %v", diags)
+	}
+
+	connector, diags := getConnector(&model.ConfigModel{
+		ApplicationName: types.StringValue("terraform/production"),
+		WorkstationID:   types.StringValue("runner-42"),
+		SessionContext:  sessionContext,
+	})
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got: %v", diags)
+	}
+	if connector.ApplicationName != "terraform/production" || connector.WorkstationID != "runner-42" {
+		t.Errorf("Expected the application name and workstation id, got %q and %q", connector.ApplicationName, connector.WorkstationID)
+	}
+	if connector.SessionContext["run_id"] != "1234" {
+		t.Errorf("Expected the session context, got %v", connector.SessionContext)
+	}
+}
+
+func TestGetConnector_SecretSources(t *testing.T) {
+	command := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("vault"), types.StringValue("read")})
+
+	t.Run("SQLLogin", func(t *testing.T) {
+		connector, diags := getConnector(&model.ConfigModel{
+			SQLLogin: createLoginObject(t, "sql_login", map[string]attr.Value{
+				"username":         types.StringValue("sa"),
+				"password_command": command,
+			}),
+		})
+		if diags.HasError() {
+			t.Fatalf("Expected no errors, got: %v", diags)
+		}
+		if got := connector.LocalUserLogin.PasswordCommand; len(got) != 2 || got[0] != "vault" || got[1] != "read" {
+			t.Errorf("Expected the password command, got %v", got)
+		}
+	})
+
+	t.Run("SPNLogin", func(t *testing.T) {
+		connector, diags := getConnector(&model.ConfigModel{
+			SPNLogin: createLoginObject(t, "spn_login", map[string]attr.Value{
+				"client_id":          types.StringValue("client"),
+				"tenant_id":          types.StringValue("tenant"),
+				"client_secret_file": types.StringValue("/run/secrets/client"),
+			}),
+		})
+		if diags.HasError() {
+			t.Fatalf("Expected no errors, got: %v", diags)
+		}
+		if connector.AzureApplicationLogin.ClientSecretFile != "/run/secrets/client" {
+			t.Errorf("Expected the client secret file, got %q", connector.AzureApplicationLogin.ClientSecretFile)
+		}
+		if connector.AzureApplicationLogin.ClientSecretCommand != nil {
+			t.Errorf("Expected no client secret command, got %v", connector.AzureApplicationLogin.ClientSecretCommand)
+		}
+	})
+}
+
+func TestGetConnector_ConnectionString(t *testing.T) {
+	t.Run("Parsed", func(t *testing.T) {
+		connector, diags := getConnector(&model.ConfigModel{
+			ConnectionString:   types.StringValue(`server=sql.example.com\SQL01;database=app;user id=admin;password=secret;dial timeout=15`),
+			ExecuteAsUser:      types.StringValue("security_admin"),
+			MaxOpenConnections: types.Int64Value(4),
+		})
+		if diags.HasError() {
+			t.Fatalf("Expected no errors, got: %v", diags)
+		}
+		if connector.Host != "sql.example.com" || connector.InstanceName != "SQL01" || connector.Database != "app" {
+			t.Errorf("Expected the server and database of the connection string, got %s\\%s/%s", connector.Host, connector.InstanceName, connector.Database)
+		}
+		if connector.LocalUserLogin == nil || connector.LocalUserLogin.Username != "admin" {
+			t.Errorf("Expected the SQL login of the connection string, got %+v", connector.LocalUserLogin)
+		}
+		if connector.Timeout != 15*time.Second {
+			t.Errorf("Expected Timeout 15s, got %s", connector.Timeout)
+		}
+		if connector.ExecuteAsUser != "security_admin" || connector.MaxOpenConns != 4 {
+			t.Errorf("Expected the provider settings to apply on top of the connection string, got %q and %d", connector.ExecuteAsUser, connector.MaxOpenConns)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, diags := getConnector(&model.ConfigModel{
+			ConnectionString: types.StringValue("server=sql.example.com;database=app;user id=admin;password=secret;packet size=8192"),
+		})
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid Connection String" {
+			t.Fatalf("Expected 'Invalid Connection String' error, got: %v", diags)
+		}
+		if !strings.Contains(diags.Errors()[0].Detail(), `"packet size"`) {
+			t.Errorf("Expected the error to name the rejected key, got: %s", diags.Errors()[0].Detail())
+		}
+	})
+
+	t.Run("ConflictingApplicationName", func(t *testing.T) {
+		_, diags := getConnector(&model.ConfigModel{
+			ConnectionString: types.StringValue("server=sql.example.com;database=app;user id=admin;password=secret;app name=pipeline"),
+			ApplicationName:  types.StringValue("terraform"),
+		})
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Conflicting Connection Settings" {
+			t.Errorf("Expected 'Conflicting Connection Settings' error, got: %v", diags)
+		}
+	})
+
+	t.Run("ConflictingConnectTimeout", func(t *testing.T) {
+		_, diags := getConnector(&model.ConfigModel{
+			ConnectionString: types.StringValue("server=sql.example.com;database=app;user id=admin;password=secret;dial timeout=15"),
+			ConnectTimeout:   types.StringValue("1m"),
+		})
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Conflicting Connection Settings" {
+			t.Errorf("Expected 'Conflicting Connection Settings' error, got: %v", diags)
+		}
+	})
+}
+
+func TestValidateConnectionString(t *testing.T) {
+	t.Run("Alone", func(t *testing.T) {
+		config := SqlPermissionsProviderModel{
+			ConnectionString: types.StringValue("server=sql.example.com;database=app;user id=admin;password=secret"),
+			ExecuteAsUser:    types.StringValue("security_admin"),
+		}
+		if diags := validateConnectionString(config); diags.HasError() {
+			t.Errorf("Expected no errors, got: %v", diags)
+		}
+	})
+
+	t.Run("WithStructuredSettings", func(t *testing.T) {
+		config := SqlPermissionsProviderModel{
+			ConnectionString: types.StringValue("server=sql.example.com;database=app;user id=admin;password=secret"),
+			ServerFqdn:       types.StringValue("sql.example.com"),
+			SQLLogin:         types.ObjectUnknown(nil),
+		}
+		diags := validateConnectionString(config)
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Conflicting Connection Settings" {
+			t.Fatalf("Expected 'Conflicting Connection Settings' error, got: %v", diags)
+		}
+		if !strings.Contains(diags.Errors()[0].Detail(), "server_fqdn, sql_login") {
+			t.Errorf("Expected the error to list the conflicting attributes, got: %s", diags.Errors()[0].Detail())
+		}
+	})
+}
+
 // Test provider interface compliance
 func TestSqlPermissionsProvider_InterfaceCompliance(t *testing.T) {
 	var _ provider.Provider = &SqlPermissionsProvider{}
@@ -256,6 +994,31 @@ func createValidSQLLoginConfig() SqlPermissionsProviderModel {
 	}
 }
 
+// createLoginObject builds an authentication block object from the provider schema, leaving unset attributes null.
+func createLoginObject(t *testing.T, block string, values map[string]attr.Value) types.Object {
+	t.Helper()
+
+	attrTypes := getProviderConfigSchema()[block].GetType().(types.ObjectType).AttrTypes
+	attrs := map[string]attr.Value{}
+	for name, attrType := range attrTypes {
+		if value, ok := values[name]; ok {
+			attrs[name] = value
+		} else if listType, ok := attrType.(types.ListType); ok {
+			attrs[name] = types.ListNull(listType.ElemType)
+		} else if attrType == types.BoolType {
+			attrs[name] = types.BoolNull()
+		} else {
+			attrs[name] = types.StringNull()
+		}
+	}
+
+	object, diags := types.ObjectValue(attrTypes, attrs)
+	if diags.HasError() {
+		t.Fatalf("Failed to build %s object: %v", block, diags)
+	}
+	return object
+}
+
 func isConfigInvalid(value types.String) bool {
 	return value.IsNull() || value.ValueString() == ""
 }
@@ -307,3 +1070,32 @@ func BenchmarkSqlPermissionsProvider_Schema(b *testing.B) {
 		p.Schema(ctx, req, resp)
 	}
 }
+
+// writeTestCACertificate writes a self-signed PEM certificate to a temporary file and returns its path.
+func writeTestCACertificate(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform-provider-mssqlpermissions test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	return caPath
+}
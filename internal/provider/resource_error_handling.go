@@ -90,3 +90,26 @@ func HandlePermissionReadError(err error) ErrorHandlingResult {
 		ErrorMessage:          "Error getting permission for role",
 	}
 }
+
+// HandleLoginReadError analyzes an error from GetLogin and determines the appropriate action
+func HandleLoginReadError(err error) ErrorHandlingResult {
+	if err == nil {
+		return ErrorHandlingResult{
+			ShouldRemoveFromState: false,
+			ShouldAddError:        false,
+		}
+	}
+
+	if err.Error() == "login not found" {
+		return ErrorHandlingResult{
+			ShouldRemoveFromState: true,
+			ShouldAddError:        false,
+		}
+	}
+
+	return ErrorHandlingResult{
+		ShouldRemoveFromState: false,
+		ShouldAddError:        true,
+		ErrorMessage:          "Error getting login",
+	}
+}
@@ -7,14 +7,33 @@ package provider
 import (
 	"context"
 	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"terraform-provider-mssqlpermissions/internal/provider/model"
 	"terraform-provider-mssqlpermissions/internal/queries"
+	qmodel "terraform-provider-mssqlpermissions/internal/queries/model"
+	"time"
 
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// Default resource operation timeouts, used when the timeouts block does not set them.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
 // getResourceConnector gets the connector for a resource, using provider-level config if available,
 // otherwise falling back to resource-level config for backward compatibility.
 func getResourceConnector(ctx context.Context, providerConnector *queries.Connector, resourceConfig *model.ConfigModel) (*queries.Connector, diag.Diagnostics) {
@@ -41,15 +60,187 @@ func getResourceConnector(ctx context.Context, providerConnector *queries.Connec
 	return nil, diags
 }
 
-// connectToDatabase establishes a database connection using the provided connector and context.
+// getDatabaseConnector gets the connector for the database targeted by a resource or data source.
+// A null or empty databaseName targets the provider database. Other databases use a connector sharing
+// the provider authentication, cached per database so that their connection pool is shared.
+func getDatabaseConnector(ctx context.Context, providerConnector *queries.Connector, databaseName types.String) (*queries.Connector, diag.Diagnostics) {
+	connector, diags := getResourceConnector(ctx, providerConnector, nil)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if name := databaseName.ValueString(); name != "" {
+		tflog.Debug(ctx, "Using resource-level database", map[string]interface{}{
+			"database_name": name,
+		})
+		connector = connector.ForDatabase(name)
+	}
+
+	return connector, diags
+}
+
+// databaseNameResourceAttribute returns the database_name attribute of the resources.
+// Moving a resource to another database replaces it, see requireReplaceOnDatabaseMove.
+func databaseNameResourceAttribute() rschema.StringAttribute {
+	return rschema.StringAttribute{
+		Description:         "The database to manage the object in. Defaults to the database_name of the provider. Moving the object to another database replaces it.",
+		MarkdownDescription: "The database to manage the object in. Defaults to the `database_name` of the provider. Moving the object to another database replaces it.",
+		Optional:            true,
+	}
+}
+
+// effectiveDatabase returns the database a resource targets: its database_name, else the database of the provider.
+func effectiveDatabase(providerConnector *queries.Connector, databaseName types.String) string {
+	if databaseName.ValueString() != "" || providerConnector == nil {
+		return databaseName.ValueString()
+	}
+	return providerConnector.Database
+}
+
+// requireReplaceOnDatabaseMove replaces a resource whose database changes, comparing the names case-insensitively
+// like Connector.ForDatabase. A null database_name stands for the database of the provider, so setting it to that
+// database, or unsetting it, updates the resource in place. It runs in ModifyPlan rather than as a plan modifier
+// of the attribute, which does not know the database of the provider.
+func requireReplaceOnDatabaseMove(ctx context.Context, providerConnector *queries.Connector, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planned, current types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("database_name"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("database_name"), &current)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planned.IsUnknown() || !strings.EqualFold(effectiveDatabase(providerConnector, planned), effectiveDatabase(providerConnector, current)) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("database_name"))
+	}
+}
+
+// previousDatabaseRole returns the role named in the state of a resource whose role_name now names role.
+// The role resource is applied first, so a role renamed to role no longer exists under its former name,
+// and role itself is returned. Otherwise the resource moves from one role to another.
+func previousDatabaseRole(ctx context.Context, connector *queries.Connector, db *sql.DB, stateName types.String, role *qmodel.Role) (*qmodel.Role, error) {
+	if strings.EqualFold(stateName.ValueString(), role.Name) {
+		return role, nil
+	}
+
+	previous, err := connector.GetDatabaseRole(ctx, db, &qmodel.Role{Name: stateName.ValueString()})
+	if err != nil {
+		if err.Error() == "database role not found" {
+			return role, nil
+		}
+		return nil, err
+	}
+	return previous, nil
+}
+
+// databaseNameDataSourceAttribute returns the database_name attribute of the data sources.
+func databaseNameDataSourceAttribute() dschema.StringAttribute {
+	return dschema.StringAttribute{
+		Description:         "The database to read the object from. Defaults to the database_name of the provider.",
+		MarkdownDescription: "The database to read the object from. Defaults to the `database_name` of the provider.",
+		Optional:            true,
+	}
+}
+
+// principalImportID is the identifier of a database principal given to terraform import.
+type principalImportID struct {
+	Database    string
+	Name        string
+	PrincipalID int64
+	SID         string
+}
+
+// splitImportDatabase splits the optional database prefix, followed by a colon, from an import identifier.
+// The database is empty when the identifier has no prefix; the object then belongs to the provider database.
+func splitImportDatabase(id string) (database, rest string, err error) {
+	database, rest, found := strings.Cut(id, ":")
+	if !found {
+		return "", id, nil
+	}
+	if database == "" {
+		return "", "", fmt.Errorf("import identifier %q has an empty database name", id)
+	}
+	return database, rest, nil
+}
+
+// parsePrincipalImportID parses an import identifier of the form [database:]principal, where principal is
+// the SID of the principal in hexadecimal starting with 0x, its principal id, or else its name.
+// A principal whose name is a number, starts with 0x or contains a colon can only be imported by SID or principal id.
+func parsePrincipalImportID(id string) (principalImportID, error) {
+	var parsed principalImportID
+
+	database, principal, err := splitImportDatabase(id)
+	if err != nil {
+		return parsed, fmt.Errorf("%w, expected [database:]name, [database:]principal_id or [database:]0xSID", err)
+	}
+	parsed.Database = database
+
+	if principal == "" {
+		return parsed, fmt.Errorf("import identifier %q has no principal, expected [database:]name, [database:]principal_id or [database:]0xSID", id)
+	}
+
+	if digits, ok := strings.CutPrefix(strings.ToLower(principal), "0x"); ok {
+		if _, err := hex.DecodeString(digits); err != nil || digits == "" {
+			return parsed, fmt.Errorf("import identifier %q is not a valid hexadecimal SID", id)
+		}
+		parsed.SID = "0x" + strings.ToUpper(digits)
+		return parsed, nil
+	}
+
+	if principalID, err := strconv.ParseInt(principal, 10, 64); err == nil {
+		if principalID <= 0 {
+			return parsed, fmt.Errorf("import identifier %q has an invalid principal id, expected a positive number", id)
+		}
+		parsed.PrincipalID = principalID
+		return parsed, nil
+	}
+
+	parsed.Name = principal
+	return parsed, nil
+}
+
+// importPrincipalState sets the attribute identifying the imported principal, along with its database.
+// The Read that follows the import looks the principal up by that attribute and fills in the rest of the state.
+func importPrincipalState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parsePrincipalImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", err.Error())
+		return
+	}
+
+	setImportDatabase(ctx, id.Database, resp)
+
+	switch {
+	case id.SID != "":
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("sid"), id.SID)...)
+	case id.Name != "":
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id.Name)...)
+	default:
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal_id"), id.PrincipalID)...)
+	}
+}
+
+// setImportDatabase sets the database_name of an imported object, unless it belongs to the provider database.
+func setImportDatabase(ctx context.Context, database string, resp *resource.ImportStateResponse) {
+	if database != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_name"), database)...)
+	}
+}
+
+// connectToDatabase returns the connection pool of the provided connector, opening it on first use.
+// The pool is shared across operations and must not be closed by the caller.
 func connectToDatabase(ctx context.Context, connector *queries.Connector) (*sql.DB, error) {
 	tflog.Debug(ctx, "Connecting to database")
 	return connector.Connect()
 }
 
 // handleDatabaseConnectionError is a standardized error handler for database connection failures.
+// Failures to acquire an Entra ID access token are reported apart, as authentication failures.
 func handleDatabaseConnectionError(ctx context.Context, err error, diags *diag.Diagnostics) {
-	if err == nil {
+	if err == nil || handleAuthenticationError(ctx, err, diags) {
 		return
 	}
 
@@ -59,6 +250,26 @@ func handleDatabaseConnectionError(ctx context.Context, err error, diags *diag.D
 	diags.AddError("Database Connection Failed", err.Error())
 }
 
+// handleAuthenticationError adds an authentication diagnostic and returns true when err reports
+// that no Entra ID access token could be acquired.
+func handleAuthenticationError(ctx context.Context, err error, diags *diag.Diagnostics) bool {
+	var authErr *queries.AuthenticationError
+	if !errors.As(err, &authErr) {
+		return false
+	}
+
+	tflog.Error(ctx, "Entra ID authentication failed", map[string]interface{}{
+		"identity": authErr.Identity,
+		"error":    authErr.Err.Error(),
+	})
+	diags.AddError(
+		"Entra ID Authentication Failed",
+		"The provider cannot acquire an Entra ID access token for "+authErr.Identity+": "+authErr.Err.Error()+
+			". Check the credentials of the authentication method configured in the provider.",
+	)
+	return true
+}
+
 // logResourceOperation logs the start of a resource operation for debugging.
 func logResourceOperation(ctx context.Context, resourceType, operation string) {
 	tflog.Debug(ctx, "Resource operation started", map[string]interface{}{
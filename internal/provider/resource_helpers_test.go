@@ -7,12 +7,21 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"terraform-provider-mssqlpermissions/internal/provider/model"
 	"terraform-provider-mssqlpermissions/internal/queries"
+	qmodel "terraform-provider-mssqlpermissions/internal/queries/model"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestGetResourceConnector(t *testing.T) {
@@ -144,6 +153,23 @@ func TestHandleDatabaseConnectionError(t *testing.T) {
 		}
 	})
 
+	t.Run("AuthenticationError", func(t *testing.T) {
+		var diags diag.Diagnostics
+		authErr := &queries.AuthenticationError{Identity: "service principal client", Err: errors.New("AADSTS7000215: invalid client secret")}
+
+		handleDatabaseConnectionError(ctx, fmt.Errorf("error creating driver connector: %w", authErr), &diags)
+
+		if len(diags.Errors()) != 1 {
+			t.Fatalf("Expected one error, got: %v", diags)
+		}
+		if summary := diags.Errors()[0].Summary(); summary != "Entra ID Authentication Failed" {
+			t.Errorf("Expected error summary 'Entra ID Authentication Failed', got: %s", summary)
+		}
+		if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "service principal client") || !strings.Contains(detail, "AADSTS7000215") {
+			t.Errorf("Expected the identity and the credential error in the detail, got: %s", detail)
+		}
+	})
+
 	t.Run("NilError", func(t *testing.T) {
 		var diags diag.Diagnostics
 
@@ -220,6 +246,101 @@ func createTestConfigModel() *model.ConfigModel {
 	}
 }
 
+func TestGetDatabaseConnector(t *testing.T) {
+	ctx := context.Background()
+	providerConnector := &queries.Connector{Host: "sql.example.com", Database: "ApplicationDB"}
+
+	t.Run("ProviderDatabase", func(t *testing.T) {
+		for _, databaseName := range []types.String{types.StringNull(), types.StringValue(""), types.StringValue("ApplicationDB")} {
+			connector, diags := getDatabaseConnector(ctx, providerConnector, databaseName)
+			if diags.HasError() {
+				t.Errorf("Expected no diagnostics errors, got: %v", diags.Errors())
+			}
+			if connector != providerConnector {
+				t.Errorf("Expected the provider connector for database_name %s", databaseName)
+			}
+		}
+	})
+
+	t.Run("OtherDatabase", func(t *testing.T) {
+		connector, diags := getDatabaseConnector(ctx, providerConnector, types.StringValue("ReportingDB"))
+		if diags.HasError() {
+			t.Fatalf("Expected no diagnostics errors, got: %v", diags.Errors())
+		}
+		if connector == providerConnector || connector.Database != "ReportingDB" || connector.Host != providerConnector.Host {
+			t.Errorf("Expected a connector to ReportingDB on the provider server, got %+v", connector)
+		}
+
+		// Resources on the same database share the connector and its connection pool.
+		again, _ := getDatabaseConnector(ctx, providerConnector, types.StringValue("ReportingDB"))
+		if again != connector {
+			t.Error("Expected the same connector for the same database")
+		}
+	})
+
+	t.Run("NoProviderConnector", func(t *testing.T) {
+		connector, diags := getDatabaseConnector(ctx, nil, types.StringValue("ReportingDB"))
+		if !diags.HasError() {
+			t.Error("Expected diagnostics error when the provider is not configured")
+		}
+		if connector != nil {
+			t.Error("Expected nil connector when the provider is not configured")
+		}
+	})
+}
+
+func TestRequireReplaceOnDatabaseMove(t *testing.T) {
+	ctx := context.Background()
+	providerConnector := &queries.Connector{Host: "sql.example.com", Database: "ApplicationDB"}
+
+	tests := []struct {
+		name        string
+		current     types.String
+		planned     types.String
+		wantReplace bool
+	}{
+		{"Unchanged", types.StringValue("ReportingDB"), types.StringValue("ReportingDB"), false},
+		{"SetToProviderDatabase", types.StringNull(), types.StringValue("ApplicationDB"), false},
+		{"UnsetFromProviderDatabase", types.StringValue("applicationdb"), types.StringNull(), false},
+		{"OtherCase", types.StringValue("ReportingDB"), types.StringValue("reportingdb"), false},
+		{"MovedToOtherDatabase", types.StringNull(), types.StringValue("ReportingDB"), true},
+		{"MovedToProviderDatabase", types.StringValue("ReportingDB"), types.StringNull(), true},
+		{"Unknown", types.StringNull(), types.StringUnknown(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newImportStateResponse(NewUserResource()).State
+			plan := newImportStateResponse(NewUserResource()).State
+			state.SetAttribute(ctx, path.Root("database_name"), tt.current)
+			plan.SetAttribute(ctx, path.Root("database_name"), tt.planned)
+
+			resp := &resource.ModifyPlanResponse{}
+			requireReplaceOnDatabaseMove(ctx, providerConnector, resource.ModifyPlanRequest{State: state, Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if got := len(resp.RequiresReplace) == 1; got != tt.wantReplace {
+				t.Errorf("Expected replacement %v, got %v", tt.wantReplace, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestPreviousDatabaseRole_Renamed(t *testing.T) {
+	role := &qmodel.Role{Name: "Readers", PrincipalID: 5}
+
+	// A name differing only in case is the same role, and the database is not queried.
+	previous, err := previousDatabaseRole(context.Background(), nil, nil, types.StringValue("readers"), role)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if previous != role {
+		t.Errorf("Expected the planned role, got %v", previous)
+	}
+}
+
 // Integration test for the full helper workflow
 func TestResourceHelperWorkflow(t *testing.T) {
 	ctx := context.Background()
@@ -266,3 +387,174 @@ func TestResourceHelperWorkflow(t *testing.T) {
 		}
 	})
 }
+
+func TestResourceTimeouts(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		resource resource.Resource
+		model    interface{}
+	}{
+		{"DatabaseRole", NewDatabaseRoleResource(), &model.RoleModel{}},
+		{"DatabaseRoleMembers", NewDatabaseRoleMembersResource(), &model.RoleMembersWithTimeoutsModel{}},
+		{"Login", NewLoginResource(), &model.LoginResourceModel{}},
+		{"Permissions", NewPermissionsResource(), &model.PermissionResourceWithTimeoutsModel{}},
+		{"SchemaPermissions", NewSchemaPermissionsResource(), &model.SchemaPermissionResourceWithTimeoutsModel{}},
+		{"User", NewUserResource(), &model.UserResourceModel{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.SchemaResponse{}
+			tt.resource.Schema(ctx, resource.SchemaRequest{}, resp)
+
+			if _, ok := resp.Schema.Blocks["timeouts"]; !ok {
+				t.Fatal("Expected a timeouts block in the resource schema")
+			}
+
+			// The resource model must match the schema, timeouts included.
+			objectType := resp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			attributes := map[string]tftypes.Value{}
+			for name, attributeType := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+			state := tfsdk.State{Schema: resp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+
+			if diags := state.Get(ctx, tt.model); diags.HasError() {
+				t.Fatalf("Expected the model to match the schema, got: %v", diags)
+			}
+			if diags := state.Set(ctx, tt.model); diags.HasError() {
+				t.Fatalf("Expected the model to be set in the state, got: %v", diags)
+			}
+		})
+	}
+}
+
+func TestResourceTimeouts_Defaults(t *testing.T) {
+	ctx := context.Background()
+
+	value := timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{
+		"create": types.StringType,
+		"read":   types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	})}
+
+	createTimeout, diags := value.Create(ctx, defaultCreateTimeout)
+	if diags.HasError() || createTimeout != defaultCreateTimeout {
+		t.Errorf("Expected create timeout %v, got %v (%v)", defaultCreateTimeout, createTimeout, diags)
+	}
+
+	readTimeout, diags := value.Read(ctx, defaultReadTimeout)
+	if diags.HasError() || readTimeout != defaultReadTimeout {
+		t.Errorf("Expected read timeout %v, got %v (%v)", defaultReadTimeout, readTimeout, diags)
+	}
+}
+
+func TestParsePrincipalImportID(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		want    principalImportID
+		wantErr string
+	}{
+		{"Name", "app_user", principalImportID{Name: "app_user"}, ""},
+		{"PrincipalID", "5", principalImportID{PrincipalID: 5}, ""},
+		{"SID", "0x0105000000000009030000004f1b", principalImportID{SID: "0x0105000000000009030000004F1B"}, ""},
+		{"DatabaseAndName", "sales:app_user", principalImportID{Database: "sales", Name: "app_user"}, ""},
+		{"DatabaseAndPrincipalID", "sales:5", principalImportID{Database: "sales", PrincipalID: 5}, ""},
+		{"NameWithColon", "sales:app:user", principalImportID{Database: "sales", Name: "app:user"}, ""},
+		{"EntraName", "someone@example.com", principalImportID{Name: "someone@example.com"}, ""},
+		{"Empty", "", principalImportID{}, "has no principal"},
+		{"EmptyDatabase", ":app_user", principalImportID{}, "has an empty database name"},
+		{"EmptyPrincipal", "sales:", principalImportID{}, "has no principal"},
+		{"InvalidSID", "0x01G5", principalImportID{}, "is not a valid hexadecimal SID"},
+		{"EmptySID", "0x", principalImportID{}, "is not a valid hexadecimal SID"},
+		{"ZeroPrincipalID", "0", principalImportID{}, "has an invalid principal id"},
+		{"NegativePrincipalID", "sales:-3", principalImportID{}, "has an invalid principal id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePrincipalImportID(tt.id)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected an error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestImportPrincipalState(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		resource  resource.ResourceWithImportState
+		id        string
+		attribute string
+		want      attr.Value
+	}{
+		{"UserByName", NewUserResource().(*UserResource), "app_user", "name", types.StringValue("app_user")},
+		{"UserByPrincipalID", NewUserResource().(*UserResource), "sales:7", "principal_id", types.Int64Value(7)},
+		{"UserBySID", NewUserResource().(*UserResource), "0x01ab", "sid", types.StringValue("0x01AB")},
+		{"DatabaseRoleByName", NewDatabaseRoleResource().(*DatabaseRoleResource), "sales:app_role", "name", types.StringValue("app_role")},
+		{"DatabaseRoleByPrincipalID", NewDatabaseRoleResource().(*DatabaseRoleResource), "16384", "principal_id", types.Int64Value(16384)},
+		{"DatabaseRoleBySID", NewDatabaseRoleResource().(*DatabaseRoleResource), "0x01ab", "sid", types.StringValue("0x01AB")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := newImportStateResponse(tt.resource)
+
+			tt.resource.ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var got attr.Value
+			resp.State.GetAttribute(ctx, path.Root(tt.attribute), &got)
+			if !got.Equal(tt.want) {
+				t.Errorf("Expected %s to be %v, got %v", tt.attribute, tt.want, got)
+			}
+
+			var databaseName types.String
+			resp.State.GetAttribute(ctx, path.Root("database_name"), &databaseName)
+			if wantDatabase := strings.HasPrefix(tt.id, "sales:"); wantDatabase != (databaseName.ValueString() == "sales") {
+				t.Errorf("Unexpected database_name %v for import identifier %q", databaseName, tt.id)
+			}
+		})
+	}
+
+	t.Run("InvalidIdentifier", func(t *testing.T) {
+		resp := &resource.ImportStateResponse{}
+		NewUserResource().(*UserResource).ImportState(ctx, resource.ImportStateRequest{ID: "0xZZ"}, resp)
+
+		if len(resp.Diagnostics.Errors()) != 1 || resp.Diagnostics.Errors()[0].Summary() != "Invalid Import Identifier" {
+			t.Errorf("Expected an Invalid Import Identifier error, got: %v", resp.Diagnostics)
+		}
+	})
+}
+
+// newImportStateResponse returns an import response holding the empty state of r, as passed by the framework.
+func newImportStateResponse(r resource.Resource) *resource.ImportStateResponse {
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	return &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+}
@@ -40,6 +40,7 @@ func (d *schemaPermissionsDataSource) Schema(_ context.Context, req datasource.S
 		Description:         "Reads schema-level permissions assigned to a role.",
 		MarkdownDescription: "Reads schema-level permissions assigned to a role.",
 		Attributes: map[string]schema.Attribute{
+			"database_name": databaseNameDataSourceAttribute(),
 			"schema_name": schema.StringAttribute{
 				Description:         "The schema name.",
 				MarkdownDescription: "The schema name.",
@@ -135,7 +136,12 @@ func (d *schemaPermissionsDataSource) Read(ctx context.Context, req datasource.R
 		"role_name":   data.RoleName.ValueString(),
 	})
 
-	connector := d.connector
+	// Use the provider connector, or the connector to the database of the data source
+	connector, connectorDiags := getDatabaseConnector(ctx, d.connector, data.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database
 	db, err := connectToDatabase(ctx, connector)
@@ -6,10 +6,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"terraform-provider-mssqlpermissions/internal/provider/model"
 	"terraform-provider-mssqlpermissions/internal/queries"
 	qmodel "terraform-provider-mssqlpermissions/internal/queries/model"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -24,6 +27,7 @@ var _ resource.Resource = &SchemaPermissionsResource{}
 var _ resource.ResourceWithValidateConfig = &SchemaPermissionsResource{}
 var _ resource.ResourceWithImportState = &SchemaPermissionsResource{}
 var _ resource.ResourceWithConfigure = &SchemaPermissionsResource{}
+var _ resource.ResourceWithModifyPlan = &SchemaPermissionsResource{}
 
 func NewSchemaPermissionsResource() resource.Resource {
 	return &SchemaPermissionsResource{}
@@ -39,11 +43,12 @@ func (r *SchemaPermissionsResource) Metadata(_ context.Context, req resource.Met
 }
 
 // Schema defines the schema for the SchemaPermissionsResource.
-func (r *SchemaPermissionsResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *SchemaPermissionsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description:         "Schema-level permissions assigned to a database role.",
 		MarkdownDescription: "Schema-level permissions assigned to a database role.",
 		Attributes: map[string]schema.Attribute{
+			"database_name": databaseNameResourceAttribute(),
 			"schema_name": schema.StringAttribute{
 				Description:         "The schema name.",
 				MarkdownDescription: "The schema name.",
@@ -57,9 +62,6 @@ func (r *SchemaPermissionsResource) Schema(_ context.Context, req resource.Schem
 				Description:         "The database role's name.",
 				MarkdownDescription: "The database role's name.",
 				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 
 			"permissions": schema.ListNestedAttribute{
@@ -124,12 +126,20 @@ func (r *SchemaPermissionsResource) Schema(_ context.Context, req resource.Schem
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
 // ValidateConfig validates the configuration for the SchemaPermissionsResource.
 func (r *SchemaPermissionsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var config model.SchemaPermissionResourceModel
+	var config model.SchemaPermissionResourceWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 
@@ -204,6 +214,11 @@ func (r *SchemaPermissionsResource) ValidateConfig(ctx context.Context, req reso
 	}
 }
 
+// ModifyPlan replaces the resource when it moves to another database.
+func (r *SchemaPermissionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	requireReplaceOnDatabaseMove(ctx, r.connector, req, resp)
+}
+
 // Configure configures the resource with the provider configuration.
 func (r *SchemaPermissionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -224,16 +239,31 @@ func (r *SchemaPermissionsResource) Configure(ctx context.Context, req resource.
 
 // Create creates a new schema permissions resource.
 func (r *SchemaPermissionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var state model.SchemaPermissionResourceModel
+	var state model.SchemaPermissionResourceWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Bound the whole operation by the create timeout of the resource
+	createTimeout, timeoutDiags := state.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "SchemaPermissionsResource", "Create")
 
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -314,16 +344,31 @@ func (r *SchemaPermissionsResource) Create(ctx context.Context, req resource.Cre
 
 // Read reads the schema permissions resource.
 func (r *SchemaPermissionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state model.SchemaPermissionResourceModel
+	var state model.SchemaPermissionResourceWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Bound the whole operation by the read timeout of the resource
+	readTimeout, timeoutDiags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "SchemaPermissionsResource", "Read")
 
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -356,6 +401,20 @@ func (r *SchemaPermissionsResource) Read(ctx context.Context, req resource.ReadR
 	// Get the permissions for the role on the schema
 	var readPermissions []model.PermissionModel
 
+	// An imported resource has no permissions until its first read, which adopts every permission
+	// granted or denied to the role on the schema.
+	if state.Permissions.IsNull() {
+		schemaPermissions, err := connector.GetSchemaPermissionsForRole(ctx, db, role, schemaName)
+		if err != nil {
+			resp.Diagnostics.AddError("Error getting permissions for role on schema", err.Error())
+			return
+		}
+
+		for _, permission := range schemaPermissions {
+			readPermissions = append(readPermissions, newPermissionModel(&permission))
+		}
+	}
+
 	// Convert permissions list to slice for processing
 	permissions, diags := convertPermissionsListToSlice(ctx, state.Permissions)
 	if diags != nil {
@@ -410,8 +469,8 @@ func (r *SchemaPermissionsResource) Read(ctx context.Context, req resource.ReadR
 
 // Update updates the schema permissions resource.
 func (r *SchemaPermissionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var state model.SchemaPermissionResourceModel
-	var plan model.SchemaPermissionResourceModel
+	var state model.SchemaPermissionResourceWithTimeoutsModel
+	var plan model.SchemaPermissionResourceWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -419,9 +478,24 @@ func (r *SchemaPermissionsResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
+	// Bound the whole operation by the update timeout of the resource
+	updateTimeout, timeoutDiags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "SchemaPermissionsResource", "Update")
 
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, plan.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -432,7 +506,7 @@ func (r *SchemaPermissionsResource) Update(ctx context.Context, req resource.Upd
 
 	// Confirm that the role exists
 	role := &qmodel.Role{
-		Name: state.RoleName.ValueString(),
+		Name: plan.RoleName.ValueString(),
 	}
 
 	role, err = connector.GetDatabaseRole(ctx, db, role)
@@ -441,6 +515,14 @@ func (r *SchemaPermissionsResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
+	// The permissions of the state are revoked from the role they were granted to,
+	// which is the same role when it was only renamed.
+	previousRole, err := previousDatabaseRole(ctx, connector, db, state.RoleName, role)
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting role", err.Error())
+		return
+	}
+
 	schemaName := state.SchemaName.ValueString()
 
 	// As the permissions are defined in a list, the order is not guaranteed.
@@ -459,7 +541,7 @@ func (r *SchemaPermissionsResource) Update(ctx context.Context, req resource.Upd
 			Name: permissionState.Name.ValueString(),
 		}
 
-		err = connector.RevokePermissionOnSchemaFromRole(ctx, db, role, schemaName, permission)
+		err = connector.RevokePermissionOnSchemaFromRole(ctx, db, previousRole, schemaName, permission)
 		if err != nil {
 			resp.Diagnostics.AddError("Error revoking permission from role on schema", err.Error())
 			return
@@ -518,24 +600,39 @@ func (r *SchemaPermissionsResource) Update(ctx context.Context, req resource.Upd
 		resp.Diagnostics.Append(*diags...)
 		return
 	}
-	state.Permissions = updatedPermissionsList
+	plan.Permissions = updatedPermissionsList
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	logResourceOperationComplete(ctx, "SchemaPermissionsResource", "Update")
 }
 
 // Delete deletes the schema permissions resource.
 func (r *SchemaPermissionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state model.SchemaPermissionResourceModel
+	var state model.SchemaPermissionResourceWithTimeoutsModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Bound the whole operation by the delete timeout of the resource
+	deleteTimeout, timeoutDiags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	logResourceOperation(ctx, "SchemaPermissionsResource", "Delete")
 
-	connector := r.connector
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using helper function
 	db, err := connectToDatabase(ctx, connector)
@@ -589,11 +686,22 @@ func (r *SchemaPermissionsResource) Delete(ctx context.Context, req resource.Del
 	logResourceOperationComplete(ctx, "SchemaPermissionsResource", "Delete")
 }
 
-// ImportState implements resource.ResourceWithImportState.
+// ImportState imports the permissions of a role on a schema from an identifier of the form [database:]schema/role,
+// adopting every permission granted or denied to the role on the schema.
 func (r *SchemaPermissionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import is not implemented for this resource as it requires complex state reconstruction
-	resp.Diagnostics.AddError(
-		"Import Not Supported",
-		"Importing schema permissions is not currently supported. Please define the resource in your Terraform configuration.",
-	)
+	database, id, err := splitImportDatabase(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("%s, expected [database:]schema/role", err))
+		return
+	}
+
+	schemaName, roleName, found := strings.Cut(id, "/")
+	if !found || schemaName == "" || roleName == "" {
+		resp.Diagnostics.AddError("Invalid Import Identifier", fmt.Sprintf("import identifier %q must be of the form [database:]schema/role", req.ID))
+		return
+	}
+
+	setImportDatabase(ctx, database, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("schema_name"), schemaName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_name"), roleName)...)
 }
@@ -78,7 +78,7 @@ func TestSchemaPermissionsResource_Schema(t *testing.T) {
 		}
 	}
 
-	// Verify role_name has required plan modifier (requires replacement)
+	// Verify role_name is updated in place, so that renaming the role keeps the resource
 	roleNameAttr, exists := resp.Schema.Attributes["role_name"]
 	if !exists {
 		t.Error("Expected role_name attribute to exist")
@@ -86,8 +86,8 @@ func TestSchemaPermissionsResource_Schema(t *testing.T) {
 	}
 
 	if stringAttr, ok := roleNameAttr.(schema.StringAttribute); ok {
-		if len(stringAttr.PlanModifiers) == 0 {
-			t.Error("Expected role_name to have plan modifiers")
+		if len(stringAttr.PlanModifiers) != 0 {
+			t.Error("Expected role_name to have no plan modifiers")
 		}
 	}
 }
@@ -191,27 +191,35 @@ func TestSchemaPermissionsResource_Configure(t *testing.T) {
 }
 
 func TestSchemaPermissionsResource_ImportState(t *testing.T) {
-	r := &SchemaPermissionsResource{}
 	ctx := context.Background()
 
-	req := resource.ImportStateRequest{}
-	resp := &resource.ImportStateResponse{}
+	t.Run("SchemaAndRole", func(t *testing.T) {
+		resp := newImportStateResponse(&SchemaPermissionsResource{})
+		(&SchemaPermissionsResource{}).ImportState(ctx, resource.ImportStateRequest{ID: "sales:reporting/app_role"}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+		}
 
-	r.ImportState(ctx, req, resp)
+		var state model.SchemaPermissionResourceWithTimeoutsModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if state.SchemaName.ValueString() != "reporting" || state.RoleName.ValueString() != "app_role" || state.DatabaseName.ValueString() != "sales" {
+			t.Errorf("Unexpected state %+v", state.SchemaPermissionResourceModel)
+		}
+		// Read adopts every permission of the role on the schema when the permissions are null.
+		if !state.Permissions.IsNull() {
+			t.Errorf("Expected null permissions, got %v", state.Permissions)
+		}
+	})
 
-	// Import should add an error since it's not supported
-	if !resp.Diagnostics.HasError() {
-		t.Error("Expected ImportState to return an error for unsupported operation")
-	}
+	for _, id := range []string{"", "reporting", "reporting/", "/app_role"} {
+		t.Run("Invalid "+id, func(t *testing.T) {
+			resp := newImportStateResponse(&SchemaPermissionsResource{})
+			(&SchemaPermissionsResource{}).ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
 
-	errors := resp.Diagnostics.Errors()
-	if len(errors) == 0 {
-		t.Error("Expected at least one error in diagnostics")
-	} else {
-		summary := errors[0].Summary()
-		if summary != "Import Not Supported" {
-			t.Errorf("Expected error summary 'Import Not Supported', got: %s", summary)
-		}
+			if len(resp.Diagnostics.Errors()) != 1 || resp.Diagnostics.Errors()[0].Summary() != "Invalid Import Identifier" {
+				t.Errorf("Expected an Invalid Import Identifier error, got: %v", resp.Diagnostics)
+			}
+		})
 	}
 }
 
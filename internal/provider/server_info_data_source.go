@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-mssqlpermissions/internal/provider/model"
+	"terraform-provider-mssqlpermissions/internal/queries"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &serverInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &serverInfoDataSource{}
+)
+
+func NewServerInfoDataSource() datasource.DataSource {
+	return &serverInfoDataSource{}
+}
+
+type serverInfoDataSource struct {
+	connector *queries.Connector
+}
+
+// Metadata is a method that sets the metadata for the server_info data source.
+// It sets the TypeName field of the response to the concatenation of the ProviderTypeName from the request and "_server_info".
+func (d *serverInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_info"
+}
+
+// Schema defines the schema for the server_info data source.
+// Every attribute but the database is computed.
+func (d *serverInfoDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	databaseName := databaseNameDataSourceAttribute()
+	databaseName.Description = "The database to read the settings from. Defaults to the database_name of the provider."
+	databaseName.MarkdownDescription = "The database to read the settings from. Defaults to the `database_name` of the provider."
+	databaseName.Computed = true
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Server and database information data source. Exposes the version, edition and settings of the server and database the provider connects to, so that modules can branch on their capabilities.",
+
+		Attributes: map[string]schema.Attribute{
+			"database_name": databaseName,
+			"server_name": schema.StringAttribute{
+				Description:         "The server name.",
+				MarkdownDescription: "The server name.",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				Description:         "The full version banner of the server (@@VERSION).",
+				MarkdownDescription: "The full version banner of the server (`@@VERSION`).",
+				Computed:            true,
+			},
+			"product_version": schema.StringAttribute{
+				Description:         "The product version of the server, such as 16.0.4135.4.",
+				MarkdownDescription: "The product version of the server, such as `16.0.4135.4`.",
+				Computed:            true,
+			},
+			"major_version": schema.Int64Attribute{
+				Description:         "The major version of the server, such as 16 for SQL Server 2022.",
+				MarkdownDescription: "The major version of the server, such as `16` for SQL Server 2022.",
+				Computed:            true,
+			},
+			"minor_version": schema.Int64Attribute{
+				Description:         "The minor version of the server.",
+				MarkdownDescription: "The minor version of the server.",
+				Computed:            true,
+			},
+			"build_number": schema.Int64Attribute{
+				Description:         "The build number of the server.",
+				MarkdownDescription: "The build number of the server.",
+				Computed:            true,
+			},
+			"edition": schema.StringAttribute{
+				Description:         "The edition of the server, such as Enterprise Edition (64-bit) or SQL Azure.",
+				MarkdownDescription: "The edition of the server, such as `Enterprise Edition (64-bit)` or `SQL Azure`.",
+				Computed:            true,
+			},
+			"engine_edition": schema.Int64Attribute{
+				Description:         "The engine edition of the server, such as 3 for SQL Server Enterprise, 5 for Azure SQL Database or 8 for Azure SQL Managed Instance.",
+				MarkdownDescription: "The engine edition of the server, such as `3` for SQL Server Enterprise, `5` for Azure SQL Database or `8` for Azure SQL Managed Instance.",
+				Computed:            true,
+			},
+			"engine_edition_name": schema.StringAttribute{
+				Description:         "The product identified by the engine edition, such as Enterprise, Azure SQL Database or Azure SQL Managed Instance.",
+				MarkdownDescription: "The product identified by the engine edition, such as `Enterprise`, `Azure SQL Database` or `Azure SQL Managed Instance`.",
+				Computed:            true,
+			},
+			"is_azure": schema.BoolAttribute{
+				Description:         "Is the server an Azure SQL Database or an Azure SQL Managed Instance.",
+				MarkdownDescription: "Is the server an Azure SQL Database or an Azure SQL Managed Instance.",
+				Computed:            true,
+			},
+			"containment": schema.StringAttribute{
+				Description:         "The containment type of the database, NONE or PARTIAL.",
+				MarkdownDescription: "The containment type of the database, `NONE` or `PARTIAL`.",
+				Computed:            true,
+			},
+			"compatibility_level": schema.Int64Attribute{
+				Description:         "The compatibility level of the database.",
+				MarkdownDescription: "The compatibility level of the database.",
+				Computed:            true,
+			},
+			"collation": schema.StringAttribute{
+				Description:         "The collation of the database.",
+				MarkdownDescription: "The collation of the database.",
+				Computed:            true,
+			},
+			"default_language": schema.StringAttribute{
+				Description:         "The default language of the server.",
+				MarkdownDescription: "The default language of the server.",
+				Computed:            true,
+			},
+			"contained_database_authentication": schema.BoolAttribute{
+				Description:         "Is the contained database authentication option of the server enabled.",
+				MarkdownDescription: "Is the `contained database authentication` option of the server enabled.",
+				Computed:            true,
+			},
+			"default_language_supported": schema.BoolAttribute{
+				Description:         "Can the users with a password of the database have a default_language. Only contained databases outside of Azure support it.",
+				MarkdownDescription: "Can the users with a password of the database have a `default_language`. Only contained databases outside of Azure support it.",
+				Computed:            true,
+			},
+			"login_name": schema.StringAttribute{
+				Description:         "The login the provider is connected with.",
+				MarkdownDescription: "The login the provider is connected with.",
+				Computed:            true,
+			},
+			"user_name": schema.StringAttribute{
+				Description:         "The database user the provider is connected as.",
+				MarkdownDescription: "The database user the provider is connected as.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure is called by the framework to pass provider-level configuration to the data source.
+func (d *serverInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*queries.Connector)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			"Expected *queries.Connector, got something else. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.connector = connector
+}
+
+// Read is a method that reads the server_info data source.
+func (d *serverInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var state model.ServerInfoDataModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connector, connectorDiags := getDatabaseConnector(ctx, d.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to the database using the shared connection pool.
+	tflog.Debug(ctx, "serverInfoDataSource: connect to the database")
+	db, err := connectToDatabase(ctx, connector)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to the database", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "serverInfoDataSource: get the server information")
+	info, err := connector.GetServerInfo(ctx, db)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting server information", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "serverInfoDataSource: populate the state object (model.ServerInfoDataModel)")
+	state.DatabaseName = types.StringValue(info.DatabaseName)
+	state.ServerName = types.StringValue(info.ServerName)
+	state.Version = types.StringValue(info.Version)
+	state.ProductVersion = types.StringValue(info.ProductVersion)
+	state.MajorVersion = types.Int64Value(info.MajorVersion)
+	state.MinorVersion = types.Int64Value(info.MinorVersion)
+	state.BuildNumber = types.Int64Value(info.BuildNumber)
+	state.Edition = types.StringValue(info.Edition)
+	state.EngineEdition = types.Int64Value(info.EngineEdition)
+	state.EngineEditionName = types.StringValue(info.EngineEditionName)
+	state.IsAzure = types.BoolValue(info.IsAzure)
+	state.Containment = types.StringValue(info.Containment)
+	state.CompatibilityLevel = types.Int64Value(info.CompatibilityLevel)
+	state.Collation = types.StringValue(info.Collation)
+	state.DefaultLanguage = types.StringValue(info.DefaultLanguage)
+	state.ContainedDatabaseAuthentication = types.BoolValue(info.ContainedDatabaseAuthentication)
+	state.DefaultLanguageSupported = types.BoolValue(info.DefaultLanguageSupported)
+	state.LoginName = types.StringValue(info.LoginName)
+	state.UserName = types.StringValue(info.UserName)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
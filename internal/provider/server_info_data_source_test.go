@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccServerInfoDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccServerInfoDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssqlpermissions_server_info.test", "database_name", "ApplicationDB"),
+					resource.TestCheckResourceAttr("data.mssqlpermissions_server_info.test", "is_azure", "false"),
+					resource.TestCheckResourceAttr("data.mssqlpermissions_server_info.test", "login_name", "sa"),
+					resource.TestCheckResourceAttr("data.mssqlpermissions_server_info.test", "user_name", "dbo"),
+					resource.TestCheckResourceAttrSet("data.mssqlpermissions_server_info.test", "major_version"),
+					resource.TestCheckResourceAttrSet("data.mssqlpermissions_server_info.test", "compatibility_level"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServerInfoDataSourceConfig() string {
+	return fmt.Sprintf(`
+provider "mssqlpermissions" {
+	server_fqdn   = %q
+	server_port   = %q
+	database_name = "ApplicationDB"
+
+	sql_login = {
+		username = "sa"
+		password = "P@ssw0rd"
+	}
+}
+
+data "mssqlpermissions_server_info" "test" {}
+`, os.Getenv("LOCAL_SQL_HOST"), os.Getenv("LOCAL_SQL_PORT"))
+}
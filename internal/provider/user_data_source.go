@@ -47,6 +47,7 @@ func (d *userDataSource) Schema(_ context.Context, req datasource.SchemaRequest,
 		MarkdownDescription: "User data source.",
 
 		Attributes: map[string]schema.Attribute{
+			"database_name": databaseNameDataSourceAttribute(),
 			"name": schema.StringAttribute{
 				Description:         "The user name.",
 				MarkdownDescription: "The user name.",
@@ -77,10 +78,15 @@ func (d *userDataSource) Schema(_ context.Context, req datasource.SchemaRequest,
 				Computed:            true,
 			},
 			"object_id": schema.StringAttribute{
-				Description:         "The user object id.",
-				MarkdownDescription: "The user object id.",
+				Description:         "The user object id, read from the SID of external users: the object id of users and groups, but the application (client) id of service principals and managed identities.",
+				MarkdownDescription: "The user object id, read from the SID of external users: the object id of users and groups, but the application (client) id of service principals and managed identities.",
 				Optional:            true,
 			},
+			"login_name": schema.StringAttribute{
+				Description:         "The server login the user is mapped to, if any.",
+				MarkdownDescription: "The server login the user is mapped to, if any.",
+				Computed:            true,
+			},
 			"sid": schema.StringAttribute{
 				Description:         "The user SID.",
 				MarkdownDescription: "The user SID.",
@@ -125,11 +131,15 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	tflog.Debug(ctx, "userDataSource: using provider connector")
+	connector, connectorDiags := getDatabaseConnector(ctx, d.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Set up the context and connect to the database.
-	dbCtx := context.Background()
+	// Connect to the database using the shared connection pool.
 	tflog.Debug(ctx, "userDataSource: connect to the database")
-	db, err := d.connector.Connect()
+	db, err := connectToDatabase(ctx, connector)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error connecting to the database", err.Error())
@@ -142,7 +152,7 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	tflog.Debug(ctx, "userDataSource: get the user")
-	user, err = d.connector.GetUser(dbCtx, db, user)
+	user, err = connector.GetUser(ctx, db, user)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error getting user", err.Error())
@@ -163,6 +173,12 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		state.ObjectID = types.StringValue(user.ObjectID)
 	}
 
+	if user.LoginName == "" {
+		state.LoginName = types.StringNull()
+	} else {
+		state.LoginName = types.StringValue(user.LoginName)
+	}
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
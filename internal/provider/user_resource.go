@@ -6,17 +6,23 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"terraform-provider-mssqlpermissions/internal/provider/model"
 	"terraform-provider-mssqlpermissions/internal/queries"
 	qmodel "terraform-provider-mssqlpermissions/internal/queries/model"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -24,6 +30,8 @@ import (
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
 var _ resource.ResourceWithConfigure = &UserResource{}
+var _ resource.ResourceWithValidateConfig = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
 
 func NewUserResource() resource.Resource {
 	return &UserResource{}
@@ -61,28 +69,60 @@ func (r *UserResource) Metadata(_ context.Context, req resource.MetadataRequest,
 
 // Schema is a method that sets the schema for the UserResource.
 // It defines the attributes and their properties for the user resource.
-// The attributes include the user name, password, external flag, contained flag,
+// The attributes include the user name, password, write-only password and what rotates it, external flag,
 // login name, principal id, default schema, default language, object id, and SID.
-func (r *UserResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "User resource.",
 
 		Attributes: map[string]schema.Attribute{
 
+			"database_name": databaseNameResourceAttribute(),
 			"name": schema.StringAttribute{
-				Description:         "The user name.",
-				MarkdownDescription: "The user name.",
+				Description:         "The user name. Changing it renames the user in place.",
+				MarkdownDescription: "The user name. Changing it renames the user in place.",
 				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"password": schema.StringAttribute{
-				Description:         "The user password.",
-				MarkdownDescription: "The user password.",
+				Description:         "The user password. It is stored in the plan and state: prefer password_wo. Conflicts with password_wo.",
+				MarkdownDescription: "The user password. It is stored in the plan and state: prefer `password_wo`. Conflicts with `password_wo`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"password_wo": schema.StringAttribute{
+				Description:         "The user password, write-only: it never lands in the plan or state. Requires Terraform 1.11 or later and at least one of password_wo_version, rotation_trigger and rotate_after. The password is only set when the user is created or one of them rotates it. Generate it with the mssqlpermissions_password ephemeral resource to keep it out of state altogether.",
+				MarkdownDescription: "The user password, write-only: it never lands in the plan or state. Requires Terraform 1.11 or later and at least one of `password_wo_version`, `rotation_trigger` and `rotate_after`. The password is only set when the user is created or one of them rotates it. Generate it with the `mssqlpermissions_password` ephemeral resource to keep it out of state altogether.",
 				Optional:            true,
 				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Description:         "The version of password_wo. Change it to rotate the password.",
+				MarkdownDescription: "The version of `password_wo`. Change it to rotate the password.",
+				Optional:            true,
+			},
+			"rotation_trigger": schema.StringAttribute{
+				Description:         "An arbitrary value. Changing it rotates password_wo.",
+				MarkdownDescription: "An arbitrary value. Changing it rotates `password_wo`.",
+				Optional:            true,
+			},
+			"rotate_after": schema.StringAttribute{
+				Description:         "The age after which password_wo is rotated, as a duration such as 720h. The rotation is planned on the first run after the password expires.",
+				MarkdownDescription: "The age after which `password_wo` is rotated, as a duration such as `720h`. The rotation is planned on the first run after the password expires.",
+				Optional:            true,
+			},
+			"password_rotated_at": schema.StringAttribute{
+				Description:         "When the provider last set the password, in RFC 3339 format.",
+				MarkdownDescription: "When the provider last set the password, in RFC 3339 format.",
+				Computed:            true,
+			},
+			"old_password_wo": schema.StringAttribute{
+				Description:         "The current password of the user, write-only. When set, SQL Server checks it before changing the password (OLD_PASSWORD).",
+				MarkdownDescription: "The current password of the user, write-only. When set, SQL Server checks it before changing the password (`OLD_PASSWORD`).",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
 			},
 			"external": schema.BoolAttribute{
 				Description:         "Is the user external.",
@@ -95,9 +135,12 @@ func (r *UserResource) Schema(_ context.Context, req resource.SchemaRequest, res
 				},
 			},
 			"principal_id": schema.Int64Attribute{
-				Description:         "The user principal id.",
-				MarkdownDescription: "The user principal id.",
+				Description:         "The user principal id, used along with the SID to find the user again after a rename.",
+				MarkdownDescription: "The user principal id, used along with the SID to find the user again after a rename.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"default_schema": schema.StringAttribute{
 				Description:         "The user default schema.",
@@ -112,17 +155,179 @@ func (r *UserResource) Schema(_ context.Context, req resource.SchemaRequest, res
 				Computed:            true,
 			},
 			"object_id": schema.StringAttribute{
-				Description:         "The user object id.",
-				MarkdownDescription: "The user object id.",
+				Description:         "The user object id. Read from the SID of external users when not set, in which case it is the application (client) id of service principals and managed identities, as held in their SID.",
+				MarkdownDescription: "The user object id. Read from the SID of external users when not set, in which case it is the application (client) id of service principals and managed identities, as held in their SID.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"login_name": schema.StringAttribute{
+				Description:         "The server login the user is mapped to. The user is created FOR LOGIN instead of WITH PASSWORD, which doesn't require a contained database. Conflicts with password and external.",
+				MarkdownDescription: "The server login the user is mapped to. The user is created `FOR LOGIN` instead of `WITH PASSWORD`, which doesn't require a contained database. Conflicts with `password` and `external`.",
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"sid": schema.StringAttribute{
 				Description:         "The user SID.",
 				MarkdownDescription: "The user SID.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig checks that the password is given either in state or write-only, with a way to rotate the latter,
+// and warns when a known password breaks the SQL Server complexity rules for the name of the user.
+func (r *UserResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config model.UserResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Password.IsNull() && !config.PasswordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_wo"),
+			"Conflicting Password Attributes",
+			"Set either password or password_wo, not both. password_wo keeps the password out of the plan and state.",
+		)
+	}
+
+	rotated := !config.PasswordVersion.IsNull() || !config.RotationTrigger.IsNull() || !config.RotateAfter.IsNull()
+
+	if !config.PasswordWO.IsNull() && !rotated {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_wo_version"),
+			"Missing Password Version",
+			"password_wo_version, rotation_trigger or rotate_after is required with password_wo. The password is only set again when one of them rotates it.",
+		)
+	}
+
+	if config.PasswordWO.IsNull() && rotated {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_wo"),
+			"Missing Write-Only Password",
+			"password_wo_version, rotation_trigger and rotate_after rotate password_wo, which is not set.",
+		)
+	}
+
+	if !config.RotateAfter.IsNull() && !config.RotateAfter.IsUnknown() {
+		if period, err := time.ParseDuration(config.RotateAfter.ValueString()); err != nil || period <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rotate_after"),
+				"Invalid Rotation Period",
+				fmt.Sprintf("rotate_after must be a positive duration such as 720h, got %q.", config.RotateAfter.ValueString()),
+			)
+		}
+	}
+
+	// The write-only password of an ephemeral resource is only known once the plan opens it
+	for attribute, password := range map[string]types.String{"password": config.Password, "password_wo": config.PasswordWO} {
+		if password.IsNull() || password.IsUnknown() {
+			continue
+		}
+		// The rules only apply when the server enforces a password policy, so SQL Server has the final say
+		if err := queries.CheckPasswordComplexity(password.ValueString(), config.Name.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root(attribute),
+				"Weak Password",
+				fmt.Sprintf("The password does not meet the SQL Server complexity rules: %s. SQL Server rejects it when it enforces a password policy.", err),
+			)
+		}
+	}
+
+	if !config.OldPasswordWO.IsNull() && config.Password.IsNull() && config.PasswordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("old_password_wo"),
+			"Missing Password",
+			"old_password_wo is only checked when the password changes: set password or password_wo.",
+		)
+	}
+}
+
+// ModifyPlan replaces the user when it moves to another database, and plans the rotation of the password.
+// password_rotated_at is left unknown when the password is set again, which is also what makes an expired
+// password show up as a change.
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	requireReplaceOnDatabaseMove(ctx, r.connector, req, resp)
+
+	// Nothing to rotate on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state model.UserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rotatedAt := state.PasswordRotatedAt
+	if passwordRotationDue(plan, state, time.Now()) {
+		tflog.Debug(ctx, "Planning the rotation of the user password")
+		rotatedAt = types.StringUnknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password_rotated_at"), rotatedAt)...)
+}
+
+// passwordRotationDue tells whether the planned user gets its password set again: its version, rotation trigger
+// or plain password changed, or it is older than rotate_after at now.
+func passwordRotationDue(plan, state model.UserResourceModel, now time.Time) bool {
+	if !plan.PasswordVersion.Equal(state.PasswordVersion) && !plan.PasswordVersion.IsNull() {
+		return true
+	}
+	if !plan.RotationTrigger.Equal(state.RotationTrigger) && !plan.RotationTrigger.IsNull() {
+		return true
+	}
+	if !plan.Password.Equal(state.Password) && !plan.Password.IsNull() {
+		return true
+	}
+
+	if plan.RotateAfter.IsNull() || plan.RotateAfter.IsUnknown() {
+		return false
+	}
+	period, err := time.ParseDuration(plan.RotateAfter.ValueString())
+	if err != nil || period <= 0 {
+		return false
+	}
+
+	// A password set before it was tracked is rotated right away
+	rotatedAt, err := time.Parse(time.RFC3339, state.PasswordRotatedAt.ValueString())
+	if err != nil {
+		return true
 	}
+	return !now.Before(rotatedAt.Add(period))
+}
+
+// userWriteOnlyPasswords returns the write-only password and old password of the configuration.
+// Write-only attributes are only available in the configuration, never in the plan or state.
+func userWriteOnlyPasswords(ctx context.Context, config tfsdk.Config) (string, string, diag.Diagnostics) {
+	var password, oldPassword types.String
+	var diags diag.Diagnostics
+
+	diags.Append(config.GetAttribute(ctx, path.Root("password_wo"), &password)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("old_password_wo"), &oldPassword)...)
+
+	return password.ValueString(), oldPassword.ValueString(), diags
 }
 
 // Create creates a new user resource in the database.
@@ -142,8 +347,22 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Use provider connector
-	connector := r.connector
+	// Bound the whole operation by the create timeout of the resource
+	createTimeout, timeoutDiags := state.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using proper context
 	db, err := connectToDatabase(ctx, connector)
@@ -152,6 +371,12 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	passwordWO, _, diags := userWriteOnlyPasswords(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	user := &qmodel.User{
 		Name:            state.Name.ValueString(),
 		Password:        state.Password.ValueString(),
@@ -159,7 +384,12 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		DefaultSchema:   state.DefaultSchema.ValueString(),
 		DefaultLanguage: state.DefaultLanguage.ValueString(),
 		ObjectID:        state.ObjectID.ValueString(),
+		LoginName:       state.LoginName.ValueString(),
+	}
+	if passwordWO != "" {
+		user.Password = passwordWO
 	}
+	passwordSet := user.Password != ""
 
 	tflog.Debug(ctx, "Creating user")
 	err = connector.CreateUser(ctx, db, user)
@@ -189,6 +419,18 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		state.ObjectID = types.StringValue(user.ObjectID)
 	}
 
+	if user.LoginName == "" {
+		state.LoginName = types.StringNull()
+	} else {
+		state.LoginName = types.StringValue(user.LoginName)
+	}
+
+	if passwordSet {
+		state.PasswordRotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	} else {
+		state.PasswordRotatedAt = types.StringNull()
+	}
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -213,8 +455,22 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	// Use provider connector
-	connector := r.connector
+	// Bound the whole operation by the delete timeout of the resource
+	deleteTimeout, timeoutDiags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using proper context
 	db, err := connectToDatabase(ctx, connector)
@@ -252,8 +508,22 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Use provider connector
-	connector := r.connector
+	// Bound the whole operation by the read timeout of the resource
+	readTimeout, timeoutDiags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using proper context
 	db, err := connectToDatabase(ctx, connector)
@@ -262,10 +532,17 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	// A managed user is found by its principal id and SID, which survive a rename made outside of Terraform.
+	// An imported user only has its name, principal id or SID in state.
 	user := &qmodel.User{
-		Name:        state.Name.ValueString(),
 		PrincipalID: state.PrincipalID.ValueInt64(),
+		SID:         state.SID.ValueString(),
 		External:    state.External.ValueBool(),
+		ObjectID:    state.ObjectID.ValueString(),
+		LoginName:   state.LoginName.ValueString(),
+	}
+	if state.PrincipalID.IsNull() || state.PrincipalID.IsUnknown() {
+		user.Name = state.Name.ValueString()
 	}
 
 	tflog.Debug(ctx, "Reading user from database")
@@ -299,6 +576,12 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		state.ObjectID = types.StringValue(user.ObjectID)
 	}
 
+	if user.LoginName == "" {
+		state.LoginName = types.StringNull()
+	} else {
+		state.LoginName = types.StringValue(user.LoginName)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -313,18 +596,33 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 // If any errors occur during the process, they are added to the response's diagnostics.
 func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 
-	var state model.UserResourceModel
+	var state, priorState model.UserResourceModel
 	var err error
 
 	logResourceOperation(ctx, "User", "Update")
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Use provider connector
-	connector := r.connector
+	// Bound the whole operation by the update timeout of the resource
+	updateTimeout, timeoutDiags := state.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Use the provider connector, or the connector to the database of the resource
+	connector, connectorDiags := getDatabaseConnector(ctx, r.connector, state.DatabaseName)
+	resp.Diagnostics.Append(connectorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Connect to database using proper context
 	db, err := connectToDatabase(ctx, connector)
@@ -333,13 +631,43 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	// The user is renamed first, so that the other changes apply to its new name.
+	if priorState.Name.ValueString() != state.Name.ValueString() {
+		tflog.Debug(ctx, "Renaming user")
+		err = connector.RenameUser(ctx, db, &qmodel.User{Name: priorState.Name.ValueString()}, state.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error renaming user", err.Error())
+			return
+		}
+	}
+
+	passwordWO, oldPasswordWO, diags := userWriteOnlyPasswords(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	user := &qmodel.User{
 		Name:            state.Name.ValueString(),
-		Password:        state.Password.ValueString(),
 		External:        state.External.ValueBool(),
 		DefaultSchema:   state.DefaultSchema.ValueString(),
 		DefaultLanguage: state.DefaultLanguage.ValueString(),
+		ObjectID:        state.ObjectID.ValueString(),
+		LoginName:       state.LoginName.ValueString(),
+	}
+
+	// The password is only changed when it differs from the last apply, or when ModifyPlan planned the
+	// rotation of the write-only password, so that an unchanged password is not set again on every update.
+	if !state.Password.Equal(priorState.Password) {
+		user.Password = state.Password.ValueString()
+	}
+	if state.PasswordRotatedAt.IsUnknown() && passwordWO != "" {
+		user.Password = passwordWO
+	}
+	if user.Password != "" {
+		user.OldPassword = oldPasswordWO
 	}
+	passwordSet := user.Password != ""
 
 	tflog.Debug(ctx, "Updating user")
 	err = connector.UpdateUser(ctx, db, user)
@@ -369,6 +697,18 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		state.ObjectID = types.StringValue(user.ObjectID)
 	}
 
+	if user.LoginName == "" {
+		state.LoginName = types.StringNull()
+	} else {
+		state.LoginName = types.StringValue(user.LoginName)
+	}
+
+	if passwordSet {
+		state.PasswordRotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	} else {
+		state.PasswordRotatedAt = priorState.PasswordRotatedAt
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -377,7 +717,8 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	logResourceOperationComplete(ctx, "User", "Update")
 }
 
-// ImportState implements resource.ResourceWithImportState.
+// ImportState imports a user by name, principal id or SID, optionally prefixed by its database.
+// The password of a contained user cannot be read back.
 func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	panic("not implemented")
+	importPrincipalState(ctx, req, resp)
 }
@@ -110,6 +110,55 @@ func TestHandleDatabaseRoleReadError(t *testing.T) {
 	}
 }
 
+// TestHandleLoginReadError tests the actual error handling function used by the login resource
+func TestHandleLoginReadError(t *testing.T) {
+	tests := []struct {
+		name                   string
+		err                    error
+		expectedShouldRemove   bool
+		expectedShouldAddError bool
+		expectedErrorMessage   string
+	}{
+		{
+			name:                   "No error - should not remove or add error",
+			err:                    nil,
+			expectedShouldRemove:   false,
+			expectedShouldAddError: false,
+		},
+		{
+			name:                   "Login not found - should remove from state",
+			err:                    errors.New("login not found"),
+			expectedShouldRemove:   true,
+			expectedShouldAddError: false,
+		},
+		{
+			name:                   "Permission denied - should add error",
+			err:                    errors.New("permission denied"),
+			expectedShouldRemove:   false,
+			expectedShouldAddError: true,
+			expectedErrorMessage:   "Error getting login",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := HandleLoginReadError(tt.err)
+
+			if result.ShouldRemoveFromState != tt.expectedShouldRemove {
+				t.Errorf("Expected ShouldRemoveFromState to be %v, got %v", tt.expectedShouldRemove, result.ShouldRemoveFromState)
+			}
+
+			if result.ShouldAddError != tt.expectedShouldAddError {
+				t.Errorf("Expected ShouldAddError to be %v, got %v", tt.expectedShouldAddError, result.ShouldAddError)
+			}
+
+			if tt.expectedErrorMessage != "" && result.ErrorMessage != tt.expectedErrorMessage {
+				t.Errorf("Expected ErrorMessage to be '%s', got '%s'", tt.expectedErrorMessage, result.ErrorMessage)
+			}
+		})
+	}
+}
+
 // TestHandlePermissionReadError tests the actual error handling function used by permissions resource
 func TestHandlePermissionReadError(t *testing.T) {
 	tests := []struct {
@@ -10,9 +10,12 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccUserResource(t *testing.T) {
+	var principalID string
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -23,8 +26,18 @@ func TestAccUserResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("mssqlpermissions_user.test", "name", "one"),
 					resource.TestCheckResourceAttr("mssqlpermissions_user.test", "password", "P@ssw0rd"),
+					testAccCheckSamePrincipalID("mssqlpermissions_user.test", &principalID),
 				),
 			},
+			// ImportState testing
+			{
+				ResourceName:                         "mssqlpermissions_user.test",
+				ImportState:                          true,
+				ImportStateId:                        "one",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerifyIgnore:              []string{"password"},
+			},
 			// Update and Read testing
 			{
 				Config: testAccUserResourceConfig("one", "P@ssw0rd!"),
@@ -32,6 +45,14 @@ func TestAccUserResource(t *testing.T) {
 					resource.TestCheckResourceAttr("mssqlpermissions_user.test", "password", "P@ssw0rd!"),
 				),
 			},
+			// Rename in place and Read testing
+			{
+				Config: testAccUserResourceConfig("renamed", "P@ssw0rd!"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mssqlpermissions_user.test", "name", "renamed"),
+					testAccCheckSamePrincipalID("mssqlpermissions_user.test", &principalID),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 
 			// TODO: Add external deletion test scenario:
@@ -63,3 +84,127 @@ resource "mssqlpermissions_user" "test" {
 }
 `, os.Getenv("LOCAL_SQL_HOST"), os.Getenv("LOCAL_SQL_PORT"), name, password)
 }
+
+func TestAccUserResourceWriteOnlyPassword(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing: the password stays out of the state
+			{
+				Config: testAccUserResourceWriteOnlyConfig("P@ssw0rd", "", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mssqlpermissions_user.test", "password_wo_version", "1"),
+					resource.TestCheckNoResourceAttr("mssqlpermissions_user.test", "password_wo"),
+					resource.TestCheckNoResourceAttr("mssqlpermissions_user.test", "password"),
+				),
+			},
+			// A new password without a new version is not applied
+			{
+				Config:   testAccUserResourceWriteOnlyConfig("P@ssw0rd!", "", 1),
+				PlanOnly: true,
+			},
+			// Rotation checking the old password
+			{
+				Config: testAccUserResourceWriteOnlyConfig("P@ssw0rd!", "P@ssw0rd", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mssqlpermissions_user.test", "password_wo_version", "2"),
+					resource.TestCheckNoResourceAttr("mssqlpermissions_user.test", "old_password_wo"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccUserResourceWriteOnlyConfig(password string, oldPassword string, version int) string {
+	oldPasswordAttribute := ""
+	if oldPassword != "" {
+		oldPasswordAttribute = fmt.Sprintf("old_password_wo = %q", oldPassword)
+	}
+
+	return fmt.Sprintf(`
+provider "mssqlpermissions" {
+	server_fqdn   = %q
+	server_port   = %q
+	database_name = "ApplicationDB"
+
+	sql_login = {
+		username = "sa"
+		password = "P@ssw0rd"
+	}
+}
+
+resource "mssqlpermissions_user" "test" {
+	name                = "write_only_user"
+	password_wo         = %q
+	password_wo_version = %d
+	%s
+}
+`, os.Getenv("LOCAL_SQL_HOST"), os.Getenv("LOCAL_SQL_PORT"), password, version, oldPasswordAttribute)
+}
+
+func TestAccUserResourceGeneratedPassword(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing: the generated password is set and its rotation recorded
+			{
+				Config: testAccUserResourceGeneratedPasswordConfig("2026-09"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mssqlpermissions_user.test", "rotation_trigger", "2026-09"),
+					resource.TestCheckResourceAttrSet("mssqlpermissions_user.test", "password_rotated_at"),
+					resource.TestCheckNoResourceAttr("mssqlpermissions_user.test", "password_wo"),
+				),
+			},
+			// A password generated again on every run is not applied until the rotation is due
+			{
+				Config:   testAccUserResourceGeneratedPasswordConfig("2026-09"),
+				PlanOnly: true,
+			},
+			// Rotation on a new trigger
+			{
+				Config: testAccUserResourceGeneratedPasswordConfig("2026-10"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mssqlpermissions_user.test", "rotation_trigger", "2026-10"),
+					resource.TestCheckResourceAttrSet("mssqlpermissions_user.test", "password_rotated_at"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccUserResourceGeneratedPasswordConfig(trigger string) string {
+	return fmt.Sprintf(`
+provider "mssqlpermissions" {
+	server_fqdn   = %q
+	server_port   = %q
+	database_name = "ApplicationDB"
+
+	sql_login = {
+		username = "sa"
+		password = "P@ssw0rd"
+	}
+}
+
+ephemeral "mssqlpermissions_password" "test" {
+	length    = 24
+	user_name = "generated_user"
+}
+
+resource "mssqlpermissions_user" "test" {
+	name             = "generated_user"
+	password_wo      = ephemeral.mssqlpermissions_password.test.result
+	rotation_trigger = %q
+	rotate_after     = "720h"
+}
+`, os.Getenv("LOCAL_SQL_HOST"), os.Getenv("LOCAL_SQL_PORT"), trigger)
+}
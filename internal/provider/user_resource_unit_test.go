@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-mssqlpermissions/internal/provider/model"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newUserConfig returns a user configuration holding the given attribute values.
+func newUserConfig(t *testing.T, values map[string]interface{}) tfsdk.Config {
+	t.Helper()
+
+	state := newImportStateResponse(NewUserResource()).State
+	for name, value := range values {
+		if diags := state.SetAttribute(context.Background(), path.Root(name), value); diags.HasError() {
+			t.Fatalf("Unexpected diagnostics: %v", diags)
+		}
+	}
+
+	return tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+}
+
+func TestUserResource_ValidateConfig(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		values      map[string]interface{}
+		wantSummary string
+		wantWarning string
+	}{
+		{
+			name:   "Password",
+			values: map[string]interface{}{"name": "app_user", "password": "P@ssw0rd"},
+		},
+		{
+			name:   "WriteOnlyPassword",
+			values: map[string]interface{}{"name": "app_user", "password_wo": "P@ssw0rd", "password_wo_version": int64(1), "old_password_wo": "Old_P@ssw0rd"},
+		},
+		{
+			name:        "BothPasswords",
+			values:      map[string]interface{}{"name": "app_user", "password": "P@ssw0rd", "password_wo": "P@ssw0rd", "password_wo_version": int64(1)},
+			wantSummary: "Conflicting Password Attributes",
+		},
+		{
+			name:        "WriteOnlyPasswordWithoutVersion",
+			values:      map[string]interface{}{"name": "app_user", "password_wo": "P@ssw0rd"},
+			wantSummary: "Missing Password Version",
+		},
+		{
+			name:        "VersionWithoutWriteOnlyPassword",
+			values:      map[string]interface{}{"name": "app_user", "password_wo_version": int64(1)},
+			wantSummary: "Missing Write-Only Password",
+		},
+		{
+			name:   "RotationTrigger",
+			values: map[string]interface{}{"name": "app_user", "password_wo": "P@ssw0rd", "rotation_trigger": "2026-10"},
+		},
+		{
+			name:   "RotateAfter",
+			values: map[string]interface{}{"name": "app_user", "password_wo": "P@ssw0rd", "rotate_after": "720h"},
+		},
+		{
+			name:        "RotateAfterWithoutWriteOnlyPassword",
+			values:      map[string]interface{}{"name": "app_user", "password": "P@ssw0rd", "rotate_after": "720h"},
+			wantSummary: "Missing Write-Only Password",
+		},
+		{
+			name:        "InvalidRotateAfter",
+			values:      map[string]interface{}{"name": "app_user", "password_wo": "P@ssw0rd", "rotate_after": "30 days"},
+			wantSummary: "Invalid Rotation Period",
+		},
+		{
+			name:        "NegativeRotateAfter",
+			values:      map[string]interface{}{"name": "app_user", "password_wo": "P@ssw0rd", "rotate_after": "-1h"},
+			wantSummary: "Invalid Rotation Period",
+		},
+		{
+			name:        "WeakPassword",
+			values:      map[string]interface{}{"name": "app_user", "password": "password"},
+			wantWarning: "Weak Password",
+		},
+		{
+			name:        "WriteOnlyPasswordWithUserName",
+			values:      map[string]interface{}{"name": "app_user", "password_wo": "My_App_User_1", "password_wo_version": int64(1)},
+			wantWarning: "Weak Password",
+		},
+		{
+			name:   "UnknownWriteOnlyPassword",
+			values: map[string]interface{}{"name": "app_user", "password_wo": types.StringUnknown(), "password_wo_version": int64(1)},
+		},
+		{
+			name:        "OldPasswordWithoutPassword",
+			values:      map[string]interface{}{"name": "app_user", "old_password_wo": "Old_P@ssw0rd"},
+			wantSummary: "Missing Password",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.ValidateConfigResponse{}
+			NewUserResource().(*UserResource).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: newUserConfig(t, tt.values)}, resp)
+
+			if tt.wantSummary == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				if tt.wantWarning != "" && (resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != tt.wantWarning) {
+					t.Errorf("Expected a single %q warning, got %v", tt.wantWarning, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != tt.wantSummary {
+				t.Errorf("Expected a single %q error, got %v", tt.wantSummary, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestUserWriteOnlyPasswords(t *testing.T) {
+	config := newUserConfig(t, map[string]interface{}{"name": "app_user", "password_wo": "P@ssw0rd", "old_password_wo": "Old_P@ssw0rd"})
+
+	password, oldPassword, diags := userWriteOnlyPasswords(context.Background(), config)
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	if password != "P@ssw0rd" || oldPassword != "Old_P@ssw0rd" {
+		t.Errorf("Expected the write-only passwords of the configuration, got %q and %q", password, oldPassword)
+	}
+}
+
+func TestPasswordRotationDue(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	state := model.UserResourceModel{
+		PasswordVersion:   types.Int64Value(1),
+		RotationTrigger:   types.StringValue("2026-09"),
+		RotateAfter:       types.StringValue("720h"),
+		PasswordRotatedAt: types.StringValue("2026-10-01T12:00:00Z"),
+	}
+
+	tests := []struct {
+		name   string
+		update func(plan *model.UserResourceModel)
+		state  func(state *model.UserResourceModel)
+		want   bool
+	}{
+		{
+			name:   "Unchanged",
+			update: func(plan *model.UserResourceModel) {},
+			want:   false,
+		},
+		{
+			name:   "VersionChanged",
+			update: func(plan *model.UserResourceModel) { plan.PasswordVersion = types.Int64Value(2) },
+			want:   true,
+		},
+		{
+			name:   "VersionRemoved",
+			update: func(plan *model.UserResourceModel) { plan.PasswordVersion = types.Int64Null() },
+			want:   false,
+		},
+		{
+			name:   "TriggerChanged",
+			update: func(plan *model.UserResourceModel) { plan.RotationTrigger = types.StringValue("2026-10") },
+			want:   true,
+		},
+		{
+			name:   "PasswordChanged",
+			update: func(plan *model.UserResourceModel) { plan.Password = types.StringValue("P@ssw0rd") },
+			want:   true,
+		},
+		{
+			name:   "Expired",
+			update: func(plan *model.UserResourceModel) { plan.RotateAfter = types.StringValue("360h") },
+			want:   true,
+		},
+		{
+			name:   "NeverRotated",
+			update: func(plan *model.UserResourceModel) {},
+			state:  func(state *model.UserResourceModel) { state.PasswordRotatedAt = types.StringNull() },
+			want:   true,
+		},
+		{
+			name:   "NeverRotatedWithoutRotateAfter",
+			update: func(plan *model.UserResourceModel) { plan.RotateAfter = types.StringNull() },
+			state:  func(state *model.UserResourceModel) { state.PasswordRotatedAt = types.StringNull() },
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := state
+			if tt.state != nil {
+				tt.state(&prior)
+			}
+			plan := prior
+			tt.update(&plan)
+
+			if got := passwordRotationDue(plan, prior, now); got != tt.want {
+				t.Errorf("passwordRotationDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,463 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// connectionStringSynonyms maps the ADO.NET and ODBC synonyms understood by go-mssqldb to their key.
+var connectionStringSynonyms = map[string]string{
+	"data source":               "server",
+	"address":                   "server",
+	"network address":           "server",
+	"addr":                      "server",
+	"user":                      "user id",
+	"uid":                       "user id",
+	"pwd":                       "password",
+	"initial catalog":           "database",
+	"application name":          "app name",
+	"column encryption setting": "columnencryption",
+}
+
+// connectionStringKeys lists the keys of the connection string mapped to the Connector.
+var connectionStringKeys = []string{
+	"server", "port", "database",
+	"user id", "password", "fedauth", "applicationclientid", "resource id", "clientcertpath",
+	"encrypt", "trustservercertificate", "certificate", "hostnameincertificate", "tlsmin",
+	"app name", "workstation id", "dial timeout", "failoverpartner", "failoverport", "multisubnetfailover", "applicationintent",
+}
+
+// unsupportedConnectionStringKeys lists the keys understood by go-mssqldb that the provider rejects, with the reason.
+var unsupportedConnectionStringKeys = map[string]string{
+	"connection timeout": "the driver applies it to every read, use dial timeout or the connect_timeout attribute instead",
+	"change password":    "passwords cannot be changed at login",
+	"packet size":        "the provider keeps the driver packet size",
+	"log":                "the driver logging is not exposed by the provider",
+	"keepalive":          "the provider keeps the driver keepalive",
+	"serverspn":          "Kerberos authentication is not supported",
+	"protocol":           "only the TCP protocol is supported",
+	"pipe":               "named pipes are not supported",
+	"disableretry":       "the provider has its own retry policy, use the max_retries attribute instead",
+	"columnencryption":   "Always Encrypted is not supported",
+}
+
+// serverProtocols lists the protocol prefixes of the server key understood by go-mssqldb.
+var serverProtocols = []string{"tcp", "np", "lpc", "admin"}
+
+// fedAuthKeys lists, for each FedAuth mode, the authentication keys of the connection string it uses.
+var fedAuthKeys = map[FedAuth][]string{
+	ActiveDirectoryServicePrincipal: {"user id", "password", "clientcertpath"},
+	ActiveDirectoryApplication:      {"user id", "password", "clientcertpath"},
+	ActiveDirectoryPassword:         {"applicationclientid", "user id", "password"},
+	ActiveDirectoryManagedIdentity:  {"user id", "resource id"},
+	ActiveDirectoryMSI:              {"user id", "resource id"},
+	ActiveDirectoryInteractive:      {"applicationclientid", "user id"},
+	ActiveDirectoryDeviceCode:       {"applicationclientid"},
+	ActiveDirectoryDefault:          {},
+	ActiveDirectoryAzCli:            {},
+}
+
+// ParseConnectionString returns a Connector built from an ADO.NET or ODBC style connection string, as
+// semicolon-separated key=value pairs. Keys are case-insensitive and the ADO.NET synonyms understood by
+// go-mssqldb are accepted. An ODBC connection string starts with "odbc:", as with the driver, and its values
+// may be enclosed in braces, "}}" standing for a closing brace. An ADO.NET value may be enclosed in single or
+// double quotes, a doubled quote standing for the quote itself. A quoted value can hold semicolons and equal signs.
+//
+// Only the keys mapped to a Connector field are accepted. Any other key, a key given twice with different
+// values and settings contradicting each other are reported as errors. The errors never quote the values of
+// the credentials.
+func ParseConnectionString(connectionString string) (*Connector, error) {
+	params, err := splitConnectionString(connectionString)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Connector{}
+
+	if err := c.parseServer(params); err != nil {
+		return nil, err
+	}
+
+	c.Database = params["database"]
+	if c.Database == "" {
+		return nil, errors.New("the database key is required")
+	}
+
+	if err := c.parseAuthentication(params); err != nil {
+		return nil, err
+	}
+
+	if err := c.parseTLS(params); err != nil {
+		return nil, err
+	}
+
+	if err := c.parseHighAvailability(params); err != nil {
+		return nil, err
+	}
+
+	c.ApplicationName = params["app name"]
+	c.WorkstationID = params["workstation id"]
+
+	if value, ok := params["dial timeout"]; ok {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			return nil, fmt.Errorf("invalid dial timeout %q: it must be a number of seconds", value)
+		}
+		c.Timeout = time.Duration(seconds) * time.Second
+	}
+
+	return c, nil
+}
+
+// connectionStringPair is a key=value pair of a connection string, with its key lowercased.
+type connectionStringPair struct {
+	name  string
+	value string
+}
+
+// splitConnectionString returns the keys of connectionString, lowercased and resolved from their synonyms,
+// with their trimmed values. It rejects the keys that are not mapped to the Connector.
+func splitConnectionString(connectionString string) (map[string]string, error) {
+	if strings.HasPrefix(strings.ToLower(connectionString), "sqlserver://") {
+		return nil, errors.New("only the ADO.NET and ODBC key=value formats are supported")
+	}
+
+	odbc := false
+	if strings.HasPrefix(strings.ToLower(connectionString), "odbc:") {
+		connectionString, odbc = connectionString[len("odbc:"):], true
+	}
+
+	pairs, err := connectionStringPairs(connectionString, odbc)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{}
+	names := map[string]string{}
+
+	for _, pair := range pairs {
+		name, value := pair.name, pair.value
+
+		key := name
+		if synonym, ok := connectionStringSynonyms[name]; ok {
+			key = synonym
+		}
+		if reason, ok := unsupportedConnectionStringKeys[key]; ok {
+			return nil, fmt.Errorf("the %q key is not supported: %s", name, reason)
+		}
+		if !slices.Contains(connectionStringKeys, key) {
+			return nil, fmt.Errorf("the %q key is not supported by the driver", name)
+		}
+
+		if previous, ok := names[key]; ok && params[key] != value {
+			if previous == name {
+				return nil, fmt.Errorf("the %q key is set twice with different values", name)
+			}
+			return nil, fmt.Errorf("the %q and %q keys conflict: they set the same setting to different values", previous, name)
+		}
+		names[key] = name
+		params[key] = value
+	}
+
+	return params, nil
+}
+
+// connectionStringPairs splits connectionString into its key=value pairs, in order. Values are quoted with
+// braces in the ODBC format, and with single or double quotes in the ADO.NET format; unquoted values are trimmed.
+// The errors never quote the values, which may be credentials.
+func connectionStringPairs(connectionString string, odbc bool) ([]connectionStringPair, error) {
+	var pairs []connectionStringPair
+
+	rest := connectionString
+	for segment := 1; rest != ""; segment++ {
+		end := strings.IndexAny(rest, "=;")
+		if end < 0 || rest[end] == ';' {
+			// An empty segment is skipped, any other segment must hold an equal sign.
+			part, next := rest, ""
+			if end >= 0 {
+				part, next = rest[:end], rest[end+1:]
+			}
+			if strings.TrimSpace(part) != "" {
+				return nil, fmt.Errorf("segment %d is not a key=value pair", segment)
+			}
+			rest = next
+			continue
+		}
+
+		name := strings.ToLower(strings.TrimSpace(rest[:end]))
+		if name == "" {
+			return nil, fmt.Errorf("segment %d is not a key=value pair", segment)
+		}
+		rest = strings.TrimLeftFunc(rest[end+1:], unicode.IsSpace)
+
+		var value string
+		if closing, quoted := connectionStringQuote(rest, odbc); quoted {
+			var err error
+			value, rest, err = unquoteConnectionStringValue(rest[1:], closing)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of the %q key: %w", name, err)
+			}
+		} else {
+			value, rest, _ = strings.Cut(rest, ";")
+			value = strings.TrimSpace(value)
+		}
+
+		pairs = append(pairs, connectionStringPair{name: name, value: value})
+	}
+
+	return pairs, nil
+}
+
+// connectionStringQuote returns the closing quote of the value starting value, and whether it is quoted.
+func connectionStringQuote(value string, odbc bool) (byte, bool) {
+	switch {
+	case value == "":
+		return 0, false
+	case odbc:
+		return '}', value[0] == '{'
+	case value[0] == '\'' || value[0] == '"':
+		return value[0], true
+	default:
+		return 0, false
+	}
+}
+
+// unquoteConnectionStringValue returns the quoted value at the start of s, which follows the opening quote,
+// and what follows the semicolon ending the value. A doubled closing quote stands for the quote itself.
+func unquoteConnectionStringValue(s string, closing byte) (string, string, error) {
+	var value strings.Builder
+	for i := 0; ; i++ {
+		if i == len(s) {
+			return "", "", fmt.Errorf("missing closing %c", closing)
+		}
+		if s[i] != closing {
+			value.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == closing {
+			value.WriteByte(closing)
+			i++
+			continue
+		}
+
+		// Only spaces may follow the closing quote, up to the semicolon ending the pair.
+		after, rest, _ := strings.Cut(s[i+1:], ";")
+		if strings.TrimSpace(after) != "" {
+			return "", "", fmt.Errorf("unexpected characters after the closing %c", closing)
+		}
+		return value.String(), rest, nil
+	}
+}
+
+// parseServer sets the host, instance name and port from the server and port keys.
+// The server is given as [tcp:]host[\instance][,port].
+func (c *Connector) parseServer(params map[string]string) error {
+	server := params["server"]
+	if server == "" {
+		return errors.New("the server key is required")
+	}
+
+	if protocol, rest, found := strings.Cut(server, ":"); found && slices.Contains(serverProtocols, strings.ToLower(protocol)) {
+		if !strings.EqualFold(protocol, "tcp") {
+			return fmt.Errorf("unsupported protocol %q in the server key: only tcp is supported", protocol)
+		}
+		server = rest
+	}
+
+	serverPort := ""
+	if host, port, found := strings.Cut(server, ","); found {
+		server, serverPort = strings.TrimSpace(host), strings.TrimSpace(port)
+	}
+	c.Host, c.InstanceName, _ = strings.Cut(server, `\`)
+	if c.Host == "" {
+		return errors.New("the server key has no host name")
+	}
+
+	port, hasPort := params["port"]
+	switch {
+	case serverPort != "" && hasPort && serverPort != port:
+		return fmt.Errorf("the port %s of the server key conflicts with the port key %s", serverPort, port)
+	case serverPort != "":
+		port = serverPort
+	case !hasPort:
+		return nil
+	}
+
+	value, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || value == 0 {
+		return fmt.Errorf("invalid port %q: it must be between 1 and 65535", port)
+	}
+	c.Port = int(value)
+	return nil
+}
+
+// parseAuthentication sets the login from the user id and password keys, or from the keys used by the
+// FedAuth mode of the fedauth key.
+func (c *Connector) parseAuthentication(params map[string]string) error {
+	fedAuth, ok := params["fedauth"]
+	if !ok {
+		for _, key := range []string{"applicationclientid", "resource id", "clientcertpath"} {
+			if _, ok := params[key]; ok {
+				return fmt.Errorf("the %q key requires the fedauth key", key)
+			}
+		}
+		if params["user id"] == "" || params["password"] == "" {
+			return errors.New("the user id and password keys are required for SQL authentication, set fedauth to use Entra ID")
+		}
+		c.LocalUserLogin = &LocalUserLogin{
+			Username: params["user id"],
+			Password: params["password"],
+		}
+		return nil
+	}
+
+	var method FedAuth
+	for mode := range fedAuthKeys {
+		if strings.EqualFold(fedAuth, mode.String()) {
+			method = mode
+		}
+	}
+	if method == "" {
+		return fmt.Errorf("unsupported fedauth %q", fedAuth)
+	}
+
+	for _, key := range []string{"user id", "password", "applicationclientid", "resource id", "clientcertpath"} {
+		if _, ok := params[key]; ok && !slices.Contains(fedAuthKeys[method], key) {
+			return fmt.Errorf("the %q key cannot be used with fedauth %s", key, method)
+		}
+	}
+
+	login := &EntraLogin{Method: method}
+
+	switch method {
+	case ActiveDirectoryServicePrincipal, ActiveDirectoryApplication:
+		clientId, tenantId, _ := strings.Cut(params["user id"], "@")
+		if clientId == "" {
+			return fmt.Errorf("the user id key is required with fedauth %s, as client_id@tenant_id", method)
+		}
+		if path, ok := params["clientcertpath"]; ok {
+			c.AzureApplicationLogin = &AzureApplicationLogin{
+				ClientId:                  clientId,
+				TenantId:                  tenantId,
+				ClientCertificatePath:     path,
+				ClientCertificatePassword: params["password"],
+			}
+			return nil
+		}
+		if params["password"] == "" {
+			return fmt.Errorf("the password or clientcertpath key is required with fedauth %s", method)
+		}
+		login.ClientId, login.TenantId, login.ClientSecret = clientId, tenantId, params["password"]
+	case ActiveDirectoryPassword:
+		if params["user id"] == "" || params["password"] == "" || params["applicationclientid"] == "" {
+			return fmt.Errorf("the user id, password and applicationclientid keys are required with fedauth %s", method)
+		}
+		login.ClientId, login.Username, login.Password = params["applicationclientid"], params["user id"], params["password"]
+	case ActiveDirectoryManagedIdentity, ActiveDirectoryMSI:
+		login.ClientId, login.ResourceId = params["user id"], params["resource id"]
+	case ActiveDirectoryInteractive:
+		if params["applicationclientid"] == "" {
+			return fmt.Errorf("the applicationclientid key is required with fedauth %s", method)
+		}
+		login.ClientId, login.Username = params["applicationclientid"], params["user id"]
+	case ActiveDirectoryDeviceCode:
+		login.ClientId = params["applicationclientid"]
+	}
+
+	c.EntraLogin = login
+	return nil
+}
+
+// parseTLS sets the encryption settings when any of the TLS keys is set.
+func (c *Connector) parseTLS(params map[string]string) error {
+	tls := &TLSConfig{
+		CACertificatePath:     params["certificate"],
+		HostNameInCertificate: params["hostnameincertificate"],
+		MinTLSVersion:         params["tlsmin"],
+	}
+	set := false
+	for _, key := range []string{"encrypt", "trustservercertificate", "certificate", "hostnameincertificate", "tlsmin"} {
+		if _, ok := params[key]; ok {
+			set = true
+		}
+	}
+	if !set {
+		return nil
+	}
+
+	if value, ok := params["encrypt"]; ok {
+		// The driver accepts booleans for the mandatory and optional modes.
+		switch strings.ToLower(value) {
+		case "strict":
+			tls.Encrypt = EncryptStrict
+		case "mandatory", "yes", "1", "t", "true":
+			tls.Encrypt = EncryptMandatory
+		case "optional", "no", "0", "f", "false":
+			tls.Encrypt = EncryptOptional
+		case "disable":
+			tls.Encrypt = EncryptDisable
+		default:
+			return fmt.Errorf("invalid encrypt %q: it must be strict, mandatory, optional or disable", value)
+		}
+	}
+
+	if value, ok := params["trustservercertificate"]; ok {
+		trust, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid trustservercertificate %q: it must be true or false", value)
+		}
+		tls.TrustServerCertificate = trust
+	}
+
+	if err := tls.validate(); err != nil {
+		return fmt.Errorf("invalid TLS keys: %w", err)
+	}
+	c.TLS = tls
+	return nil
+}
+
+// parseHighAvailability sets the failover partner, multi-subnet failover and application intent.
+// A read-only intent is rejected, as the resources need a read-write connection.
+func (c *Connector) parseHighAvailability(params map[string]string) error {
+	partner, hasPartner := params["failoverpartner"]
+	if port, ok := params["failoverport"]; ok {
+		if !hasPartner {
+			return errors.New("the failoverport key requires the failoverpartner key")
+		}
+		if value, err := strconv.ParseUint(port, 10, 16); err != nil || value == 0 {
+			return fmt.Errorf("invalid failoverport %q: it must be between 1 and 65535", port)
+		}
+		partner = net.JoinHostPort(partner, port)
+	}
+	c.FailoverPartner = partner
+
+	if value, ok := params["multisubnetfailover"]; ok {
+		multiSubnetFailover, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid multisubnetfailover %q: it must be true or false", value)
+		}
+		c.MultiSubnetFailover = &multiSubnetFailover
+	}
+
+	if value, ok := params["applicationintent"]; ok {
+		switch {
+		case strings.EqualFold(value, "ReadWrite"):
+		case strings.EqualFold(value, "ReadOnly"):
+			return errors.New("the ReadOnly applicationintent is not supported as resources need a read-write connection, use the read_only_intent_for_data_sources attribute instead")
+		default:
+			return fmt.Errorf("invalid applicationintent %q: it must be ReadWrite", value)
+		}
+	}
+
+	return nil
+}
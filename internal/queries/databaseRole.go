@@ -16,20 +16,40 @@ import (
 // It takes a context, a database connection, and a database role model as input.
 // It returns the retrieved database role model and an error if any.
 func (c *Connector) GetDatabaseRole(ctx context.Context, db *sql.DB, databaseRole *model.Role) (*model.Role, error) {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	var err error
 
+	// Without a name, SID or principal id the query would return an arbitrary role.
+	if databaseRole.Name == "" && databaseRole.SID == "" && databaseRole.PrincipalID <= 0 {
+		return nil, errors.New("cannot retrieve database role: a name, SID or principal id is required")
+	}
+
 	// Check if the database connection is nil.
 	if err := c.validateDatabaseConnection(ctx, db); err != nil {
 		return nil, err
 	}
 
 	// SQL query to get a database role.
-	query := `SELECT name, principal_id, type, type_desc, owning_principal_id, is_fixed_role
+	query := `SELECT name, principal_id, CONVERT(varchar(max), sid, 1) AS sid, type, type_desc, owning_principal_id, is_fixed_role
 				FROM [sys].[database_principals]
-				WHERE [name] = @name AND type_desc = 'DATABASE_ROLE'`
+				WHERE type_desc = 'DATABASE_ROLE'`
+
+	// The role is looked up by name, by principal id and SID, by SID or by principal id, in that order of preference.
+	switch {
+	case databaseRole.Name != "":
+		query = query + " AND [name] = @name"
+	case databaseRole.PrincipalID > 0 && databaseRole.SID != "":
+		query = query + " AND [principal_id] = @principal_id AND [sid] = CONVERT(varbinary(85), @sid, 1)"
+	case databaseRole.SID != "":
+		query = query + " AND [sid] = CONVERT(varbinary(85), @sid, 1)"
+	default:
+		query = query + " AND [principal_id] = @principal_id"
+	}
 
 	// Execute the query and get a single row result.
-	row := db.QueryRowContext(ctx, query, sql.Named("name", databaseRole.Name))
+	row := c.queryRowContext(ctx, db, query, sql.Named("name", databaseRole.Name), sql.Named("sid", databaseRole.SID), sql.Named("principal_id", databaseRole.PrincipalID))
 
 	// Check for any error during the query execution.
 	if err = row.Err(); err != nil {
@@ -37,7 +57,8 @@ func (c *Connector) GetDatabaseRole(ctx context.Context, db *sql.DB, databaseRol
 	}
 
 	// Scan the result into the DatabaseRole model.
-	err = row.Scan(&databaseRole.Name, &databaseRole.PrincipalID, &databaseRole.Type, &databaseRole.TypeDescription, &databaseRole.OwningPrincipal, &databaseRole.IsFixedRole)
+	var sid sql.NullString
+	err = row.Scan(&databaseRole.Name, &databaseRole.PrincipalID, &sid, &databaseRole.Type, &databaseRole.TypeDescription, &databaseRole.OwningPrincipal, &databaseRole.IsFixedRole)
 
 	// Check if the database role is not found.
 	if err == sql.ErrNoRows {
@@ -46,6 +67,7 @@ func (c *Connector) GetDatabaseRole(ctx context.Context, db *sql.DB, databaseRol
 		// Check for other scan errors.
 		return nil, fmt.Errorf("scan error - cannot retrieve database role: %w", err)
 	}
+	databaseRole.SID = sid.String
 
 	return databaseRole, nil
 }
@@ -54,6 +76,9 @@ func (c *Connector) GetDatabaseRole(ctx context.Context, db *sql.DB, databaseRol
 // It takes a context, a database connection, and a database role model as input.
 // It returns an error if any.
 func (c *Connector) CreateDatabaseRole(ctx context.Context, db *sql.DB, databaseRole *model.Role) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	var err error
 
 	// Check if the database connection is nil.
@@ -81,8 +106,9 @@ func (c *Connector) CreateDatabaseRole(ctx context.Context, db *sql.DB, database
 	// The full TSQL script.
 	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
 
-	_, err = db.ExecContext(
+	_, err = c.execContext(
 		ctx,
+		db,
 		tsql,
 		sql.Named("database_role_name", roleCopy.Name),
 		sql.Named("user_name", user.Name))
@@ -94,11 +120,53 @@ func (c *Connector) CreateDatabaseRole(ctx context.Context, db *sql.DB, database
 	}
 }
 
+// RenameDatabaseRole renames a database role of the specified database to newName.
+// The role keeps its principal id, members and permissions. Fixed database roles cannot be renamed.
+// It returns an error if any.
+func (c *Connector) RenameDatabaseRole(ctx context.Context, db *sql.DB, databaseRole *model.Role, newName string) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
+	if databaseRole.Name == "" || newName == "" {
+		return errors.New("cannot rename database role. a database role must have a name")
+	}
+
+	// Check if the database connection is nil.
+	if err := c.validateDatabaseConnection(ctx, db); err != nil {
+		return err
+	}
+
+	// Retrieve the role to rename, without mutating the input parameter.
+	existingRole, err := c.GetDatabaseRole(ctx, db, &model.Role{Name: databaseRole.Name})
+	if err != nil {
+		return fmt.Errorf("cannot retrieve the database role to rename. Underlying error : %w", err)
+	}
+	if existingRole.IsFixedRole {
+		return fmt.Errorf("cannot rename database role. %s is a fixed database role", existingRole.Name)
+	}
+
+	// SQL query to rename a database role.
+	query := "'ALTER ROLE ' + QUOTENAME(@database_role_name) + ' WITH NAME = ' + QUOTENAME(@new_name)"
+
+	// The full TSQL script.
+	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
+
+	_, err = c.execContext(ctx, db, tsql, sql.Named("database_role_name", existingRole.Name), sql.Named("new_name", newName))
+	if err != nil {
+		return fmt.Errorf("cannot rename database role. Underlying sql error : %w", err)
+	}
+
+	return nil
+}
+
 // DeleteDatabaseRole deletes a database role from the specified database.
 // It takes a context, a database connection, and a database role model as input.
 // It returns an error if any.
 // Not available on Azure Database.
 func (c *Connector) DeleteDatabaseRole(ctx context.Context, db *sql.DB, databaseRole *model.Role) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	var err error
 
 	// Check if the database connection is nil.
@@ -147,8 +215,9 @@ func (c *Connector) DeleteDatabaseRole(ctx context.Context, db *sql.DB, database
 	EXEC(@SQL);
 	`
 
-	_, err = db.ExecContext(
+	_, err = c.execContext(
 		ctx,
+		db,
 		tsql,
 		sql.Named("RoleName", databaseRole.Name))
 
@@ -163,6 +232,9 @@ func (c *Connector) DeleteDatabaseRole(ctx context.Context, db *sql.DB, database
 // It takes a context, a database connection, a database role model, and a user model as input.
 // It returns an error if any.
 func (c *Connector) AddDatabaseRoleMember(ctx context.Context, db *sql.DB, databaseRole *model.Role, user *model.User) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	var err error
 
 	// Check if the database connection is nil.
@@ -189,7 +261,7 @@ func (c *Connector) AddDatabaseRoleMember(ctx context.Context, db *sql.DB, datab
 	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
 
 	// Execute the query.
-	_, err = db.ExecContext(ctx, tsql, sql.Named("database_role_name", databaseRole.Name), sql.Named("user_name", user.Name))
+	_, err = c.execContext(ctx, db, tsql, sql.Named("database_role_name", databaseRole.Name), sql.Named("user_name", user.Name))
 
 	if err != nil {
 		return fmt.Errorf("cannot add user to database role. Underlying sql error : %w", err)
@@ -202,6 +274,9 @@ func (c *Connector) AddDatabaseRoleMember(ctx context.Context, db *sql.DB, datab
 // It takes a context, a database connection, a database role model, and a list of user models as input.
 // It returns an error if any.
 func (c *Connector) AddDatabaseRoleMembers(ctx context.Context, db *sql.DB, databaseRole *model.Role, user []*model.User) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	for _, u := range user {
 		err := c.AddDatabaseRoleMember(ctx, db, databaseRole, u)
 		if err != nil {
@@ -215,6 +290,9 @@ func (c *Connector) AddDatabaseRoleMembers(ctx context.Context, db *sql.DB, data
 // It takes a context, a database connection, a database role model, and a user model as input.
 // It returns an error if any.
 func (c *Connector) RemoveDatabaseRoleMember(ctx context.Context, db *sql.DB, databaseRole *model.Role, user *model.User) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	var err error
 
 	// Check if the database connection is nil.
@@ -241,7 +319,7 @@ func (c *Connector) RemoveDatabaseRoleMember(ctx context.Context, db *sql.DB, da
 	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
 
 	// Execute the query.
-	_, err = db.ExecContext(ctx, tsql, sql.Named("database_role_name", databaseRole.Name), sql.Named("user_name", user.Name))
+	_, err = c.execContext(ctx, db, tsql, sql.Named("database_role_name", databaseRole.Name), sql.Named("user_name", user.Name))
 
 	if err != nil {
 		return fmt.Errorf("cannot remove user from database role. Underlying sql error : %w", err)
@@ -254,6 +332,9 @@ func (c *Connector) RemoveDatabaseRoleMember(ctx context.Context, db *sql.DB, da
 // It takes a context, a database connection, a database role model, and a list of user models as input.
 // It returns an error if any.
 func (c *Connector) RemoveDatabaseRoleMembers(ctx context.Context, db *sql.DB, databaseRole *model.Role, user []*model.User) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	for _, u := range user {
 		err := c.RemoveDatabaseRoleMember(ctx, db, databaseRole, u)
 		if err != nil {
@@ -267,6 +348,9 @@ func (c *Connector) RemoveDatabaseRoleMembers(ctx context.Context, db *sql.DB, d
 // It takes a context, a database connection, and a database role model as input.
 // It returns a list of database role members and an error if any.
 func (c *Connector) GetDatabaseRoleMembers(ctx context.Context, db *sql.DB, databaseRole *model.Role) ([]*model.User, error) {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	var err error
 	var users []*model.User
 
@@ -301,10 +385,11 @@ func (c *Connector) GetDatabaseRoleMembers(ctx context.Context, db *sql.DB, data
 					WHERE role_principal_id IN (
 						SELECT principal_id
 						FROM [sys].[database_principals]
-						WHERE [name] = @name AND type_desc = 'DATABASE_ROLE'))`
+						WHERE [name] = @name AND type_desc = 'DATABASE_ROLE'))
+				ORDER BY [name]`
 
 	// Execute the query.
-	rows, err := db.QueryContext(ctx, query, sql.Named("name", databaseRole.Name))
+	rows, err := c.queryContext(ctx, db, query, sql.Named("name", databaseRole.Name))
 	if err != nil {
 		return nil, fmt.Errorf("query execution error - cannot retrieve database role members: %w", err)
 	}
@@ -42,6 +42,24 @@ func TestConnector_GetDatabaseRole(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:             "db_owner-by-principal-id-on-LocalSQL",
+			connector:        testConnectors.localSQL,
+			databaseOverride: "master",
+			databaseRole: &model.Role{
+				PrincipalID: 16384,
+			},
+			wantErr: false,
+		},
+		{
+			name:             "user-by-principal-id-on-LocalSQL",
+			connector:        testConnectors.localSQL,
+			databaseOverride: "master",
+			databaseRole: &model.Role{
+				PrincipalID: 1,
+			},
+			wantErr: true,
+		},
 	}
 
 	// Iterate through the test cases.
@@ -69,6 +87,10 @@ func TestConnector_GetDatabaseRole(t *testing.T) {
 				return
 			}
 
+			if tt.wantErr {
+				return
+			}
+
 			// Check the type of PrincipalID in the returned databaseRole.
 			principalIDType := reflect.TypeOf(gotDatabaseRole.PrincipalID)
 			if principalIDType != reflect.TypeOf(int64(0)) {
@@ -522,3 +544,69 @@ func TestConnector_GetDatabaseRoleMembers(t *testing.T) {
 		})
 	}
 }
+
+// TestConnector_RenameDatabaseRole tests that a renamed role keeps its principal id and members, and that fixed roles are not renamed.
+func TestConnector_RenameDatabaseRole(t *testing.T) {
+	connector := testConnectors.localSQL
+	ctx := context.Background()
+	db, err := connector.Connect()
+	if err != nil {
+		t.Fatalf("Unable to connect: %v", err)
+	}
+
+	role := &model.Role{Name: generateRandomString(10)}
+	if err := connector.CreateDatabaseRole(ctx, db, role); err != nil {
+		t.Fatalf("Connector.CreateDatabaseRole() error = %v", err)
+	}
+
+	created, err := connector.GetDatabaseRole(ctx, db, &model.Role{Name: role.Name})
+	if err != nil {
+		t.Fatalf("Connector.GetDatabaseRole() error = %v", err)
+	}
+
+	member := &model.User{
+		Name:     generateRandomString(10),
+		Password: fmt.Sprintf("%s1aA!", generateRandomString(16)),
+	}
+	if err := connector.CreateUser(ctx, db, member); err != nil {
+		t.Fatalf("Connector.CreateUser() error = %v", err)
+	}
+	defer func() {
+		if err := connector.DeleteUser(ctx, db, &model.User{Name: member.Name}); err != nil {
+			t.Errorf("error during cleanup = %v", err)
+		}
+	}()
+	if err := connector.AddDatabaseRoleMember(ctx, db, &model.Role{Name: role.Name}, member); err != nil {
+		t.Fatalf("Connector.AddDatabaseRoleMember() error = %v", err)
+	}
+
+	newName := generateRandomString(10)
+	if err := connector.RenameDatabaseRole(ctx, db, &model.Role{Name: role.Name}, newName); err != nil {
+		t.Fatalf("Connector.RenameDatabaseRole() error = %v", err)
+	}
+	defer func() {
+		if err := connector.DeleteDatabaseRole(ctx, db, &model.Role{Name: newName}); err != nil {
+			t.Errorf("error during cleanup = %v", err)
+		}
+	}()
+
+	got, err := connector.GetDatabaseRole(ctx, db, &model.Role{PrincipalID: created.PrincipalID, SID: created.SID})
+	if err != nil {
+		t.Fatalf("Connector.GetDatabaseRole() error = %v", err)
+	}
+	if got.Name != newName {
+		t.Errorf("Connector.GetDatabaseRole() Name = %v, want %v", got.Name, newName)
+	}
+
+	members, err := connector.GetDatabaseRoleMembers(ctx, db, &model.Role{Name: newName})
+	if err != nil {
+		t.Fatalf("Connector.GetDatabaseRoleMembers() error = %v", err)
+	}
+	if len(members) != 1 || members[0].Name != member.Name {
+		t.Errorf("Connector.GetDatabaseRoleMembers() = %v, want %v", members, member.Name)
+	}
+
+	if err := connector.RenameDatabaseRole(ctx, db, &model.Role{Name: "db_owner"}, newName); err == nil || !contains(err.Error(), "is a fixed database role") {
+		t.Errorf("Connector.RenameDatabaseRole() error = %v, want a fixed database role error", err)
+	}
+}
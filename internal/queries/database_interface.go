@@ -32,8 +32,9 @@ type DatabaseInterface interface {
 	TransactionExecutor
 }
 
-// Ensure *sql.DB implements our interface
+// Ensure *sql.DB and the *sql.Conn reserved for an impersonation implement our interface
 var _ DatabaseInterface = (*sql.DB)(nil)
+var _ DatabaseInterface = (*sql.Conn)(nil)
 
 // MockDatabaseExecutor is a mock implementation for unit testing
 type MockDatabaseExecutor struct {
@@ -0,0 +1,299 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	mssql "github.com/microsoft/go-mssqldb"
+)
+
+// hasClientCertificate reports whether the login is configured with a client certificate.
+func (l *AzureApplicationLogin) hasClientCertificate() bool {
+	return l.ClientCertificatePath != "" || l.ClientCertificate != ""
+}
+
+// validate checks that the login uses exactly one kind of credential.
+func (l *AzureApplicationLogin) validate() error {
+	if l.ClientCertificatePath != "" && l.ClientCertificate != "" {
+		return errors.New("client certificate path and inline client certificate are mutually exclusive")
+	}
+	if (l.ClientSecret != "" || l.ClientSecretFile != "" || len(l.ClientSecretCommand) > 0) && l.hasClientCertificate() {
+		return errors.New("client secret and client certificate are mutually exclusive")
+	}
+	if l.hasClientCertificate() && l.TenantId == "" {
+		return errors.New("tenant id is required for client certificate authentication")
+	}
+	return nil
+}
+
+// clientCertificate loads and parses the PFX or PEM client certificate of the login. It also returns the
+// fingerprint of the certificate contents, so that a certificate rotated in place is told apart from the previous one.
+func (l *AzureApplicationLogin) clientCertificate() ([]*x509.Certificate, crypto.PrivateKey, string, error) {
+	var data []byte
+	var err error
+
+	if l.ClientCertificatePath != "" {
+		data, err = os.ReadFile(l.ClientCertificatePath)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("cannot read client certificate: %w", err)
+		}
+	} else {
+		data, err = base64.StdEncoding.DecodeString(l.ClientCertificate)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("cannot decode client certificate: %w", err)
+		}
+	}
+
+	var password []byte
+	if l.ClientCertificatePassword != "" {
+		password = []byte(l.ClientCertificatePassword)
+	}
+
+	certs, key, err := azidentity.ParseCertificates(data, password)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("cannot parse client certificate: %w", err)
+	}
+	return certs, key, secretFingerprint(string(data), l.ClientCertificatePassword), nil
+}
+
+// clientCertificateCredential returns a credential for the service principal authenticating with its certificate,
+// along with the fingerprint of the certificate.
+func (l *AzureApplicationLogin) clientCertificateCredential() (azcore.TokenCredential, string, error) {
+	certs, key, fingerprint, err := l.clientCertificate()
+	if err != nil {
+		return nil, "", err
+	}
+	credential, err := azidentity.NewClientCertificateCredential(l.TenantId, l.ClientId, certs, key, nil)
+	return credential, fingerprint, err
+}
+
+// identity returns the Entra ID identity of the service principal, authenticating with its client
+// certificate, or with clientSecret when it has none. The certificate is loaded once, here.
+func (l *AzureApplicationLogin) identity(clientSecret string) (entraIdentity, error) {
+	identity := entraIdentity{name: "service principal " + l.ClientId, tenant: l.TenantId, workflow: mssql.FedAuthADALWorkflowPassword}
+
+	if l.hasClientCertificate() {
+		credential, fingerprint, err := l.clientCertificateCredential()
+		if err != nil {
+			return entraIdentity{}, err
+		}
+		identity.fingerprint = fingerprint
+		identity.credential = func(_, _ string) (azcore.TokenCredential, error) { return credential, nil }
+		return identity, nil
+	}
+
+	// Without a tenant, the service principal authenticates in the tenant of the server.
+	identity.fingerprint = secretFingerprint(clientSecret)
+	identity.credential = func(_, tenant string) (azcore.TokenCredential, error) {
+		if l.TenantId != "" {
+			tenant = l.TenantId
+		}
+		return azidentity.NewClientSecretCredential(tenant, l.ClientId, clientSecret, nil)
+	}
+	return identity, nil
+}
+
+// identity returns the Entra ID identity of the managed identity, user-assigned when selected by
+// UserId or ResourceId, system-assigned otherwise.
+func (l *ManagedIdentityLogin) identity() entraIdentity {
+	var id azidentity.ManagedIDKind
+	name := "system-assigned managed identity"
+	if l.UserIdentity {
+		switch {
+		case l.ResourceId != "":
+			id, name = azidentity.ResourceID(l.ResourceId), "managed identity "+l.ResourceId
+		case l.UserId != "":
+			id, name = azidentity.ClientID(l.UserId), "managed identity "+l.UserId
+		}
+	}
+
+	return entraIdentity{
+		name:     name,
+		workflow: mssql.FedAuthADALWorkflowMSI,
+		credential: func(_, _ string) (azcore.TokenCredential, error) {
+			return azidentity.NewManagedIdentityCredential(&azidentity.ManagedIdentityCredentialOptions{ID: id})
+		},
+	}
+}
+
+// validate checks that the federated login has an application, a tenant and exactly one OIDC token source.
+func (l *FederatedLogin) validate() error {
+	if l.ClientId == "" {
+		return errors.New("client id is required for federated authentication")
+	}
+	if l.TenantId == "" {
+		return errors.New("tenant id is required for federated authentication")
+	}
+	if l.OIDCToken != "" && l.OIDCTokenFilePath != "" {
+		return errors.New("OIDC token and OIDC token file path are mutually exclusive")
+	}
+	if l.OIDCToken == "" && l.OIDCTokenFilePath == "" {
+		return errors.New("an OIDC token or an OIDC token file path is required for federated authentication")
+	}
+	return nil
+}
+
+// assertion returns the OIDC token used as client assertion. The token file is read on every call
+// so that tokens rotated by the CI platform during a long apply are picked up.
+func (l *FederatedLogin) assertion(_ context.Context) (string, error) {
+	if l.OIDCToken != "" {
+		return l.OIDCToken, nil
+	}
+
+	data, err := os.ReadFile(l.OIDCTokenFilePath)
+	if err != nil {
+		return "", fmt.Errorf("cannot read OIDC token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("OIDC token file %s is empty", l.OIDCTokenFilePath)
+	}
+	return token, nil
+}
+
+// identity returns the Entra ID identity exchanging the OIDC token for access tokens. The credential
+// holds the inline token, which is part of the fingerprint; the token file is read again on every exchange.
+func (l *FederatedLogin) identity() (entraIdentity, error) {
+	if err := l.validate(); err != nil {
+		return entraIdentity{}, err
+	}
+
+	return entraIdentity{
+		name:        "federated identity " + l.ClientId,
+		tenant:      l.TenantId,
+		workflow:    mssql.FedAuthADALWorkflowPassword,
+		fingerprint: secretFingerprint(l.OIDCToken, l.OIDCTokenFilePath),
+		credential: func(_, _ string) (azcore.TokenCredential, error) {
+			return azidentity.NewClientAssertionCredential(l.TenantId, l.ClientId, l.assertion, nil)
+		},
+	}, nil
+}
+
+// identity returns the Entra ID identity of the FedAuth mode selected in the login. As with the
+// go-mssqldb azuread package, the tenant of the server is used when the login sets none.
+func (l *EntraLogin) identity() (entraIdentity, error) {
+	tenantOr := func(tenant string) string {
+		if l.TenantId != "" {
+			return l.TenantId
+		}
+		return tenant
+	}
+
+	identity := entraIdentity{name: l.Method.String(), tenant: l.TenantId, workflow: mssql.FedAuthADALWorkflowPassword}
+
+	switch l.Method {
+	case ActiveDirectoryServicePrincipal, ActiveDirectoryApplication:
+		if l.ClientId == "" {
+			return entraIdentity{}, fmt.Errorf("client id is required for %s", l.Method)
+		}
+		identity.name += " " + l.ClientId
+		identity.fingerprint = secretFingerprint(l.ClientSecret)
+		identity.credential = func(_, tenant string) (azcore.TokenCredential, error) {
+			return azidentity.NewClientSecretCredential(tenantOr(tenant), l.ClientId, l.ClientSecret, nil)
+		}
+	case ActiveDirectoryPassword:
+		if l.ClientId == "" {
+			return entraIdentity{}, fmt.Errorf("client id is required for %s", l.Method)
+		}
+		identity.name += " " + l.Username
+		identity.fingerprint = secretFingerprint(l.ClientId, l.Password)
+		identity.credential = func(_, tenant string) (azcore.TokenCredential, error) {
+			//nolint:staticcheck // The username/password flow is what ActiveDirectoryPassword selects.
+			return azidentity.NewUsernamePasswordCredential(tenantOr(tenant), l.ClientId, l.Username, l.Password, nil)
+		}
+	case ActiveDirectoryManagedIdentity, ActiveDirectoryMSI:
+		var id azidentity.ManagedIDKind
+		switch {
+		case l.ResourceId != "":
+			id, identity.name = azidentity.ResourceID(l.ResourceId), identity.name+" "+l.ResourceId
+		case l.ClientId != "":
+			id, identity.name = azidentity.ClientID(l.ClientId), identity.name+" "+l.ClientId
+		}
+		identity.workflow = mssql.FedAuthADALWorkflowMSI
+		identity.credential = func(_, _ string) (azcore.TokenCredential, error) {
+			return azidentity.NewManagedIdentityCredential(&azidentity.ManagedIdentityCredentialOptions{ID: id})
+		}
+	case ActiveDirectoryInteractive:
+		if l.ClientId == "" {
+			return entraIdentity{}, fmt.Errorf("client id is required for %s", l.Method)
+		}
+		identity.name += " " + l.Username
+		identity.credential = func(authorityHost, _ string) (azcore.TokenCredential, error) {
+			return azidentity.NewInteractiveBrowserCredential(&azidentity.InteractiveBrowserCredentialOptions{
+				ClientOptions: azcore.ClientOptions{Cloud: cloud.Configuration{ActiveDirectoryAuthorityHost: authorityHost}},
+				ClientID:      l.ClientId,
+				LoginHint:     l.Username,
+			})
+		}
+	case ActiveDirectoryDeviceCode:
+		identity.credential = func(_, _ string) (azcore.TokenCredential, error) {
+			return azidentity.NewDeviceCodeCredential(&azidentity.DeviceCodeCredentialOptions{ClientID: l.ClientId})
+		}
+	case ActiveDirectoryAzCli:
+		identity.credential = func(_, _ string) (azcore.TokenCredential, error) {
+			return azidentity.NewAzureCLICredential(&azidentity.AzureCLICredentialOptions{TenantID: l.TenantId})
+		}
+	case ActiveDirectoryDefault:
+		identity = defaultIdentity()
+	default:
+		return entraIdentity{}, fmt.Errorf("unsupported Entra authentication method %q", l.Method)
+	}
+
+	return identity, nil
+}
+
+// defaultIdentity returns the Entra ID identity of the default Azure credential chain, used when
+// the connector has no login.
+func defaultIdentity() entraIdentity {
+	return entraIdentity{
+		name:     ActiveDirectoryDefault.String(),
+		workflow: mssql.FedAuthADALWorkflowPassword,
+		credential: func(_, _ string) (azcore.TokenCredential, error) {
+			return azidentity.NewDefaultAzureCredential(nil)
+		},
+	}
+}
+
+// validate checks that exactly one access token source is set.
+func (l *AccessTokenLogin) validate() error {
+	if l.Token != "" && l.TokenFilePath != "" {
+		return errors.New("access token and access token file are mutually exclusive")
+	}
+	if l.Token == "" && l.TokenFilePath == "" {
+		return errors.New("an access token or an access token file is required")
+	}
+	return nil
+}
+
+// token returns the access token. The token file is read on every call so that a token
+// rotated by an external broker is used by the next connection.
+func (l *AccessTokenLogin) token() (string, error) {
+	if l.Token != "" {
+		return l.Token, nil
+	}
+
+	data, err := os.ReadFile(l.TokenFilePath)
+	if err != nil {
+		return "", fmt.Errorf("cannot read access token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("access token file %s is empty", l.TokenFilePath)
+	}
+	return token, nil
+}
@@ -0,0 +1,336 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// ENTRA ID AUTHENTICATION UNIT TESTS
+// ============================================================================
+
+// TestAzureApplicationLogin_Validate_Unit tests the credential exclusivity rules of the service principal login
+func TestAzureApplicationLogin_Validate_Unit(t *testing.T) {
+	tests := []struct {
+		name    string
+		login   *AzureApplicationLogin
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "client_secret",
+			login:   &AzureApplicationLogin{ClientId: "client", ClientSecret: "secret", TenantId: "tenant"},
+			wantErr: false,
+		},
+		{
+			name:    "client_certificate_path",
+			login:   &AzureApplicationLogin{ClientId: "client", ClientCertificatePath: "/path/cert.pfx", TenantId: "tenant"},
+			wantErr: false,
+		},
+		{
+			name:    "inline_client_certificate",
+			login:   &AzureApplicationLogin{ClientId: "client", ClientCertificate: "MIIC", TenantId: "tenant"},
+			wantErr: false,
+		},
+		{
+			name:    "secret_and_certificate",
+			login:   &AzureApplicationLogin{ClientId: "client", ClientSecret: "secret", ClientCertificatePath: "/path/cert.pfx", TenantId: "tenant"},
+			wantErr: true,
+			errMsg:  "client secret and client certificate are mutually exclusive",
+		},
+		{
+			name:    "certificate_path_and_inline_certificate",
+			login:   &AzureApplicationLogin{ClientId: "client", ClientCertificatePath: "/path/cert.pfx", ClientCertificate: "MIIC", TenantId: "tenant"},
+			wantErr: true,
+			errMsg:  "mutually exclusive",
+		},
+		{
+			name:    "certificate_without_tenant",
+			login:   &AzureApplicationLogin{ClientId: "client", ClientCertificatePath: "/path/cert.pfx"},
+			wantErr: true,
+			errMsg:  "tenant id is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.login.validate()
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("validate() expected error but got none")
+					return
+				}
+				if !contains(err.Error(), tt.errMsg) {
+					t.Errorf("validate() error = %v, expected to contain %v", err, tt.errMsg)
+				}
+			} else if err != nil {
+				t.Errorf("validate() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+// TestAzureApplicationLogin_ClientCertificate_Unit tests loading PEM certificates from a file and inline
+func TestAzureApplicationLogin_ClientCertificate_Unit(t *testing.T) {
+	certPEM := generateTestCertificatePEM(t)
+
+	certPath := filepath.Join(t.TempDir(), "cert.pem")
+	if err := os.WriteFile(certPath, certPEM, 0o600); err != nil {
+		t.Fatalf("cannot write test certificate: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		login   *AzureApplicationLogin
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "from_file",
+			login:   &AzureApplicationLogin{ClientCertificatePath: certPath},
+			wantErr: false,
+		},
+		{
+			name:    "inline_base64",
+			login:   &AzureApplicationLogin{ClientCertificate: base64.StdEncoding.EncodeToString(certPEM)},
+			wantErr: false,
+		},
+		{
+			name:    "missing_file",
+			login:   &AzureApplicationLogin{ClientCertificatePath: filepath.Join(t.TempDir(), "missing.pem")},
+			wantErr: true,
+			errMsg:  "cannot read client certificate",
+		},
+		{
+			name:    "invalid_base64",
+			login:   &AzureApplicationLogin{ClientCertificate: "not base64!"},
+			wantErr: true,
+			errMsg:  "cannot decode client certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certs, key, _, err := tt.login.clientCertificate()
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("clientCertificate() expected error but got none")
+					return
+				}
+				if !contains(err.Error(), tt.errMsg) {
+					t.Errorf("clientCertificate() error = %v, expected to contain %v", err, tt.errMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("clientCertificate() unexpected error = %v", err)
+			}
+			if len(certs) != 1 || key == nil {
+				t.Errorf("clientCertificate() returned %d certificates and key %v, want 1 certificate and a key", len(certs), key)
+			}
+		})
+	}
+}
+
+// TestAzureApplicationLogin_CertificateFingerprint_Unit tests that a certificate rotated in place changes the fingerprint
+func TestAzureApplicationLogin_CertificateFingerprint_Unit(t *testing.T) {
+	certPath := filepath.Join(t.TempDir(), "cert.pem")
+	login := &AzureApplicationLogin{ClientId: "client", TenantId: "tenant", ClientCertificatePath: certPath}
+
+	var fingerprints []string
+	for range 2 {
+		if err := os.WriteFile(certPath, generateTestCertificatePEM(t), 0o600); err != nil {
+			t.Fatalf("cannot write test certificate: %v", err)
+		}
+		identity, err := login.identity("")
+		if err != nil {
+			t.Fatalf("identity() unexpected error = %v", err)
+		}
+		fingerprints = append(fingerprints, identity.fingerprint)
+	}
+
+	if fingerprints[0] == fingerprints[1] {
+		t.Error("identity() fingerprints are equal for different certificates at the same path")
+	}
+}
+
+// generateTestCertificatePEM returns a self-signed certificate and its private key in PEM format
+func generateTestCertificatePEM(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("cannot generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-mssqlpermissions"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("cannot create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("cannot marshal key: %v", err)
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return append(data, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})...)
+}
+
+// TestFederatedLogin_Validate_Unit tests the OIDC token source rules of the federated login
+func TestFederatedLogin_Validate_Unit(t *testing.T) {
+	tests := []struct {
+		name    string
+		login   *FederatedLogin
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "inline_token",
+			login:   &FederatedLogin{ClientId: "client", TenantId: "tenant", OIDCToken: "token"},
+			wantErr: false,
+		},
+		{
+			name:    "token_file",
+			login:   &FederatedLogin{ClientId: "client", TenantId: "tenant", OIDCTokenFilePath: "/var/run/secrets/token"},
+			wantErr: false,
+		},
+		{
+			name:    "missing_client_id",
+			login:   &FederatedLogin{TenantId: "tenant", OIDCToken: "token"},
+			wantErr: true,
+			errMsg:  "client id is required",
+		},
+		{
+			name:    "missing_tenant_id",
+			login:   &FederatedLogin{ClientId: "client", OIDCToken: "token"},
+			wantErr: true,
+			errMsg:  "tenant id is required",
+		},
+		{
+			name:    "no_token_source",
+			login:   &FederatedLogin{ClientId: "client", TenantId: "tenant"},
+			wantErr: true,
+			errMsg:  "OIDC token file path is required",
+		},
+		{
+			name:    "both_token_sources",
+			login:   &FederatedLogin{ClientId: "client", TenantId: "tenant", OIDCToken: "token", OIDCTokenFilePath: "/var/run/secrets/token"},
+			wantErr: true,
+			errMsg:  "mutually exclusive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.login.validate()
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("validate() expected error but got none")
+					return
+				}
+				if !contains(err.Error(), tt.errMsg) {
+					t.Errorf("validate() error = %v, expected to contain %v", err, tt.errMsg)
+				}
+			} else if err != nil {
+				t.Errorf("validate() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+// TestFederatedLogin_Assertion_Unit tests that the OIDC token file is read again on every assertion
+func TestFederatedLogin_Assertion_Unit(t *testing.T) {
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	login := &FederatedLogin{ClientId: "client", TenantId: "tenant", OIDCTokenFilePath: tokenPath}
+
+	if _, err := login.assertion(context.Background()); err == nil || !contains(err.Error(), "cannot read OIDC token file") {
+		t.Errorf("assertion() error = %v, expected to contain %v", err, "cannot read OIDC token file")
+	}
+
+	for _, token := range []string{"first-token", "rotated-token"} {
+		if err := os.WriteFile(tokenPath, []byte(token+"\n"), 0o600); err != nil {
+			t.Fatalf("cannot write token file: %v", err)
+		}
+
+		got, err := login.assertion(context.Background())
+		if err != nil {
+			t.Fatalf("assertion() unexpected error = %v", err)
+		}
+		if got != token {
+			t.Errorf("assertion() = %v, want %v", got, token)
+		}
+	}
+}
+
+// TestAccessTokenLogin_Token_Unit tests the access token sources and the re-reading of the token file
+func TestAccessTokenLogin_Token_Unit(t *testing.T) {
+	tokenPath := filepath.Join(t.TempDir(), "access-token")
+
+	t.Run("validate", func(t *testing.T) {
+		if err := (&AccessTokenLogin{}).validate(); err == nil || !contains(err.Error(), "is required") {
+			t.Errorf("validate() error = %v, expected to contain %v", err, "is required")
+		}
+		if err := (&AccessTokenLogin{Token: "token", TokenFilePath: tokenPath}).validate(); err == nil || !contains(err.Error(), "mutually exclusive") {
+			t.Errorf("validate() error = %v, expected to contain %v", err, "mutually exclusive")
+		}
+	})
+
+	t.Run("inline_token", func(t *testing.T) {
+		got, err := (&AccessTokenLogin{Token: "inline-token"}).token()
+		if err != nil || got != "inline-token" {
+			t.Errorf("token() = %v, %v, want %v", got, err, "inline-token")
+		}
+	})
+
+	t.Run("token_file_rotation", func(t *testing.T) {
+		login := &AccessTokenLogin{TokenFilePath: tokenPath}
+
+		for _, token := range []string{"first-token", "rotated-token"} {
+			if err := os.WriteFile(tokenPath, []byte(token), 0o600); err != nil {
+				t.Fatalf("cannot write token file: %v", err)
+			}
+
+			got, err := login.token()
+			if err != nil {
+				t.Fatalf("token() unexpected error = %v", err)
+			}
+			if got != token {
+				t.Errorf("token() = %v, want %v", got, token)
+			}
+		}
+	})
+
+	t.Run("empty_token_file", func(t *testing.T) {
+		if err := os.WriteFile(tokenPath, []byte("\n"), 0o600); err != nil {
+			t.Fatalf("cannot write token file: %v", err)
+		}
+		if _, err := (&AccessTokenLogin{TokenFilePath: tokenPath}).token(); err == nil || !contains(err.Error(), "is empty") {
+			t.Errorf("token() error = %v, expected to contain %v", err, "is empty")
+		}
+	})
+}
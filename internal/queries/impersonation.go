@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// impersonate runs operation as the ExecuteAsUser of the connector, or on db directly when it is empty.
+//
+// EXECUTE AS applies to the session, so a connection of db is reserved for the operation. EXECUTE AS USER
+// and REVERT are sent as plain batches: a parameterized statement runs through sp_executesql, which would
+// revert the impersonation as soon as it returns. A connection that cannot be reverted is discarded rather
+// than returned to the pool still impersonating the user.
+func (c *Connector) impersonate(ctx context.Context, db *sql.DB, operation func(DatabaseInterface) error) error {
+	if c.ExecuteAsUser == "" {
+		return operation(db)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "EXECUTE AS USER = N'"+strings.ReplaceAll(c.ExecuteAsUser, "'", "''")+"'"); err != nil {
+		return fmt.Errorf("cannot impersonate user %q: %w", c.ExecuteAsUser, err)
+	}
+
+	err = operation(conn)
+	if err != nil {
+		err = fmt.Errorf("statement failed while executing as user %q: %w", c.ExecuteAsUser, err)
+	}
+
+	if _, revertErr := conn.ExecContext(ctx, "REVERT"); revertErr != nil {
+		_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		if err == nil {
+			err = fmt.Errorf("cannot revert the impersonation of user %q: %w", c.ExecuteAsUser, revertErr)
+		}
+	}
+
+	return err
+}
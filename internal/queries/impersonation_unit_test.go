@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingDriver is a database/sql driver recording the statements executed on its connections.
+// Statements starting with failOn fail.
+type recordingDriver struct {
+	mu         sync.Mutex
+	statements []string
+	closed     int
+	failOn     string
+}
+
+func (d *recordingDriver) Connect(context.Context) (driver.Conn, error) {
+	return &recordingConn{d}, nil
+}
+func (d *recordingDriver) Driver() driver.Driver { return nil }
+
+type recordingConn struct{ d *recordingDriver }
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *recordingConn) Begin() (driver.Tx, error)           { return c, nil }
+func (c *recordingConn) Commit() error                       { return nil }
+func (c *recordingConn) Rollback() error                     { return nil }
+func (c *recordingConn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+func (c *recordingConn) Close() error {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.closed++
+	return nil
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.statements = append(c.d.statements, query)
+	if c.d.failOn != "" && strings.HasPrefix(query, c.d.failOn) {
+		return nil, errors.New("mssql: " + query + " failed")
+	}
+	return driver.RowsAffected(1), nil
+}
+
+// TestConnector_Impersonate_Unit tests the statements sent around the mutating statements
+func TestConnector_Impersonate_Unit(t *testing.T) {
+	tests := []struct {
+		name           string
+		executeAsUser  string
+		failOn         string
+		wantStatements []string
+		wantErr        string
+		wantDiscarded  bool
+	}{
+		{
+			name:           "no_impersonation",
+			wantStatements: []string{"CREATE ROLE [app]"},
+		},
+		{
+			name:           "impersonation",
+			executeAsUser:  "security_admin",
+			wantStatements: []string{"EXECUTE AS USER = N'security_admin'", "CREATE ROLE [app]", "REVERT"},
+		},
+		{
+			name:           "quoted_user_name",
+			executeAsUser:  "o'brien",
+			wantStatements: []string{"EXECUTE AS USER = N'o''brien'", "CREATE ROLE [app]", "REVERT"},
+		},
+		{
+			name:           "impersonation_failed",
+			executeAsUser:  "security_admin",
+			failOn:         "EXECUTE AS",
+			wantStatements: []string{"EXECUTE AS USER = N'security_admin'"},
+			wantErr:        `cannot impersonate user "security_admin"`,
+		},
+		{
+			name:           "statement_failed",
+			executeAsUser:  "security_admin",
+			failOn:         "CREATE ROLE",
+			wantStatements: []string{"EXECUTE AS USER = N'security_admin'", "CREATE ROLE [app]", "REVERT"},
+			wantErr:        `statement failed while executing as user "security_admin"`,
+		},
+		{
+			name:           "revert_failed",
+			executeAsUser:  "security_admin",
+			failOn:         "REVERT",
+			wantStatements: []string{"EXECUTE AS USER = N'security_admin'", "CREATE ROLE [app]", "REVERT"},
+			wantErr:        `cannot revert the impersonation of user "security_admin"`,
+			wantDiscarded:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &recordingDriver{failOn: tt.failOn}
+			db := sql.OpenDB(recorder)
+			defer db.Close()
+
+			connector := &Connector{ExecuteAsUser: tt.executeAsUser, MaxRetries: -1}
+			_, err := connector.execContext(context.Background(), db, "CREATE ROLE [app]")
+
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("execContext() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("execContext() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(recorder.statements, tt.wantStatements) {
+				t.Errorf("execContext() statements = %q, want %q", recorder.statements, tt.wantStatements)
+			}
+			if discarded := recorder.closed > 0; discarded != tt.wantDiscarded {
+				t.Errorf("connection discarded = %v, want %v", discarded, tt.wantDiscarded)
+			}
+		})
+	}
+}
+
+// TestConnector_Impersonate_Transaction_Unit tests that a transaction runs within the impersonation
+func TestConnector_Impersonate_Transaction_Unit(t *testing.T) {
+	recorder := &recordingDriver{}
+	db := sql.OpenDB(recorder)
+	defer db.Close()
+
+	connector := &Connector{ExecuteAsUser: "security_admin", MaxRetries: -1}
+	err := connector.executePermissionsInTransaction(context.Background(), db, []func(*sql.Tx) error{
+		func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(context.Background(), "GRANT SELECT TO [app]")
+			return err
+		},
+	})
+	if err != nil {
+		t.Fatalf("executePermissionsInTransaction() unexpected error = %v", err)
+	}
+
+	want := []string{"EXECUTE AS USER = N'security_admin'", "GRANT SELECT TO [app]", "REVERT"}
+	if !reflect.DeepEqual(recorder.statements, want) {
+		t.Errorf("executePermissionsInTransaction() statements = %q, want %q", recorder.statements, want)
+	}
+}
@@ -0,0 +1,343 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"terraform-provider-mssqlpermissions/internal/queries/model"
+)
+
+// validateLogin validates the given login object.
+// It checks if the login has a name, and performs additional validations based on the login's properties
+// and on the options supported by the server edition.
+// If any validation fails, it returns an error indicating the reason.
+func (c *Connector) validateLogin(login *model.Login) error {
+
+	if login.Name == "" {
+		return errors.New("a login must have a name")
+	}
+
+	if login.External {
+		if login.Password != "" {
+			return errors.New("an external login cannot have a password")
+		}
+		if login.CheckPolicy != nil || login.CheckExpiration != nil {
+			return errors.New("check policy and check expiration only apply to SQL logins")
+		}
+	} else if login.ObjectID != "" {
+		return errors.New("only external login can specify an ObjectID")
+	}
+
+	if login.CheckExpiration != nil && *login.CheckExpiration && login.CheckPolicy != nil && !*login.CheckPolicy {
+		return errors.New("check expiration cannot be enabled when check policy is disabled")
+	}
+
+	// Azure SQL Database logins only have a name and a password.
+	if c.engineEdition == engineEditionAzureSQLDatabase {
+		if login.CheckPolicy != nil || login.CheckExpiration != nil {
+			return errors.New("check policy and check expiration are not supported on Azure SQL Database")
+		}
+		if login.DefaultDatabase != "" || login.DefaultLanguage != "" {
+			return errors.New("a login cannot have a default database or a default language on Azure SQL Database")
+		}
+	}
+
+	return nil
+}
+
+// onOff returns the T-SQL ON/OFF keyword for value.
+func onOff(value bool) string {
+	if value {
+		return "ON"
+	}
+	return "OFF"
+}
+
+// CreateLogin creates a server login. The connection must target the master database.
+// It takes a context, a database connection, and a login model as input.
+// It returns an error if the login creation fails, or nil if successful.
+func (c *Connector) CreateLogin(ctx context.Context, db *sql.DB, login *model.Login) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
+	var err error
+
+	// Validate the login object.
+	err = c.validateLogin(login)
+	if err != nil {
+		return fmt.Errorf("cannot create login. validation failed : %w", err)
+	}
+
+	// The password is only required on creation; updates leave it unchanged when empty.
+	if !login.External && login.Password == "" {
+		return errors.New("cannot create login. validation failed : a SQL login must have a password if it's not external")
+	}
+
+	// Check if the database connection is nil.
+	if err := c.validateDatabaseConnection(ctx, db); err != nil {
+		return err
+	}
+
+	// SQL query to create a login
+	// Note: CREATE LOGIN doesn't accept parameters. Working around by building the query string then executing it.
+	query := "'CREATE LOGIN ' + QUOTENAME(@name)"
+
+	var options []string
+
+	if login.External { // The authentication type is Azure Active Directory.
+		query = query + " + ' FROM EXTERNAL PROVIDER'"
+
+		if login.ObjectID != "" {
+			options = append(options, "'OBJECT_ID = ' + QUOTENAME(@objectID, '''')")
+		}
+	} else { // The authentication type is SQL Server authentication.
+		options = append(options, "'PASSWORD = ' + QUOTENAME(@password, '''')")
+
+		if login.CheckPolicy != nil {
+			options = append(options, "'CHECK_POLICY = "+onOff(*login.CheckPolicy)+"'")
+		}
+		if login.CheckExpiration != nil {
+			options = append(options, "'CHECK_EXPIRATION = "+onOff(*login.CheckExpiration)+"'")
+		}
+	}
+
+	if login.DefaultDatabase != "" {
+		options = append(options, "'DEFAULT_DATABASE = ' + QUOTENAME(@defaultDatabase)")
+	}
+	if login.DefaultLanguage != "" {
+		options = append(options, "'DEFAULT_LANGUAGE = ' + QUOTENAME(@defaultLanguage)")
+	}
+
+	if len(options) > 0 {
+		query = query + " + ' WITH ' + " + strings.Join(options, " + ', ' + ")
+	}
+
+	// The full TSQL script.
+	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
+
+	_, err = c.execContext(
+		ctx,
+		db,
+		tsql,
+		sql.Named("name", login.Name),
+		sql.Named("password", login.Password),
+		sql.Named("objectID", login.ObjectID),
+		sql.Named("defaultDatabase", login.DefaultDatabase),
+		sql.Named("defaultLanguage", login.DefaultLanguage))
+
+	if err != nil {
+		return fmt.Errorf("cannot create login. Underlying sql error : %w", err)
+	}
+
+	return nil
+}
+
+// GetLogin retrieves a server login based on the provided login name.
+// It takes a context, a database connection, and a login object as input.
+// It returns a new login object, without the password, and an error if any.
+func (c *Connector) GetLogin(ctx context.Context, db *sql.DB, login *model.Login) (*model.Login, error) {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
+	var err error
+
+	type ServerPrincipals struct {
+		Name                string
+		PrincipalID         int64
+		SID                 string
+		Type                string
+		DefaultDatabaseName sql.NullString
+		DefaultLanguageName sql.NullString
+		IsPolicyChecked     sql.NullBool
+		IsExpirationChecked sql.NullBool
+	}
+
+	var result ServerPrincipals
+
+	// Check if the database connection is nil.
+	if err := c.validateDatabaseConnection(ctx, db); err != nil {
+		return nil, err
+	}
+
+	// SQL query to retrieve a login. Only SQL logins have a password policy.
+	query := `SELECT sp.[name], sp.[principal_id], CONVERT(varchar(max), sp.[sid], 1) AS [sid], sp.[type],
+				sp.[default_database_name], sp.[default_language_name], sl.[is_policy_checked], sl.[is_expiration_checked]
+				FROM [sys].[server_principals] sp
+				LEFT JOIN [sys].[sql_logins] sl ON sl.[principal_id] = sp.[principal_id]
+				WHERE sp.[name] = @name AND sp.[type] IN ('S', 'U', 'G', 'E', 'X')`
+
+	// Execute query
+	row := c.queryRowContext(ctx, db, query, sql.Named("name", login.Name))
+
+	// Populate the result object with the result of the query.
+	err = row.Scan(
+		&result.Name,
+		&result.PrincipalID,
+		&result.SID,
+		&result.Type,
+		&result.DefaultDatabaseName,
+		&result.DefaultLanguageName,
+		&result.IsPolicyChecked,
+		&result.IsExpirationChecked)
+
+	// Check if the login was not found.
+	if err == sql.ErrNoRows {
+		return nil, errors.New("login not found")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot retrieve login: %w", err)
+	}
+
+	// Populate a new login object with the result.
+	found := &model.Login{
+		Name:            result.Name,
+		External:        result.Type == "E" || result.Type == "X",
+		ObjectID:        login.ObjectID,
+		DefaultDatabase: result.DefaultDatabaseName.String,
+		DefaultLanguage: result.DefaultLanguageName.String,
+		PrincipalID:     result.PrincipalID,
+		SID:             result.SID,
+	}
+
+	if result.IsPolicyChecked.Valid {
+		found.CheckPolicy = &result.IsPolicyChecked.Bool
+	}
+	if result.IsExpirationChecked.Valid {
+		found.CheckExpiration = &result.IsExpirationChecked.Bool
+	}
+
+	return found, nil
+}
+
+// UpdateLogin updates a server login. The connection must target the master database.
+// It takes a context, a database connection, and a login model as input.
+// It returns an error if the login update fails, or nil if successful.
+func (c *Connector) UpdateLogin(ctx context.Context, db *sql.DB, login *model.Login) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
+	var err error
+
+	// Validate the login object.
+	err = c.validateLogin(login)
+	if err != nil {
+		return fmt.Errorf("cannot update login. validation failed : %w", err)
+	}
+
+	// Get the original login
+	originalLogin, err := c.GetLogin(ctx, db, login)
+	if err != nil {
+		return fmt.Errorf("cannot retrieve the login to update. Underlying sql error : %w", err)
+	}
+
+	var options []string
+
+	if login.Password != "" {
+		options = append(options, "'PASSWORD = ' + QUOTENAME(@password, '''')")
+	}
+
+	if login.DefaultDatabase != "" && !strings.EqualFold(login.DefaultDatabase, originalLogin.DefaultDatabase) {
+		options = append(options, "'DEFAULT_DATABASE = ' + QUOTENAME(@defaultDatabase)")
+	}
+
+	if login.DefaultLanguage != "" && !strings.EqualFold(login.DefaultLanguage, originalLogin.DefaultLanguage) {
+		options = append(options, "'DEFAULT_LANGUAGE = ' + QUOTENAME(@defaultLanguage)")
+	}
+
+	options = append(options, checkOptions(login, originalLogin)...)
+
+	if len(options) == 0 {
+		return nil
+	}
+
+	// SQL query to update a login
+	query := "'ALTER LOGIN ' + QUOTENAME(@name) + ' WITH ' + " + strings.Join(options, " + ', ' + ")
+
+	// The full TSQL script.
+	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
+
+	_, err = c.execContext(
+		ctx,
+		db,
+		tsql,
+		sql.Named("name", login.Name),
+		sql.Named("password", login.Password),
+		sql.Named("defaultDatabase", login.DefaultDatabase),
+		sql.Named("defaultLanguage", login.DefaultLanguage))
+
+	if err != nil {
+		return fmt.Errorf("cannot update login. Underlying sql error : %w", err)
+	}
+
+	return nil
+}
+
+// checkOptions returns the CHECK_POLICY and CHECK_EXPIRATION options changing original into login.
+// SQL Server rejects a policy turned off while the expiration stays on (error 15128), so an unset expiration
+// is turned off along with the policy, and the expiration is always turned off before the policy and on after it.
+func checkOptions(login, original *model.Login) []string {
+	checkExpiration := login.CheckExpiration
+	if login.CheckPolicy != nil && !*login.CheckPolicy && checkExpiration == nil && original.CheckExpiration != nil && *original.CheckExpiration {
+		off := false
+		checkExpiration = &off
+	}
+
+	var policy, expiration string
+	if login.CheckPolicy != nil && (original.CheckPolicy == nil || *login.CheckPolicy != *original.CheckPolicy) {
+		policy = "'CHECK_POLICY = " + onOff(*login.CheckPolicy) + "'"
+	}
+	if checkExpiration != nil && (original.CheckExpiration == nil || *checkExpiration != *original.CheckExpiration) {
+		expiration = "'CHECK_EXPIRATION = " + onOff(*checkExpiration) + "'"
+	}
+
+	ordered := []string{policy, expiration}
+	if login.CheckPolicy != nil && !*login.CheckPolicy {
+		ordered = []string{expiration, policy}
+	}
+
+	var options []string
+	for _, option := range ordered {
+		if option != "" {
+			options = append(options, option)
+		}
+	}
+
+	return options
+}
+
+// DeleteLogin deletes a server login. The connection must target the master database.
+// It takes a context, a database connection, and a login model as input.
+// It returns an error if the login deletion fails, or nil if successful.
+func (c *Connector) DeleteLogin(ctx context.Context, db *sql.DB, login *model.Login) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
+	var err error
+
+	// Get the original login
+	_, err = c.GetLogin(ctx, db, login)
+	if err != nil {
+		return fmt.Errorf("cannot retrieve the login to delete. Underlying sql error : %w", err)
+	}
+
+	// SQL query to delete a login
+	query := "'DROP LOGIN ' + QUOTENAME(@name)"
+
+	// The full TSQL script.
+	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
+
+	_, err = c.execContext(ctx, db, tsql, sql.Named("name", login.Name))
+
+	if err != nil {
+		return fmt.Errorf("cannot delete login. Underlying sql error : %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+//go:build integration
+
+package queries
+
+import (
+	"context"
+	"terraform-provider-mssqlpermissions/internal/queries/model"
+	"testing"
+)
+
+func TestConnector_Login(t *testing.T) {
+	if !runLocalTests {
+		t.Skip("SQL logins are tested on the local SQL Server")
+	}
+
+	connector := testConnectors.localSQL.ForDatabase("master")
+	ctx := context.Background()
+	db, err := connector.Connect()
+	if err != nil {
+		t.Fatalf("Unable to connect: %v", err)
+	}
+
+	enabled, disabled := true, false
+	login := &model.Login{
+		Name:            generateRandomString(10),
+		Password:        generateRandomString(16) + "1aA!",
+		CheckPolicy:     &enabled,
+		CheckExpiration: &disabled,
+		DefaultDatabase: "master",
+	}
+
+	if err := connector.CreateLogin(ctx, db, login); err != nil {
+		t.Fatalf("Connector.CreateLogin() error = %v", err)
+	}
+	defer func() {
+		if err := connector.DeleteLogin(ctx, db, login); err != nil {
+			t.Errorf("error during cleanup = %v", err)
+		}
+	}()
+
+	got, err := connector.GetLogin(ctx, db, &model.Login{Name: login.Name})
+	if err != nil {
+		t.Fatalf("Connector.GetLogin() error = %v", err)
+	}
+	if got.External {
+		t.Errorf("Connector.GetLogin() External = %v, want false", got.External)
+	}
+	if got.CheckPolicy == nil || !*got.CheckPolicy {
+		t.Errorf("Connector.GetLogin() CheckPolicy = %v, want true", got.CheckPolicy)
+	}
+	if got.DefaultDatabase != "master" {
+		t.Errorf("Connector.GetLogin() DefaultDatabase = %v, want %v", got.DefaultDatabase, "master")
+	}
+
+	if err := connector.UpdateLogin(ctx, db, &model.Login{Name: login.Name, Password: generateRandomString(16) + "1aA!", CheckPolicy: &enabled, CheckExpiration: &enabled}); err != nil {
+		t.Fatalf("Connector.UpdateLogin() error = %v", err)
+	}
+
+	got, err = connector.GetLogin(ctx, db, &model.Login{Name: login.Name})
+	if err != nil {
+		t.Fatalf("Connector.GetLogin() error = %v", err)
+	}
+	if got.CheckExpiration == nil || !*got.CheckExpiration {
+		t.Errorf("Connector.GetLogin() CheckExpiration = %v, want true", got.CheckExpiration)
+	}
+
+	if _, err := connector.GetLogin(ctx, db, &model.Login{Name: generateRandomString(10)}); err == nil || err.Error() != "login not found" {
+		t.Errorf("Connector.GetLogin() error = %v, want %v", err, "login not found")
+	}
+}
@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"strings"
+	"terraform-provider-mssqlpermissions/internal/queries/model"
+	"testing"
+)
+
+// ============================================================================
+// LOGIN VALIDATION UNIT TESTS
+// ============================================================================
+
+// TestValidateLogin_Unit tests the validateLogin function with different server editions
+func TestValidateLogin_Unit(t *testing.T) {
+	serverConnector := &Connector{}
+	azureConnector := &Connector{isAzureDatabase: true, engineEdition: engineEditionAzureSQLDatabase}
+
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name      string
+		connector *Connector
+		login     *model.Login
+		wantErr   bool
+		errMsg    string
+	}{
+		{
+			name:      "valid_sql_login",
+			connector: serverConnector,
+			login:     &model.Login{Name: "testlogin", Password: "TestPassword123!", CheckPolicy: &enabled, CheckExpiration: &enabled, DefaultDatabase: "master", DefaultLanguage: "us_english"},
+			wantErr:   false,
+		},
+		{
+			name:      "valid_external_login",
+			connector: serverConnector,
+			login:     &model.Login{Name: "group@domain.com", External: true, DefaultDatabase: "master"},
+			wantErr:   false,
+		},
+		{
+			name:      "valid_external_login_azure",
+			connector: azureConnector,
+			login:     &model.Login{Name: "app", External: true, ObjectID: "00000000-0000-0000-0000-000000000000"},
+			wantErr:   false,
+		},
+		{
+			name:      "valid_sql_login_azure",
+			connector: azureConnector,
+			login:     &model.Login{Name: "testlogin", Password: "TestPassword123!"},
+			wantErr:   false,
+		},
+		{
+			name:      "missing_name",
+			connector: serverConnector,
+			login:     &model.Login{Password: "TestPassword123!"},
+			wantErr:   true,
+			errMsg:    "a login must have a name",
+		},
+		{
+			name:      "sql_login_update_without_password",
+			connector: serverConnector,
+			login:     &model.Login{Name: "testlogin", CheckPolicy: &enabled},
+			wantErr:   false,
+		},
+		{
+			name:      "external_login_with_password",
+			connector: serverConnector,
+			login:     &model.Login{Name: "testlogin", External: true, Password: "TestPassword123!"},
+			wantErr:   true,
+			errMsg:    "an external login cannot have a password",
+		},
+		{
+			name:      "external_login_with_check_policy",
+			connector: serverConnector,
+			login:     &model.Login{Name: "testlogin", External: true, CheckPolicy: &enabled},
+			wantErr:   true,
+			errMsg:    "only apply to SQL logins",
+		},
+		{
+			name:      "sql_login_with_object_id",
+			connector: serverConnector,
+			login:     &model.Login{Name: "testlogin", Password: "TestPassword123!", ObjectID: "00000000-0000-0000-0000-000000000000"},
+			wantErr:   true,
+			errMsg:    "only external login can specify an ObjectID",
+		},
+		{
+			name:      "check_expiration_without_check_policy",
+			connector: serverConnector,
+			login:     &model.Login{Name: "testlogin", Password: "TestPassword123!", CheckPolicy: &disabled, CheckExpiration: &enabled},
+			wantErr:   true,
+			errMsg:    "check expiration cannot be enabled",
+		},
+		{
+			name:      "check_policy_azure",
+			connector: azureConnector,
+			login:     &model.Login{Name: "testlogin", Password: "TestPassword123!", CheckPolicy: &enabled},
+			wantErr:   true,
+			errMsg:    "not supported on Azure SQL Database",
+		},
+		{
+			name:      "default_database_azure",
+			connector: azureConnector,
+			login:     &model.Login{Name: "testlogin", Password: "TestPassword123!", DefaultDatabase: "master"},
+			wantErr:   true,
+			errMsg:    "on Azure SQL Database",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.connector.validateLogin(tt.login)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("validateLogin() expected error but got none")
+					return
+				}
+				if !contains(err.Error(), tt.errMsg) {
+					t.Errorf("validateLogin() error = %v, expected to contain %v", err, tt.errMsg)
+				}
+			} else if err != nil {
+				t.Errorf("validateLogin() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+// TestCreateLogin_Validation_Unit tests that CreateLogin rejects invalid logins before using the connection
+func TestCreateLogin_Validation_Unit(t *testing.T) {
+	connector := &Connector{}
+
+	tests := []struct {
+		name   string
+		login  *model.Login
+		errMsg string
+	}{
+		{
+			name:   "sql_login_without_password",
+			login:  &model.Login{Name: "testlogin"},
+			errMsg: "a SQL login must have a password",
+		},
+		{
+			name:   "external_login_with_password",
+			login:  &model.Login{Name: "testlogin", External: true, Password: "TestPassword123!"},
+			errMsg: "an external login cannot have a password",
+		},
+		{
+			name:   "nil_connection",
+			login:  &model.Login{Name: "testlogin", Password: "TestPassword123!"},
+			errMsg: "database connection is nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := connector.CreateLogin(context.Background(), nil, tt.login)
+			if err == nil || !contains(err.Error(), tt.errMsg) {
+				t.Errorf("CreateLogin() error = %v, expected to contain %v", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+// TestCheckOptions_Unit tests the CHECK_POLICY and CHECK_EXPIRATION options sent to ALTER LOGIN
+func TestCheckOptions_Unit(t *testing.T) {
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name     string
+		login    *model.Login
+		original *model.Login
+		want     string
+	}{
+		{
+			name:     "unchanged",
+			login:    &model.Login{CheckPolicy: &enabled, CheckExpiration: &enabled},
+			original: &model.Login{CheckPolicy: &enabled, CheckExpiration: &enabled},
+			want:     "",
+		},
+		{
+			name:     "policy_off_with_expiration_on_on_the_server",
+			login:    &model.Login{CheckPolicy: &disabled},
+			original: &model.Login{CheckPolicy: &enabled, CheckExpiration: &enabled},
+			want:     "'CHECK_EXPIRATION = OFF', 'CHECK_POLICY = OFF'",
+		},
+		{
+			name:     "policy_off_with_expiration_off_on_the_server",
+			login:    &model.Login{CheckPolicy: &disabled},
+			original: &model.Login{CheckPolicy: &enabled, CheckExpiration: &disabled},
+			want:     "'CHECK_POLICY = OFF'",
+		},
+		{
+			name:     "both_off",
+			login:    &model.Login{CheckPolicy: &disabled, CheckExpiration: &disabled},
+			original: &model.Login{CheckPolicy: &enabled, CheckExpiration: &enabled},
+			want:     "'CHECK_EXPIRATION = OFF', 'CHECK_POLICY = OFF'",
+		},
+		{
+			name:     "both_on",
+			login:    &model.Login{CheckPolicy: &enabled, CheckExpiration: &enabled},
+			original: &model.Login{CheckPolicy: &disabled, CheckExpiration: &disabled},
+			want:     "'CHECK_POLICY = ON', 'CHECK_EXPIRATION = ON'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(checkOptions(tt.login, tt.original), ", ")
+			if got != tt.want {
+				t.Errorf("checkOptions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
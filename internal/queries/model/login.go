@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package model
+
+// Login is the model for the server login object in the MSSQL server.
+type Login struct {
+	Name            string
+	Password        string
+	External        bool
+	ObjectID        string // The Azure AD object ID
+	CheckPolicy     *bool  // Nil keeps the server default; always nil for external logins
+	CheckExpiration *bool  // Nil keeps the server default; always nil for external logins
+	DefaultDatabase string
+	DefaultLanguage string
+	PrincipalID     int64
+	SID             string // The SID stored in the master database
+}
@@ -17,3 +17,10 @@ type Permission struct {
 	State              string
 	StateDesc          string
 }
+
+// PermissionCheck is the model for an effective permission of the connecting principal.
+type PermissionCheck struct {
+	Class      string // DATABASE for the database of the connection, or SERVER
+	Permission string
+	Granted    bool
+}
@@ -12,4 +12,5 @@ type Role struct {
 	TypeDescription string
 	OwningPrincipal string
 	IsFixedRole     bool
+	SID             string // The SID stored in the database
 }
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package model
+
+// ServerInfo is the model for the server and database the connector is connected to.
+type ServerInfo struct {
+	ServerName                      string
+	Version                         string // The full @@VERSION banner
+	ProductVersion                  string // The SERVERPROPERTY('ProductVersion'), such as 16.0.4135.4
+	MajorVersion                    int64
+	MinorVersion                    int64
+	BuildNumber                     int64
+	Edition                         string
+	EngineEdition                   int64
+	EngineEditionName               string
+	IsAzure                         bool // Azure SQL Database or Azure SQL Managed Instance
+	DatabaseName                    string
+	Containment                     string // NONE or PARTIAL
+	CompatibilityLevel              int64
+	Collation                       string // The database collation
+	DefaultLanguage                 string // The server default language
+	ContainedDatabaseAuthentication bool
+	LoginName                       string
+	UserName                        string
+	DefaultLanguageSupported        bool // Contained users of the database may have a default language
+}
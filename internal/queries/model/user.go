@@ -8,10 +8,12 @@ package model
 type User struct {
 	Name            string
 	Password        string
+	OldPassword     string // The current password, checked by SQL Server when the password is changed
 	External        bool
 	PrincipalID     int64
 	DefaultSchema   string
 	DefaultLanguage string
 	ObjectID        string // The Azure AD object ID
+	LoginName       string // The server login the user is mapped to
 	SID             string // The SID stored in the database
 }
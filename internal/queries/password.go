@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultPasswordLength is the length of the generated passwords when the policy does not set one.
+	DefaultPasswordLength = 32
+
+	// DefaultExcludedPasswordCharacters are left out of the generated passwords by default: they delimit
+	// keys and values in ADO.NET and ODBC connection strings, or quote them.
+	DefaultExcludedPasswordCharacters = `;'"{}=`
+
+	// minPasswordLength and maxPasswordLength bound the length of a SQL Server password.
+	minPasswordLength = 8
+	maxPasswordLength = 128
+
+	// passwordAttempts bounds the passwords drawn until one does not contain the user name.
+	passwordAttempts = 10
+)
+
+// The character classes of the generated passwords.
+const (
+	lowerPasswordCharacters   = "abcdefghijklmnopqrstuvwxyz"
+	upperPasswordCharacters   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	numericPasswordCharacters = "0123456789"
+	specialPasswordCharacters = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+)
+
+// PasswordPolicy describes the passwords returned by GeneratePassword.
+type PasswordPolicy struct {
+	Length            int
+	Lower             bool
+	Upper             bool
+	Numeric           bool
+	Special           bool
+	ExcludeCharacters string
+}
+
+// GeneratePassword returns a random password following policy, drawn from crypto/rand.
+// The password holds at least one character of every enabled class, and meets the SQL Server complexity rules
+// for userName: a password spelling out the user name by chance is drawn again.
+func GeneratePassword(policy PasswordPolicy, userName string) (string, error) {
+	if policy.Length < minPasswordLength || policy.Length > maxPasswordLength {
+		return "", fmt.Errorf("cannot generate password. the length must be between %d and %d", minPasswordLength, maxPasswordLength)
+	}
+
+	var classes []string
+	for _, class := range []struct {
+		name       string
+		enabled    bool
+		characters string
+	}{
+		{"lowercase letters", policy.Lower, lowerPasswordCharacters},
+		{"uppercase letters", policy.Upper, upperPasswordCharacters},
+		{"digits", policy.Numeric, numericPasswordCharacters},
+		{"symbols", policy.Special, specialPasswordCharacters},
+	} {
+		if !class.enabled {
+			continue
+		}
+
+		characters := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(policy.ExcludeCharacters, r) {
+				return -1
+			}
+			return r
+		}, class.characters)
+		if characters == "" {
+			return "", fmt.Errorf("cannot generate password. every one of the %s is excluded", class.name)
+		}
+
+		classes = append(classes, characters)
+	}
+
+	if len(classes) < 3 {
+		return "", errors.New("cannot generate password. at least three character classes are required by the SQL Server complexity rules")
+	}
+
+	var err error
+	for attempt := 0; attempt < passwordAttempts; attempt++ {
+		var password string
+		password, err = randomPassword(classes, policy.Length)
+		if err != nil {
+			return "", err
+		}
+
+		if err = CheckPasswordComplexity(password, userName); err == nil {
+			return password, nil
+		}
+	}
+
+	return "", fmt.Errorf("cannot generate password. %w", err)
+}
+
+// randomPassword returns a password of length characters, holding one character of every class,
+// then characters of any class, in a random order.
+func randomPassword(classes []string, length int) (string, error) {
+	password := make([]byte, 0, length)
+	for _, characters := range classes {
+		c, err := randomCharacter(characters)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	all := strings.Join(classes, "")
+	for len(password) < length {
+		c, err := randomCharacter(all)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+
+	return string(password), nil
+}
+
+// CheckPasswordComplexity checks password against the complexity rules SQL Server enforces on the passwords
+// of contained users and SQL logins when the password policy applies, which is always the case on Azure SQL Database.
+// The password must be 8 to 128 characters long, mix three of uppercase letters, lowercase letters, digits and
+// symbols, and must not contain the user name.
+func CheckPasswordComplexity(password, userName string) error {
+	length := len([]rune(password))
+	if length < minPasswordLength || length > maxPasswordLength {
+		return fmt.Errorf("a password must be between %d and %d characters long", minPasswordLength, maxPasswordLength)
+	}
+
+	var upper, lower, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		case !unicode.IsLetter(r):
+			symbol = 1
+		}
+	}
+	if upper+lower+digit+symbol < 3 {
+		return errors.New("a password must contain characters from three of these categories: uppercase letters, lowercase letters, digits and symbols")
+	}
+
+	if len(userName) >= 3 && strings.Contains(strings.ToLower(password), strings.ToLower(userName)) {
+		return errors.New("a password must not contain the user name")
+	}
+
+	return nil
+}
+
+// randomCharacter returns a character of characters, which holds ASCII characters only.
+func randomCharacter(characters string) (byte, error) {
+	i, err := randomInt(len(characters))
+	if err != nil {
+		return 0, err
+	}
+	return characters[i], nil
+}
+
+// randomInt returns a uniform random integer in [0, n).
+func randomInt(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("cannot generate password. %w", err)
+	}
+	return int(i.Int64()), nil
+}
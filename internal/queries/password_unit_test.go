@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// PASSWORD GENERATION UNIT TESTS
+// ============================================================================
+
+// TestGeneratePassword_Unit tests the passwords generated under different policies
+func TestGeneratePassword_Unit(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  PasswordPolicy
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:   "default",
+			policy: PasswordPolicy{Length: DefaultPasswordLength, Lower: true, Upper: true, Numeric: true, Special: true, ExcludeCharacters: DefaultExcludedPasswordCharacters},
+		},
+		{
+			name:   "minimum_length",
+			policy: PasswordPolicy{Length: minPasswordLength, Lower: true, Upper: true, Numeric: true},
+		},
+		{
+			name:   "maximum_length_without_symbols",
+			policy: PasswordPolicy{Length: maxPasswordLength, Lower: true, Upper: true, Numeric: true},
+		},
+		{
+			name:    "too_short",
+			policy:  PasswordPolicy{Length: 7, Lower: true, Upper: true, Numeric: true},
+			wantErr: true,
+			errMsg:  "the length must be between 8 and 128",
+		},
+		{
+			name:    "too_long",
+			policy:  PasswordPolicy{Length: 129, Lower: true, Upper: true, Numeric: true},
+			wantErr: true,
+			errMsg:  "the length must be between 8 and 128",
+		},
+		{
+			name:    "two_classes",
+			policy:  PasswordPolicy{Length: 16, Lower: true, Numeric: true},
+			wantErr: true,
+			errMsg:  "at least three character classes",
+		},
+		{
+			name:    "every_digit_excluded",
+			policy:  PasswordPolicy{Length: 16, Lower: true, Upper: true, Numeric: true, ExcludeCharacters: "0123456789"},
+			wantErr: true,
+			errMsg:  "every one of the digits is excluded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GeneratePassword(tt.policy, "")
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("GeneratePassword() expected error but got none")
+					return
+				}
+				if !contains(err.Error(), tt.errMsg) {
+					t.Errorf("GeneratePassword() error = %v, expected to contain %v", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GeneratePassword() unexpected error = %v", err)
+			}
+			if len(got) != tt.policy.Length {
+				t.Errorf("GeneratePassword() length = %d, want %d", len(got), tt.policy.Length)
+			}
+			if strings.ContainsAny(got, tt.policy.ExcludeCharacters) && tt.policy.ExcludeCharacters != "" {
+				t.Errorf("GeneratePassword() = %q, contains one of %q", got, tt.policy.ExcludeCharacters)
+			}
+			if !tt.policy.Special && strings.ContainsAny(got, specialPasswordCharacters) {
+				t.Errorf("GeneratePassword() = %q, contains a symbol", got)
+			}
+		})
+	}
+}
+
+// TestGeneratePassword_Classes_Unit tests that every enabled class is present, even in the shortest passwords
+func TestGeneratePassword_Classes_Unit(t *testing.T) {
+	policy := PasswordPolicy{Length: minPasswordLength, Lower: true, Upper: true, Numeric: true, Special: true, ExcludeCharacters: DefaultExcludedPasswordCharacters}
+
+	for i := 0; i < 200; i++ {
+		got, err := GeneratePassword(policy, "")
+		if err != nil {
+			t.Fatalf("GeneratePassword() unexpected error = %v", err)
+		}
+		for _, class := range []string{lowerPasswordCharacters, upperPasswordCharacters, numericPasswordCharacters, specialPasswordCharacters} {
+			if !strings.ContainsAny(got, class) {
+				t.Fatalf("GeneratePassword() = %q, missing a character of %q", got, class)
+			}
+		}
+	}
+}
+
+// TestGeneratePassword_UserName_Unit tests that the generated passwords never contain the user name
+func TestGeneratePassword_UserName_Unit(t *testing.T) {
+	// The user name is made of the only symbol of the policy and letters, so that it can be drawn by chance.
+	policy := PasswordPolicy{Length: maxPasswordLength, Lower: true, Upper: true, Special: true, ExcludeCharacters: strings.ReplaceAll(specialPasswordCharacters, "_", "")}
+
+	for i := 0; i < 50; i++ {
+		got, err := GeneratePassword(policy, "a_b")
+		if err != nil {
+			t.Fatalf("GeneratePassword() unexpected error = %v", err)
+		}
+		if strings.Contains(strings.ToLower(got), "a_b") {
+			t.Fatalf("GeneratePassword() = %q, contains the user name", got)
+		}
+	}
+}
+
+// TestCheckPasswordComplexity_Unit tests the SQL Server complexity rules
+func TestCheckPasswordComplexity_Unit(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		userName string
+		errMsg   string
+	}{
+		{name: "complex", password: "Str0ngPassword", userName: "app_user"},
+		{name: "three_classes_with_symbols", password: "strong-password-1", userName: "app_user"},
+		{name: "too_short", password: "Sh0rt!", errMsg: "between 8 and 128 characters"},
+		{name: "too_long", password: strings.Repeat("aA1", 43), errMsg: "between 8 and 128 characters"},
+		{name: "two_classes", password: "onlylowercase123", errMsg: "three of these categories"},
+		{name: "contains_user_name", password: "My-App_User-Passw0rd", userName: "app_user", errMsg: "must not contain the user name"},
+		{name: "short_user_name_ignored", password: "Str0ngPassword", userName: "st"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckPasswordComplexity(tt.password, tt.userName)
+
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("CheckPasswordComplexity() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !contains(err.Error(), tt.errMsg) {
+				t.Errorf("CheckPasswordComplexity() error = %v, expected to contain %v", err, tt.errMsg)
+			}
+		})
+	}
+}
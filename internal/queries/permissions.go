@@ -33,7 +33,8 @@ const (
 	// Database permission queries
 	QueryDatabasePermissionsForRole = `SELECT [class], [class_desc], [major_id], [minor_id], [grantee_principal_id], [grantor_principal_id], [type], [permission_name], [state], [state_desc]
 		FROM [sys].[database_permissions]
-		WHERE grantee_principal_id = (SELECT principal_id FROM [sys].[database_principals] WHERE name = @name)`
+		WHERE grantee_principal_id = (SELECT principal_id FROM [sys].[database_principals] WHERE name = @name)
+		ORDER BY [permission_name]`
 
 	QueryDatabasePermissionForRole = `SELECT [class], [class_desc], [major_id], [minor_id], [grantee_principal_id], [grantor_principal_id], [type], [permission_name], [state], [state_desc]
 		FROM [sys].[database_permissions]
@@ -46,7 +47,8 @@ const (
 		INNER JOIN [sys].[schemas] s ON dp.[major_id] = s.[schema_id]
 		WHERE dp.[grantee_principal_id] = (SELECT principal_id FROM [sys].[database_principals] WHERE name = @roleName)
 			AND s.[name] = @schemaName
-			AND dp.[class] = 3`
+			AND dp.[class] = 3
+		ORDER BY dp.[permission_name]`
 
 	QuerySchemaPermissionForRole = `SELECT dp.[class], dp.[class_desc], dp.[major_id], dp.[minor_id], dp.[grantee_principal_id], dp.[grantor_principal_id], dp.[type], dp.[permission_name], dp.[state], dp.[state_desc]
 		FROM [sys].[database_permissions] dp
@@ -120,9 +122,20 @@ func scanPermissionRowFromSingleRow(row *sql.Row, permission *model.Permission)
 	return nil
 }
 
-// executePermissionsInTransaction executes a slice of permission operations within a transaction
+// executePermissionsInTransaction executes a slice of permission operations within a transaction,
+// as the impersonated user of the connector, if any.
+// A transaction rolled back by a transient error, such as a deadlock, is run again from the start.
 func (c *Connector) executePermissionsInTransaction(ctx context.Context, db *sql.DB, operations []func(*sql.Tx) error) error {
-	tx, err := db.BeginTx(ctx, nil)
+	return c.retry(ctx, func() error {
+		return c.impersonate(ctx, db, func(executor DatabaseInterface) error {
+			return c.runPermissionsTransaction(ctx, executor, operations)
+		})
+	})
+}
+
+// runPermissionsTransaction runs the permission operations once, within a single transaction
+func (c *Connector) runPermissionsTransaction(ctx context.Context, executor TransactionExecutor, operations []func(*sql.Tx) error) error {
+	tx, err := executor.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -155,7 +168,7 @@ func (c *Connector) executePermissionsInTransaction(ctx context.Context, db *sql
 // ============================================================================
 
 // Note: Database connection validation is now handled by sql.validateDatabaseConnection()
-// and sql.validateDatabaseConnectionWithRetry() for consistency across all packages.
+// for consistency across all packages.
 
 // validateRoleName validates that the role is not nil and has a valid name.
 func validateRoleName(role *model.Role) error {
@@ -261,6 +274,9 @@ func validateSchemaName(schema string) error {
 // The function validates all inputs including SQL identifier format and executes the
 // permission assignment within the current transaction context.
 func (c *Connector) AssignPermissionToRole(ctx context.Context, db *sql.DB, role *model.Role, permission *model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	// Validate inputs
 	if err := validateRoleName(role); err != nil {
 		return err
@@ -276,7 +292,7 @@ func (c *Connector) AssignPermissionToRole(ctx context.Context, db *sql.DB, role
 	}
 
 	// Validate database connection with retry logic
-	if err := c.validateDatabaseConnectionWithRetry(ctx, db, 3); err != nil {
+	if err := c.validateDatabaseConnection(ctx, db); err != nil {
 		return err
 	}
 
@@ -285,7 +301,7 @@ func (c *Connector) AssignPermissionToRole(ctx context.Context, db *sql.DB, role
 	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
 
 	// Execute the query.
-	_, err = db.ExecContext(ctx, tsql, sql.Named("roleName", role.Name))
+	_, err = c.execContext(ctx, db, tsql, sql.Named("roleName", role.Name))
 
 	// Check for any error during the query execution.
 	if err != nil {
@@ -300,6 +316,9 @@ func (c *Connector) AssignPermissionToRole(ctx context.Context, db *sql.DB, role
 // It takes a context, a database connection, a role, and a permission as parameters.
 // Returns nil if the permission is successfully granted to the role, otherwise returns an error.
 func (c *Connector) GrantPermissionToRole(ctx context.Context, db *sql.DB, role *model.Role, permission *model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	// Create a copy of the permission to avoid mutating the input parameter
 	permCopy := *permission
 	permCopy.State = "G"
@@ -310,6 +329,9 @@ func (c *Connector) GrantPermissionToRole(ctx context.Context, db *sql.DB, role
 // It takes a context, a database connection, a role, and a permission as parameters.
 // Returns nil if the permission is successfully denied to the role, otherwise returns an error.
 func (c *Connector) DenyPermissionToRole(ctx context.Context, db *sql.DB, role *model.Role, permission *model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	// Create a copy of the permission to avoid mutating the input parameter
 	permCopy := *permission
 	permCopy.State = "D"
@@ -320,6 +342,9 @@ func (c *Connector) DenyPermissionToRole(ctx context.Context, db *sql.DB, role *
 // It takes a context, a database connection, a role, and a slice of permissions as parameters.
 // Returns nil if the permissions are successfully granted to the role, otherwise returns an error.
 func (c *Connector) GrantPermissionsToRole(ctx context.Context, db *sql.DB, role *model.Role, permissions []*model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	for _, permission := range permissions {
 		err := c.GrantPermissionToRole(ctx, db, role, permission)
 		if err != nil {
@@ -333,6 +358,9 @@ func (c *Connector) GrantPermissionsToRole(ctx context.Context, db *sql.DB, role
 // It takes a context, a database connection, a role, and a slice of permissions as parameters.
 // Returns nil if the permissions are successfully denied to the role, otherwise returns an error.
 func (c *Connector) DenyPermissionsToRole(ctx context.Context, db *sql.DB, role *model.Role, permissions []*model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	for _, permission := range permissions {
 		err := c.DenyPermissionToRole(ctx, db, role, permission)
 		if err != nil {
@@ -347,6 +375,9 @@ func (c *Connector) DenyPermissionsToRole(ctx context.Context, db *sql.DB, role
 // If there is an error during the query execution, it returns an error.
 // Otherwise, it returns nil.
 func (c *Connector) RevokePermissionFromRole(ctx context.Context, db *sql.DB, role *model.Role, permission *model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	// Validate inputs
 	if err := validateRoleName(role); err != nil {
 		return err
@@ -365,7 +396,7 @@ func (c *Connector) RevokePermissionFromRole(ctx context.Context, db *sql.DB, ro
 	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
 
 	// Execute the query.
-	_, err := db.ExecContext(ctx, tsql, sql.Named("roleName", role.Name))
+	_, err := c.execContext(ctx, db, tsql, sql.Named("roleName", role.Name))
 
 	// Check for any error during the query execution.
 	if err != nil {
@@ -380,6 +411,9 @@ func (c *Connector) RevokePermissionFromRole(ctx context.Context, db *sql.DB, ro
 // It takes a context, a database connection, a role, and a slice of permissions as parameters.
 // Returns nil if all permissions are successfully revoked, otherwise returns an error.
 func (c *Connector) RevokePermissionsFromRole(ctx context.Context, db *sql.DB, role *model.Role, permissions []*model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	for _, permission := range permissions {
 		if err := c.RevokePermissionFromRole(ctx, db, role, permission); err != nil {
 			return fmt.Errorf("failed to revoke permission %s: %w", permission.Name, err)
@@ -397,6 +431,9 @@ func (c *Connector) RevokePermissionsFromRole(ctx context.Context, db *sql.DB, r
 
 // AssignPermissionOnSchemaToRole assigns the specified permission, grant or deny, to a role on a specific schema in the database.
 func (c *Connector) AssignPermissionOnSchemaToRole(ctx context.Context, db *sql.DB, role *model.Role, schema string, permission *model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	// Validate inputs
 	if err := validateRoleName(role); err != nil {
 		return err
@@ -425,7 +462,7 @@ func (c *Connector) AssignPermissionOnSchemaToRole(ctx context.Context, db *sql.
 	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
 
 	// Execute the query.
-	_, err = db.ExecContext(ctx, tsql, sql.Named("roleName", role.Name))
+	_, err = c.execContext(ctx, db, tsql, sql.Named("roleName", role.Name))
 
 	// Check for any error during the query execution.
 	if err != nil {
@@ -440,6 +477,9 @@ func (c *Connector) AssignPermissionOnSchemaToRole(ctx context.Context, db *sql.
 // It takes a context, a database connection, a role, schema name, and a permission as parameters.
 // Returns nil if the permission is successfully granted to the role, otherwise returns an error.
 func (c *Connector) GrantPermissionOnSchemaToRole(ctx context.Context, db *sql.DB, role *model.Role, schema string, permission *model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	// Create a copy of the permission to avoid mutating the input parameter
 	permCopy := *permission
 	permCopy.State = "G"
@@ -450,6 +490,9 @@ func (c *Connector) GrantPermissionOnSchemaToRole(ctx context.Context, db *sql.D
 // It takes a context, a database connection, a role, schema name, and a permission as parameters.
 // Returns nil if the permission is successfully denied to the role, otherwise returns an error.
 func (c *Connector) DenyPermissionOnSchemaToRole(ctx context.Context, db *sql.DB, role *model.Role, schema string, permission *model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	// Create a copy of the permission to avoid mutating the input parameter
 	permCopy := *permission
 	permCopy.State = "D"
@@ -460,6 +503,9 @@ func (c *Connector) DenyPermissionOnSchemaToRole(ctx context.Context, db *sql.DB
 // It takes a context, a database connection, a role, schema name, and a slice of permissions as parameters.
 // Returns nil if the permissions are successfully granted to the role, otherwise returns an error.
 func (c *Connector) GrantPermissionsOnSchemaToRole(ctx context.Context, db *sql.DB, role *model.Role, schema string, permissions []*model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	for _, permission := range permissions {
 		err := c.GrantPermissionOnSchemaToRole(ctx, db, role, schema, permission)
 		if err != nil {
@@ -473,6 +519,9 @@ func (c *Connector) GrantPermissionsOnSchemaToRole(ctx context.Context, db *sql.
 // It takes a context, a database connection, a role, schema name, and a slice of permissions as parameters.
 // Returns nil if the permissions are successfully denied to the role, otherwise returns an error.
 func (c *Connector) DenyPermissionsOnSchemaToRole(ctx context.Context, db *sql.DB, role *model.Role, schema string, permissions []*model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	for _, permission := range permissions {
 		err := c.DenyPermissionOnSchemaToRole(ctx, db, role, schema, permission)
 		if err != nil {
@@ -487,6 +536,9 @@ func (c *Connector) DenyPermissionsOnSchemaToRole(ctx context.Context, db *sql.D
 // If there is an error during the query execution, it returns an error.
 // Otherwise, it returns nil.
 func (c *Connector) RevokePermissionOnSchemaFromRole(ctx context.Context, db *sql.DB, role *model.Role, schema string, permission *model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	// Validate inputs
 	if err := validateRoleName(role); err != nil {
 		return err
@@ -509,7 +561,7 @@ func (c *Connector) RevokePermissionOnSchemaFromRole(ctx context.Context, db *sq
 	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
 
 	// Execute the query.
-	_, err := db.ExecContext(ctx, tsql, sql.Named("roleName", role.Name))
+	_, err := c.execContext(ctx, db, tsql, sql.Named("roleName", role.Name))
 
 	// Check for any error during the query execution.
 	if err != nil {
@@ -524,6 +576,9 @@ func (c *Connector) RevokePermissionOnSchemaFromRole(ctx context.Context, db *sq
 // It takes a context, a database connection, a role, schema name, and a slice of permissions as parameters.
 // Returns nil if all permissions are successfully revoked, otherwise returns an error.
 func (c *Connector) RevokePermissionsOnSchemaFromRole(ctx context.Context, db *sql.DB, role *model.Role, schema string, permissions []*model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	for _, permission := range permissions {
 		if err := c.RevokePermissionOnSchemaFromRole(ctx, db, role, schema, permission); err != nil {
 			return fmt.Errorf("failed to revoke schema permission %s: %w", permission.Name, err)
@@ -542,6 +597,9 @@ func (c *Connector) RevokePermissionsOnSchemaFromRole(ctx context.Context, db *s
 // GrantPermissionsToRoleWithTransaction grants the specified permissions to a role within a transaction.
 // This ensures atomicity - either all permissions are granted or none are.
 func (c *Connector) GrantPermissionsToRoleWithTransaction(ctx context.Context, db *sql.DB, role *model.Role, permissions []*model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	operations := make([]func(*sql.Tx) error, len(permissions))
 	for i, permission := range permissions {
 		perm := permission // capture loop variable
@@ -575,6 +633,9 @@ func (c *Connector) GrantPermissionsToRoleWithTransaction(ctx context.Context, d
 //   - nil if all permissions are successfully denied
 //   - error if any validation fails or any permission denial fails (with rollback)
 func (c *Connector) DenyPermissionsToRoleWithTransaction(ctx context.Context, db *sql.DB, role *model.Role, permissions []*model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	operations := make([]func(*sql.Tx) error, len(permissions))
 	for i, permission := range permissions {
 		perm := permission // capture loop variable
@@ -587,6 +648,9 @@ func (c *Connector) DenyPermissionsToRoleWithTransaction(ctx context.Context, db
 
 // RevokePermissionsFromRoleWithTransaction revokes multiple database permissions from a role within a transaction.
 func (c *Connector) RevokePermissionsFromRoleWithTransaction(ctx context.Context, db *sql.DB, role *model.Role, permissions []*model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	operations := make([]func(*sql.Tx) error, len(permissions))
 	for i, permission := range permissions {
 		perm := permission // capture loop variable
@@ -599,6 +663,9 @@ func (c *Connector) RevokePermissionsFromRoleWithTransaction(ctx context.Context
 
 // RevokePermissionsOnSchemaFromRoleWithTransaction revokes multiple schema permissions from a role within a transaction.
 func (c *Connector) RevokePermissionsOnSchemaFromRoleWithTransaction(ctx context.Context, db *sql.DB, role *model.Role, schema string, permissions []*model.Permission) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	operations := make([]func(*sql.Tx) error, len(permissions))
 	for i, permission := range permissions {
 		perm := permission // capture loop variable
@@ -663,6 +730,9 @@ func (c *Connector) revokePermissionOnSchemaFromRoleInTx(ctx context.Context, tx
 // It takes a context.Context, *sql.DB, and *model.Role as input parameters.
 // It returns a slice of model.Permission and an error.
 func (c *Connector) GetDatabasePermissionsForRole(ctx context.Context, db *sql.DB, role *model.Role) ([]model.Permission, error) {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	var permissions []model.Permission
 
 	// Validate inputs
@@ -671,12 +741,12 @@ func (c *Connector) GetDatabasePermissionsForRole(ctx context.Context, db *sql.D
 	}
 
 	// Validate database connection with retry logic
-	if err := c.validateDatabaseConnectionWithRetry(ctx, db, 3); err != nil {
+	if err := c.validateDatabaseConnection(ctx, db); err != nil {
 		return nil, err
 	}
 
 	// Execute the query using the predefined constant.
-	rows, err := db.QueryContext(ctx, QueryDatabasePermissionsForRole, sql.Named("name", role.Name))
+	rows, err := c.queryContext(ctx, db, QueryDatabasePermissionsForRole, sql.Named("name", role.Name))
 
 	// Check for any error during the query execution.
 	if err != nil {
@@ -708,6 +778,9 @@ func (c *Connector) GetDatabasePermissionsForRole(ctx context.Context, db *sql.D
 // It takes a context.Context, *sql.DB, *model.Role, and a *model.Permission as input parameters.
 // It returns a *model.Permission and an error.
 func (c *Connector) GetDatabasePermissionForRole(ctx context.Context, db *sql.DB, role *model.Role, permission *model.Permission) (*model.Permission, error) {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	var err error
 
 	// Validate inputs
@@ -724,8 +797,9 @@ func (c *Connector) GetDatabasePermissionForRole(ctx context.Context, db *sql.DB
 	}
 
 	// Execute the query using the predefined constant.
-	row := db.QueryRowContext(
+	row := c.queryRowContext(
 		ctx,
+		db,
 		QueryDatabasePermissionForRole,
 		sql.Named("name", role.Name),
 		sql.Named("permissionName", permission.Name))
@@ -752,6 +826,9 @@ func (c *Connector) GetDatabasePermissionForRole(ctx context.Context, db *sql.DB
 
 // GetSchemaPermissionsForRole retrieves the permissions for a role on a specific schema in the database.
 func (c *Connector) GetSchemaPermissionsForRole(ctx context.Context, db *sql.DB, role *model.Role, schema string) ([]model.Permission, error) {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	var permissions []model.Permission
 
 	// Validate inputs
@@ -769,7 +846,7 @@ func (c *Connector) GetSchemaPermissionsForRole(ctx context.Context, db *sql.DB,
 	}
 
 	// Execute the query using the predefined constant.
-	rows, err := db.QueryContext(ctx, QuerySchemaPermissionsForRole, sql.Named("roleName", role.Name), sql.Named("schemaName", schema))
+	rows, err := c.queryContext(ctx, db, QuerySchemaPermissionsForRole, sql.Named("roleName", role.Name), sql.Named("schemaName", schema))
 	if err != nil {
 		return nil, fmt.Errorf("query execution error - cannot retrieve schema permissions for role: %w", err)
 	}
@@ -795,6 +872,9 @@ func (c *Connector) GetSchemaPermissionsForRole(ctx context.Context, db *sql.DB,
 
 // GetSchemaPermissionForRole retrieves a specific permission for a role on a specific schema in the database.
 func (c *Connector) GetSchemaPermissionForRole(ctx context.Context, db *sql.DB, role *model.Role, schema string, permission *model.Permission) (*model.Permission, error) {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	var err error
 
 	// Validate inputs
@@ -815,8 +895,9 @@ func (c *Connector) GetSchemaPermissionForRole(ctx context.Context, db *sql.DB,
 	}
 
 	// Execute the query using the predefined constant.
-	row := db.QueryRowContext(
+	row := c.queryRowContext(
 		ctx,
+		db,
 		QuerySchemaPermissionForRole,
 		sql.Named("roleName", role.Name),
 		sql.Named("schemaName", schema),
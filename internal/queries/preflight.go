@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"terraform-provider-mssqlpermissions/internal/queries/model"
+)
+
+// Securable classes of the effective permission checks.
+const (
+	SecurableClassDatabase = "DATABASE"
+	SecurableClassServer   = "SERVER"
+)
+
+// HasPermissions reports whether the connecting principal holds each of the permissions, granted directly,
+// implied by a covering permission such as CONTROL, or inherited from a role membership.
+// DATABASE permissions are checked on the database of the connection.
+func (c *Connector) HasPermissions(ctx context.Context, db *sql.DB, permissions []model.PermissionCheck) ([]model.PermissionCheck, error) {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
+	for _, permission := range permissions {
+		if permission.Class != SecurableClassDatabase && permission.Class != SecurableClassServer {
+			return nil, fmt.Errorf("invalid securable class %q", permission.Class)
+		}
+		if err := validateSQLPermissionName(permission.Permission); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check if the database connection is nil.
+	if err := c.validateDatabaseConnection(ctx, db); err != nil {
+		return nil, err
+	}
+
+	// SQL query to check an effective permission. A NULL securable is the current database or the server,
+	// and HAS_PERMS_BY_NAME returns NULL for a permission that does not exist in the class.
+	query := "SELECT HAS_PERMS_BY_NAME(NULL, NULLIF(@class, 'SERVER'), @permission)"
+
+	// The permissions are those of the impersonated user, if any, since it runs the mutating statements.
+	var results []model.PermissionCheck
+	err := c.retry(ctx, func() error {
+		return c.impersonate(ctx, db, func(executor DatabaseInterface) error {
+			results = make([]model.PermissionCheck, 0, len(permissions))
+			for _, permission := range permissions {
+				var granted sql.NullInt64
+				row := executor.QueryRowContext(ctx, query, sql.Named("class", permission.Class), sql.Named("permission", permission.Permission))
+				if err := row.Scan(&granted); err != nil {
+					return fmt.Errorf("cannot check the %s permission. Underlying sql error : %w", permission.Permission, err)
+				}
+				if !granted.Valid {
+					return fmt.Errorf("unknown %s permission %q", permission.Class, permission.Permission)
+				}
+
+				permission.Granted = granted.Int64 == 1
+				results = append(results, permission)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
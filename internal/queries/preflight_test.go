@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+//go:build integration
+
+package queries
+
+import (
+	"context"
+	"terraform-provider-mssqlpermissions/internal/queries/model"
+	"testing"
+)
+
+func TestConnector_HasPermissions(t *testing.T) {
+	if !runLocalTests {
+		t.Skip("The effective permissions are tested on the local SQL Server")
+	}
+
+	connector := testConnectors.localSQL
+	ctx := context.Background()
+	db, err := connector.Connect()
+	if err != nil {
+		t.Fatalf("Unable to connect: %v", err)
+	}
+
+	// The local tests connect as a sysadmin, which holds every permission.
+	checks, err := connector.HasPermissions(ctx, db, []model.PermissionCheck{
+		{Class: SecurableClassDatabase, Permission: "ALTER ANY USER"},
+		{Class: SecurableClassDatabase, Permission: "ALTER ANY ROLE"},
+		{Class: SecurableClassServer, Permission: "ALTER ANY LOGIN"},
+	})
+	if err != nil {
+		t.Fatalf("HasPermissions() error = %v", err)
+	}
+	for _, check := range checks {
+		if !check.Granted {
+			t.Errorf("HasPermissions() %s %s not granted", check.Class, check.Permission)
+		}
+	}
+
+	// A permission of another class is reported as unknown.
+	_, err = connector.HasPermissions(ctx, db, []model.PermissionCheck{
+		{Class: SecurableClassServer, Permission: "ALTER ANY USER"},
+	})
+	if err == nil {
+		t.Error("HasPermissions() expected an error for an unknown server permission")
+	}
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"terraform-provider-mssqlpermissions/internal/queries/model"
+)
+
+// TestHasPermissions_Validation_Unit tests the validation of the effective permission checks
+func TestHasPermissions_Validation_Unit(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission model.PermissionCheck
+		errMsg     string
+	}{
+		{
+			name:       "invalid_class",
+			permission: model.PermissionCheck{Class: "SCHEMA", Permission: "ALTER"},
+			errMsg:     "invalid securable class",
+		},
+		{
+			name:       "empty_permission",
+			permission: model.PermissionCheck{Class: SecurableClassDatabase},
+			errMsg:     "permission name cannot be empty",
+		},
+		{
+			name:       "injection_attempt",
+			permission: model.PermissionCheck{Class: SecurableClassServer, Permission: "ALTER ANY LOGIN'); DROP TABLE users; --"},
+			errMsg:     "invalid permission name format",
+		},
+		{
+			name:       "nil_database",
+			permission: model.PermissionCheck{Class: SecurableClassDatabase, Permission: "ALTER ANY USER"},
+			errMsg:     "database connection is nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector := &Connector{}
+
+			_, err := connector.HasPermissions(context.Background(), nil, []model.PermissionCheck{tt.permission})
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("HasPermissions() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
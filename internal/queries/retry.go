@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mssql "github.com/microsoft/go-mssqldb"
+)
+
+// Default retry policy of the statements failing with a transient error.
+const (
+	DefaultMaxRetries      = 5
+	defaultRetryMinBackoff = 1 * time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// transientErrors lists the SQL Server error numbers after which the same statement may succeed.
+var transientErrors = map[int32]string{
+	1205:  "deadlock victim",
+	10928: "resource limit reached",
+	10929: "resource limit reached",
+	33130: "Entra ID principal not found, it may not be propagated yet",
+	40197: "service error processing the request",
+	40501: "service busy",
+	40613: "database unavailable",
+	49918: "not enough resources to process the request",
+	49919: "too many create or update operations in progress",
+	49920: "too many operations in progress",
+}
+
+// isTransientError reports whether err, or any error reported by the server along with it,
+// is a transient SQL Server error.
+func isTransientError(err error) bool {
+	var sqlErr mssql.Error
+	if !errors.As(err, &sqlErr) {
+		return false
+	}
+
+	if _, ok := transientErrors[sqlErr.Number]; ok {
+		return true
+	}
+	for _, e := range sqlErr.All {
+		if _, ok := transientErrors[e.Number]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// retryPolicy returns the retry limits of the connector, with the defaults applied.
+func (c *Connector) retryPolicy() (maxRetries int, minBackoff, maxBackoff time.Duration) {
+	maxRetries, minBackoff, maxBackoff = c.MaxRetries, c.RetryMinBackoff, c.RetryMaxBackoff
+
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	if minBackoff <= 0 {
+		minBackoff = defaultRetryMinBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+	if maxBackoff < minBackoff {
+		maxBackoff = minBackoff
+	}
+	return maxRetries, minBackoff, maxBackoff
+}
+
+// retryBackoff returns the wait before the retry following attempt, counted from zero.
+// It doubles from minBackoff for every attempt up to maxBackoff, and a random half of it is skipped
+// so that concurrent operations failing together do not retry together.
+func retryBackoff(attempt int, minBackoff, maxBackoff time.Duration) time.Duration {
+	backoff := maxBackoff
+	if attempt < 32 && minBackoff<<attempt < maxBackoff {
+		backoff = minBackoff << attempt
+	}
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// retry runs operation until it succeeds, fails with an error that is not transient, or the retry
+// policy of the connector is exhausted. It returns the last error of operation.
+func (c *Connector) retry(ctx context.Context, operation func() error) error {
+	maxRetries, minBackoff, maxBackoff := c.retryPolicy()
+
+	for attempt := 0; ; attempt++ {
+		err := operation()
+		if err == nil || attempt >= maxRetries || !isTransientError(err) {
+			return err
+		}
+
+		backoff := retryBackoff(attempt, minBackoff, maxBackoff)
+		tflog.Warn(ctx, "Retrying after a transient SQL Server error", map[string]interface{}{
+			"attempt":     attempt + 1,
+			"max_retries": maxRetries,
+			"backoff":     backoff.String(),
+			"error":       err.Error(),
+		})
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// execContext executes a statement on db as the impersonated user of the connector, if any,
+// retrying it on transient errors.
+func (c *Connector) execContext(ctx context.Context, db *sql.DB, query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := c.retry(ctx, func() error {
+		return c.impersonate(ctx, db, func(executor DatabaseInterface) error {
+			var err error
+			result, err = executor.ExecContext(ctx, query, args...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// queryContext runs a query on db, retrying it on transient errors.
+// Errors raised while iterating over the returned rows are not retried.
+func (c *Connector) queryContext(ctx context.Context, db *sql.DB, query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := c.retry(ctx, func() error {
+		var err error
+		rows, err = db.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+// queryRowContext runs a query returning at most one row on db, retrying it on transient errors.
+// As with sql.DB.QueryRowContext, the error of the query is reported by the Scan of the returned row.
+func (c *Connector) queryRowContext(ctx context.Context, db *sql.DB, query string, args ...interface{}) *sql.Row {
+	var row *sql.Row
+	_ = c.retry(ctx, func() error {
+		row = db.QueryRowContext(ctx, query, args...)
+		return row.Err()
+	})
+	return row
+}
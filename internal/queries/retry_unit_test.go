@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	mssql "github.com/microsoft/go-mssqldb"
+)
+
+// ============================================================================
+// RETRY POLICY UNIT TESTS
+// ============================================================================
+
+// TestIsTransientError_Unit tests the classification of SQL Server errors
+func TestIsTransientError_Unit(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			want: false,
+		},
+		{
+			name: "not_a_sql_server_error",
+			err:  errors.New("connection refused"),
+			want: false,
+		},
+		{
+			name: "no_rows",
+			err:  sql.ErrNoRows,
+			want: false,
+		},
+		{
+			name: "deadlock",
+			err:  mssql.Error{Number: 1205},
+			want: true,
+		},
+		{
+			name: "database_unavailable",
+			err:  mssql.Error{Number: 40613},
+			want: true,
+		},
+		{
+			name: "service_busy",
+			err:  mssql.Error{Number: 40501},
+			want: true,
+		},
+		{
+			name: "entra_principal_not_propagated",
+			err:  mssql.Error{Number: 33130},
+			want: true,
+		},
+		{
+			name: "wrapped",
+			err:  fmt.Errorf("cannot create user. Underlying sql error : %w", mssql.Error{Number: 40197}),
+			want: true,
+		},
+		{
+			name: "transient_error_reported_first",
+			err:  mssql.Error{Number: 266, All: []mssql.Error{{Number: 1205}, {Number: 266}}},
+			want: true,
+		},
+		{
+			name: "permission_denied",
+			err:  mssql.Error{Number: 15247},
+			want: false,
+		},
+		{
+			name: "already_exists",
+			err:  mssql.Error{Number: 15023, All: []mssql.Error{{Number: 15023}}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientError(tt.err); got != tt.want {
+				t.Errorf("isTransientError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRetryBackoff_Unit tests that the backoff grows exponentially within its bounds
+func TestRetryBackoff_Unit(t *testing.T) {
+	minBackoff := 100 * time.Millisecond
+	maxBackoff := time.Second
+
+	tests := []struct {
+		attempt int
+		ceiling time.Duration
+	}{
+		{attempt: 0, ceiling: 100 * time.Millisecond},
+		{attempt: 1, ceiling: 200 * time.Millisecond},
+		{attempt: 3, ceiling: 800 * time.Millisecond},
+		{attempt: 4, ceiling: time.Second},
+		{attempt: 100, ceiling: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempt_%d", tt.attempt), func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				backoff := retryBackoff(tt.attempt, minBackoff, maxBackoff)
+				if backoff < tt.ceiling/2 || backoff > tt.ceiling {
+					t.Fatalf("retryBackoff() = %v, want between %v and %v", backoff, tt.ceiling/2, tt.ceiling)
+				}
+			}
+		})
+	}
+}
+
+// TestConnector_RetryPolicy_Unit tests the defaults of the retry policy
+func TestConnector_RetryPolicy_Unit(t *testing.T) {
+	maxRetries, minBackoff, maxBackoff := (&Connector{}).retryPolicy()
+	if maxRetries != DefaultMaxRetries || minBackoff != defaultRetryMinBackoff || maxBackoff != defaultRetryMaxBackoff {
+		t.Errorf("retryPolicy() = %v, %v, %v, want the defaults", maxRetries, minBackoff, maxBackoff)
+	}
+
+	connector := &Connector{MaxRetries: 2, RetryMinBackoff: 5 * time.Second, RetryMaxBackoff: time.Second}
+	maxRetries, minBackoff, maxBackoff = connector.retryPolicy()
+	if maxRetries != 2 || minBackoff != 5*time.Second || maxBackoff != 5*time.Second {
+		t.Errorf("retryPolicy() = %v, %v, %v, want 2, 5s, 5s", maxRetries, minBackoff, maxBackoff)
+	}
+}
+
+// TestConnector_Retry_Unit tests the number of attempts made for each kind of error
+func TestConnector_Retry_Unit(t *testing.T) {
+	transient := mssql.Error{Number: 40613, Message: "Database is not currently available."}
+
+	tests := []struct {
+		name         string
+		maxRetries   int
+		errors       []error
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "success",
+			maxRetries:   3,
+			errors:       []error{nil},
+			wantAttempts: 1,
+		},
+		{
+			name:         "success_after_transient_errors",
+			maxRetries:   3,
+			errors:       []error{transient, transient, nil},
+			wantAttempts: 3,
+		},
+		{
+			name:         "permanent_error_not_retried",
+			maxRetries:   3,
+			errors:       []error{mssql.Error{Number: 2714}},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "retries_exhausted",
+			maxRetries:   2,
+			errors:       []error{transient, transient, transient, nil},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "retries_disabled",
+			maxRetries:   -1,
+			errors:       []error{transient, nil},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector := &Connector{
+				MaxRetries:      tt.maxRetries,
+				RetryMinBackoff: time.Millisecond,
+				RetryMaxBackoff: time.Millisecond,
+			}
+
+			attempts := 0
+			err := connector.retry(context.Background(), func() error {
+				err := tt.errors[attempts]
+				attempts++
+				return err
+			})
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("retry() made %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("retry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestConnector_Retry_ContextCanceled_Unit tests that the backoff stops when the context is done
+func TestConnector_Retry_ContextCanceled_Unit(t *testing.T) {
+	connector := &Connector{MaxRetries: 10, RetryMinBackoff: time.Hour, RetryMaxBackoff: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	attempts := 0
+	err := connector.retry(ctx, func() error {
+		attempts++
+		return mssql.Error{Number: 1205}
+	})
+
+	if attempts != 1 {
+		t.Errorf("retry() made %d attempts, want 1", attempts)
+	}
+	if !isTransientError(err) {
+		t.Errorf("retry() error = %v, want the last transient error", err)
+	}
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	mssql "github.com/microsoft/go-mssqldb"
+)
+
+// secretCommandTimeout bounds the execution of a command printing a secret.
+const secretCommandTimeout = 30 * time.Second
+
+// resolveSecret returns the secret given inline in value, read from file, or printed on the standard
+// output of command, which is run without a shell. The line break ending a file or an output is removed.
+// name describes the secret in the errors, which never include its value.
+func resolveSecret(ctx context.Context, name, value, file string, command []string) (string, error) {
+	sources := 0
+	for _, set := range []bool{value != "", file != "", len(command) > 0} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return "", fmt.Errorf("%s, %s file and %s command are mutually exclusive", name, name, name)
+	}
+
+	switch {
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("cannot read %s file: %w", name, err)
+		}
+		secret := strings.TrimRight(string(data), "\r\n")
+		if secret == "" {
+			return "", fmt.Errorf("%s file %s is empty", name, file)
+		}
+		return secret, nil
+	case len(command) > 0:
+		ctx, cancel := context.WithTimeout(ctx, secretCommandTimeout)
+		defer cancel()
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		if err := cmd.Run(); err != nil {
+			if message := strings.TrimSpace(stderr.String()); message != "" {
+				err = fmt.Errorf("%w: %s", err, message)
+			}
+			return "", fmt.Errorf("%s command %q failed: %w", name, command[0], err)
+		}
+		secret := strings.TrimRight(stdout.String(), "\r\n")
+		if secret == "" {
+			return "", fmt.Errorf("%s command %q printed nothing", name, command[0])
+		}
+		return secret, nil
+	default:
+		return value, nil
+	}
+}
+
+// password returns the password of the login, read again from its file or command on every call.
+func (l *LocalUserLogin) password(ctx context.Context) (string, error) {
+	return resolveSecret(ctx, "password", l.Password, l.PasswordFile, l.PasswordCommand)
+}
+
+// clientSecret returns the client secret of the login, read again from its file or command on every call.
+func (l *AzureApplicationLogin) clientSecret(ctx context.Context) (string, error) {
+	return resolveSecret(ctx, "client secret", l.ClientSecret, l.ClientSecretFile, l.ClientSecretCommand)
+}
+
+// hasSecretSource reports whether the login of the connector reads a secret from a file or a command.
+func (c *Connector) hasSecretSource() bool {
+	if l := c.LocalUserLogin; l != nil && (l.PasswordFile != "" || len(l.PasswordCommand) > 0) {
+		return true
+	}
+	if l := c.AzureApplicationLogin; l != nil && (l.ClientSecretFile != "" || len(l.ClientSecretCommand) > 0) {
+		return true
+	}
+	return false
+}
+
+// secretConnector is a driver.Connector reading the secret of the login again for every new connection,
+// so that a rotated secret file or a command returning short-lived credentials is picked up by the pool.
+type secretConnector struct {
+	connector        *Connector
+	connectionString *url.URL
+	query            url.Values
+}
+
+// Connect builds a driver connector with the current secret and opens a connection with it.
+func (s *secretConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	// The authentication adds its parameters to the connection string, so each build starts from a copy.
+	connectionString := *s.connectionString
+	query, err := url.ParseQuery(s.query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	connector, err := s.connector.withSessionContext(s.connector.authenticatedConnector(ctx, &connectionString, query))
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+// Driver returns the go-mssqldb driver.
+func (s *secretConnector) Driver() driver.Driver {
+	return &mssql.Driver{}
+}
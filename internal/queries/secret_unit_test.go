@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mssql "github.com/microsoft/go-mssqldb"
+)
+
+// ============================================================================
+// SECRET SOURCES UNIT TESTS
+// ============================================================================
+
+// TestResolveSecret_Unit tests the secrets given inline, read from a file or printed by a command
+func TestResolveSecret_Unit(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "secret")
+	if err := os.WriteFile(secretFile, []byte("from-file\r\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		file    string
+		command []string
+		want    string
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:  "inline",
+			value: "inline-secret",
+			want:  "inline-secret",
+		},
+		{
+			name: "file",
+			file: secretFile,
+			want: "from-file",
+		},
+		{
+			name:    "command",
+			command: []string{"echo", "from-command"},
+			want:    "from-command",
+		},
+		{
+			name:    "value_and_file",
+			value:   "inline-secret",
+			file:    secretFile,
+			wantErr: true,
+			errMsg:  "password, password file and password command are mutually exclusive",
+		},
+		{
+			name:    "file_and_command",
+			file:    secretFile,
+			command: []string{"echo", "from-command"},
+			wantErr: true,
+			errMsg:  "mutually exclusive",
+		},
+		{
+			name:    "missing_file",
+			file:    filepath.Join(dir, "missing"),
+			wantErr: true,
+			errMsg:  "cannot read password file",
+		},
+		{
+			name:    "empty_file",
+			file:    emptyFile,
+			wantErr: true,
+			errMsg:  "is empty",
+		},
+		{
+			name:    "failing_command",
+			command: []string{"sh", "-c", "echo vault is sealed >&2; exit 3"},
+			wantErr: true,
+			errMsg:  `password command "sh" failed: exit status 3: vault is sealed`,
+		},
+		{
+			name:    "silent_command",
+			command: []string{"true"},
+			wantErr: true,
+			errMsg:  `password command "true" printed nothing`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSecret(context.Background(), "password", tt.value, tt.file, tt.command)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("resolveSecret() expected error but got none")
+					return
+				}
+				if !contains(err.Error(), tt.errMsg) {
+					t.Errorf("resolveSecret() error = %v, expected to contain %v", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveSecret() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveSecret() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestConnector_SecretSource_Unit tests that the secret of the login is read again for every new connection
+func TestConnector_SecretSource_Unit(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "password")
+
+	connector := &Connector{
+		Host:           "test.database.windows.net",
+		Database:       "testdb",
+		LocalUserLogin: &LocalUserLogin{Username: "user", PasswordFile: secretFile},
+	}
+
+	driverConnector, err := connector.connector()
+	if err != nil {
+		t.Fatalf("connector() unexpected error = %v", err)
+	}
+	secret, ok := driverConnector.(*secretConnector)
+	if !ok {
+		t.Fatalf("connector() = %T, want *secretConnector", driverConnector)
+	}
+	if _, ok := secret.Driver().(*mssql.Driver); !ok {
+		t.Errorf("Driver() = %T, want *mssql.Driver", secret.Driver())
+	}
+
+	// The file does not exist yet: the error is reported when a connection is opened.
+	if _, err := secret.Connect(context.Background()); err == nil || !contains(err.Error(), "cannot read password file") {
+		t.Errorf("Connect() error = %v, expected to contain cannot read password file", err)
+	}
+
+	// Building the authenticated connector must leave the shared connection string untouched.
+	if err := os.WriteFile(secretFile, []byte("rotated"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		connectionString := *secret.connectionString
+		if _, err := connector.authenticatedConnector(context.Background(), &connectionString, secret.query); err != nil {
+			t.Fatalf("authenticatedConnector() unexpected error = %v", err)
+		}
+		if secret.connectionString.User != nil {
+			t.Errorf("authenticatedConnector() modified the shared connection string")
+		}
+		if password, _ := connectionString.User.Password(); password != "rotated" {
+			t.Errorf("password = %q, want rotated", password)
+		}
+	}
+}
+
+// TestConnector_HasSecretSource_Unit tests the logins reading their secret from a file or a command
+func TestConnector_HasSecretSource_Unit(t *testing.T) {
+	tests := []struct {
+		name      string
+		connector *Connector
+		want      bool
+	}{
+		{
+			name:      "inline_password",
+			connector: &Connector{LocalUserLogin: &LocalUserLogin{Username: "user", Password: "pass"}},
+			want:      false,
+		},
+		{
+			name:      "password_command",
+			connector: &Connector{LocalUserLogin: &LocalUserLogin{Username: "user", PasswordCommand: []string{"vault", "read"}}},
+			want:      true,
+		},
+		{
+			name:      "client_secret_file",
+			connector: &Connector{AzureApplicationLogin: &AzureApplicationLogin{ClientId: "client", ClientSecretFile: "/run/secrets/client"}},
+			want:      true,
+		},
+		{
+			name:      "access_token",
+			connector: &Connector{AccessTokenLogin: &AccessTokenLogin{Token: "token"}},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.connector.hasSecretSource(); got != tt.want {
+				t.Errorf("hasSecretSource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"terraform-provider-mssqlpermissions/internal/queries/model"
+)
+
+// engineEditionNames maps the SERVERPROPERTY('EngineEdition') values to the product they identify.
+var engineEditionNames = map[int64]string{
+	1:  "Personal",
+	2:  "Standard",
+	3:  "Enterprise",
+	4:  "Express",
+	5:  "Azure SQL Database",
+	6:  "Azure Synapse Analytics",
+	8:  "Azure SQL Managed Instance",
+	9:  "Azure SQL Edge",
+	11: "Azure Synapse serverless SQL pool",
+	12: "SQL database in Microsoft Fabric",
+}
+
+// GetServerInfo retrieves the version, edition and settings of the server and database the connector is
+// connected to, along with the login and user of the connection.
+func (c *Connector) GetServerInfo(ctx context.Context, db *sql.DB) (*model.ServerInfo, error) {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
+	var err error
+
+	type ServerProperties struct {
+		ServerName                      sql.NullString
+		Version                         string
+		ProductVersion                  string
+		Edition                         string
+		EngineEdition                   int64
+		DatabaseName                    string
+		Containment                     string
+		CompatibilityLevel              int64
+		Collation                       sql.NullString
+		DefaultLanguage                 sql.NullString
+		ContainedDatabaseAuthentication sql.NullBool
+		LoginName                       string
+		UserName                        string
+	}
+
+	var result ServerProperties
+
+	// Check if the database connection is nil.
+	if err := c.validateDatabaseConnection(ctx, db); err != nil {
+		return nil, err
+	}
+
+	// SQL query to retrieve the server properties and the settings of the current database.
+	query := `SELECT CAST(SERVERPROPERTY('ServerName') AS nvarchar(128)), @@VERSION,
+				CAST(SERVERPROPERTY('ProductVersion') AS nvarchar(128)), CAST(SERVERPROPERTY('Edition') AS nvarchar(128)),
+				CAST(SERVERPROPERTY('EngineEdition') AS int),
+				d.[name], d.[containment_desc], d.[compatibility_level], d.[collation_name],
+				(SELECT lang.[name] FROM [sys].[configurations] config INNER JOIN [sys].[syslanguages] lang ON config.[value] = lang.[langid] WHERE config.[name] = 'default language'),
+				(SELECT CAST([value_in_use] AS bit) FROM [sys].[configurations] WHERE [name] = 'contained database authentication'),
+				SUSER_SNAME(), USER_NAME()
+				FROM [sys].[databases] d
+				WHERE d.[database_id] = DB_ID()`
+
+	// Execute query
+	row := c.queryRowContext(ctx, db, query)
+
+	// Populate the result object with the result of the query.
+	err = row.Scan(
+		&result.ServerName,
+		&result.Version,
+		&result.ProductVersion,
+		&result.Edition,
+		&result.EngineEdition,
+		&result.DatabaseName,
+		&result.Containment,
+		&result.CompatibilityLevel,
+		&result.Collation,
+		&result.DefaultLanguage,
+		&result.ContainedDatabaseAuthentication,
+		&result.LoginName,
+		&result.UserName)
+
+	// Check if the current database is not visible.
+	if err == sql.ErrNoRows {
+		return nil, errors.New("current database not found")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot retrieve server information. Underlying sql error : %w", err)
+	}
+
+	major, minor, build, err := parseProductVersion(result.ProductVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &model.ServerInfo{
+		ServerName:                      result.ServerName.String,
+		Version:                         result.Version,
+		ProductVersion:                  result.ProductVersion,
+		MajorVersion:                    major,
+		MinorVersion:                    minor,
+		BuildNumber:                     build,
+		Edition:                         result.Edition,
+		EngineEdition:                   result.EngineEdition,
+		EngineEditionName:               engineEditionName(result.EngineEdition),
+		IsAzure:                         strings.Contains(result.Version, "Microsoft SQL Azure"),
+		DatabaseName:                    result.DatabaseName,
+		Containment:                     result.Containment,
+		CompatibilityLevel:              result.CompatibilityLevel,
+		Collation:                       result.Collation.String,
+		DefaultLanguage:                 result.DefaultLanguage.String,
+		ContainedDatabaseAuthentication: result.ContainedDatabaseAuthentication.Bool,
+		LoginName:                       result.LoginName,
+		UserName:                        result.UserName,
+	}
+
+	// Mirror CreateUser and UpdateUser: only the contained users of a SQL Server database have a default language.
+	info.DefaultLanguageSupported = !info.IsAzure && info.Containment == "PARTIAL"
+
+	return info, nil
+}
+
+// parseProductVersion splits a product version such as 16.0.4135.4 into its major, minor and build numbers.
+func parseProductVersion(version string) (major, minor, build int64, err error) {
+	parts := strings.Split(version, ".")
+	if len(parts) < 3 {
+		return 0, 0, 0, fmt.Errorf("invalid product version %q", version)
+	}
+
+	numbers := make([]int64, 3)
+	for i := range numbers {
+		numbers[i], err = strconv.ParseInt(parts[i], 10, 64)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid product version %q", version)
+		}
+	}
+
+	return numbers[0], numbers[1], numbers[2], nil
+}
+
+// engineEditionName returns the product identified by an engine edition, or "Unknown".
+func engineEditionName(engineEdition int64) string {
+	if name, ok := engineEditionNames[engineEdition]; ok {
+		return name
+	}
+	return "Unknown"
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+//go:build integration
+
+package queries
+
+import (
+	"context"
+	"testing"
+)
+
+func TestConnector_GetServerInfo(t *testing.T) {
+	if !runLocalTests {
+		t.Skip("The server information is tested on the local SQL Server")
+	}
+
+	connector := testConnectors.localSQL
+	ctx := context.Background()
+	db, err := connector.Connect()
+	if err != nil {
+		t.Fatalf("Unable to connect: %v", err)
+	}
+
+	info, err := connector.GetServerInfo(ctx, db)
+	if err != nil {
+		t.Fatalf("GetServerInfo() error = %v", err)
+	}
+
+	if info.MajorVersion < 11 {
+		t.Errorf("GetServerInfo() MajorVersion = %d, want a supported SQL Server version", info.MajorVersion)
+	}
+	if info.IsAzure || info.EngineEdition == engineEditionAzureSQLDatabase {
+		t.Errorf("GetServerInfo() = %+v, want an on-premises SQL Server", info)
+	}
+	if info.DatabaseName != connector.Database {
+		t.Errorf("GetServerInfo() DatabaseName = %s, want %s", info.DatabaseName, connector.Database)
+	}
+	if info.CompatibilityLevel == 0 || info.Collation == "" || info.DefaultLanguage == "" {
+		t.Errorf("GetServerInfo() = %+v, want the database settings", info)
+	}
+	if info.LoginName != localTestConf.username {
+		t.Errorf("GetServerInfo() LoginName = %s, want %s", info.LoginName, localTestConf.username)
+	}
+}
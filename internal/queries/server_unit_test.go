@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// SERVER INFORMATION UNIT TESTS
+// ============================================================================
+
+// TestParseProductVersion_Unit tests the parsing of the SERVERPROPERTY('ProductVersion')
+func TestParseProductVersion_Unit(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		wantMajor int64
+		wantMinor int64
+		wantBuild int64
+		wantErr   bool
+	}{
+		{name: "sql_server_2022", version: "16.0.4135.4", wantMajor: 16, wantMinor: 0, wantBuild: 4135},
+		{name: "sql_server_2014", version: "12.0.6024.0", wantMajor: 12, wantMinor: 0, wantBuild: 6024},
+		{name: "without_revision", version: "15.0.2000", wantMajor: 15, wantMinor: 0, wantBuild: 2000},
+		{name: "empty", version: "", wantErr: true},
+		{name: "too_short", version: "16.0", wantErr: true},
+		{name: "not_a_number", version: "16.x.4135.4", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			major, minor, build, err := parseProductVersion(tt.version)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("parseProductVersion() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseProductVersion() unexpected error = %v", err)
+			}
+			if major != tt.wantMajor || minor != tt.wantMinor || build != tt.wantBuild {
+				t.Errorf("parseProductVersion() = %d, %d, %d, want %d, %d, %d", major, minor, build, tt.wantMajor, tt.wantMinor, tt.wantBuild)
+			}
+		})
+	}
+}
+
+// TestEngineEditionName_Unit tests the names of the engine editions
+func TestEngineEditionName_Unit(t *testing.T) {
+	tests := []struct {
+		engineEdition int64
+		want          string
+	}{
+		{engineEdition: 3, want: "Enterprise"},
+		{engineEdition: engineEditionAzureSQLDatabase, want: "Azure SQL Database"},
+		{engineEdition: 8, want: "Azure SQL Managed Instance"},
+		{engineEdition: 42, want: "Unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := engineEditionName(tt.engineEdition); got != tt.want {
+			t.Errorf("engineEditionName(%d) = %q, want %q", tt.engineEdition, got, tt.want)
+		}
+	}
+}
+
+// TestGetServerInfo_NilDatabase_Unit tests that a nil database connection is rejected
+func TestGetServerInfo_NilDatabase_Unit(t *testing.T) {
+	connector := &Connector{}
+
+	_, err := connector.GetServerInfo(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "database connection is nil") {
+		t.Errorf("GetServerInfo() error = %v, want a nil connection error", err)
+	}
+}
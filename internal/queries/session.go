@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	mssql "github.com/microsoft/go-mssqldb"
+)
+
+// DefaultApplicationName is the application name of the sessions when the connector sets none.
+const DefaultApplicationName = "terraform-sql-provider"
+
+// Length limits of the session metadata, in characters. The application name, the workstation id and
+// the session context keys are sysname; a session context value is stored as an nvarchar(4000).
+const (
+	maxSessionNameLength         = 128
+	maxSessionContextValueLength = 4000
+)
+
+// validateSession checks the application name, the workstation id and the session context against the
+// limits of SQL Server.
+func (c *Connector) validateSession() error {
+	if utf8.RuneCountInString(c.ApplicationName) > maxSessionNameLength {
+		return fmt.Errorf("application name is longer than %d characters", maxSessionNameLength)
+	}
+	if utf8.RuneCountInString(c.WorkstationID) > maxSessionNameLength {
+		return fmt.Errorf("workstation id is longer than %d characters", maxSessionNameLength)
+	}
+	for key, value := range c.SessionContext {
+		if key == "" {
+			return fmt.Errorf("session context keys cannot be empty")
+		}
+		if utf8.RuneCountInString(key) > maxSessionNameLength {
+			return fmt.Errorf("session context key %q is longer than %d characters", key, maxSessionNameLength)
+		}
+		if utf8.RuneCountInString(value) > maxSessionContextValueLength {
+			return fmt.Errorf("session context value of %q is longer than %d characters", key, maxSessionContextValueLength)
+		}
+	}
+	return nil
+}
+
+// applySession sets the application name and workstation id reported by the sessions, which SQL Audit and
+// Extended Events record along with every statement.
+func (c *Connector) applySession(query url.Values) {
+	applicationName := c.ApplicationName
+	if applicationName == "" {
+		applicationName = DefaultApplicationName
+	}
+	query.Add("app name", applicationName)
+
+	if c.WorkstationID != "" {
+		query.Add("workstation id", c.WorkstationID)
+	}
+}
+
+// sessionInitSQL returns the batch writing the SessionContext of the connector with sp_set_session_context,
+// ordered by key, or an empty string when there is none.
+func (c *Connector) sessionInitSQL() string {
+	keys := make([]string, 0, len(c.SessionContext))
+	for key := range c.SessionContext {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var batch strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&batch, "EXEC sys.sp_set_session_context @key = N'%s', @value = N'%s';\n",
+			strings.ReplaceAll(key, "'", "''"), strings.ReplaceAll(c.SessionContext[key], "'", "''"))
+	}
+	return batch.String()
+}
+
+// withSessionContext makes the driver write the session context on every new connection, and again every
+// time a pooled connection is reset before being reused, as the reset clears it.
+func (c *Connector) withSessionContext(connector driver.Connector, err error) (driver.Connector, error) {
+	if err != nil {
+		return nil, err
+	}
+	if mssqlConnector, ok := connector.(*mssql.Connector); ok {
+		mssqlConnector.SessionInitSQL = c.sessionInitSQL()
+	}
+	return connector, nil
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+//go:build integration
+
+package queries
+
+import (
+	"context"
+	"testing"
+)
+
+func TestConnector_Session(t *testing.T) {
+	if !runLocalTests {
+		t.Skip("The session metadata is tested on the local SQL Server")
+	}
+
+	local := testConnectors.localSQL
+	connector := &Connector{
+		Host:            local.Host,
+		Port:            local.Port,
+		Database:        local.Database,
+		LocalUserLogin:  local.LocalUserLogin,
+		TLS:             local.TLS,
+		ApplicationName: "terraform-session-test",
+		SessionContext:  map[string]string{"run_id": "1234", "git_sha": "0c1d2e3"},
+		MaxOpenConns:    1,
+	}
+	defer connector.Close()
+
+	ctx := context.Background()
+	db, err := connector.Connect()
+	if err != nil {
+		t.Fatalf("Unable to connect: %v", err)
+	}
+
+	// The pooled connection is reset before each query, which must not lose the session context.
+	for i := 0; i < 2; i++ {
+		var applicationName, runID string
+		err := db.QueryRowContext(ctx, "SELECT APP_NAME(), CAST(SESSION_CONTEXT(N'run_id') AS nvarchar(128))").Scan(&applicationName, &runID)
+		if err != nil {
+			t.Fatalf("cannot read the session metadata: %v", err)
+		}
+		if applicationName != "terraform-session-test" {
+			t.Errorf("APP_NAME() = %s, want terraform-session-test", applicationName)
+		}
+		if runID != "1234" {
+			t.Errorf("SESSION_CONTEXT(N'run_id') = %s, want 1234", runID)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	mssql "github.com/microsoft/go-mssqldb"
+)
+
+// ============================================================================
+// SESSION METADATA UNIT TESTS
+// ============================================================================
+
+// TestConnector_ApplySession_Unit tests the application name and workstation id of the sessions
+func TestConnector_ApplySession_Unit(t *testing.T) {
+	tests := []struct {
+		name      string
+		connector *Connector
+		want      url.Values
+	}{
+		{
+			name:      "defaults",
+			connector: &Connector{},
+			want:      url.Values{"app name": {DefaultApplicationName}},
+		},
+		{
+			name:      "application_name",
+			connector: &Connector{ApplicationName: "terraform/production"},
+			want:      url.Values{"app name": {"terraform/production"}},
+		},
+		{
+			name:      "workstation_id",
+			connector: &Connector{WorkstationID: "runner-42"},
+			want:      url.Values{"app name": {DefaultApplicationName}, "workstation id": {"runner-42"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{}
+			tt.connector.applySession(query)
+
+			if query.Encode() != tt.want.Encode() {
+				t.Errorf("applySession() = %v, want %v", query.Encode(), tt.want.Encode())
+			}
+		})
+	}
+}
+
+// TestConnector_SessionInitSQL_Unit tests the batch writing the session context
+func TestConnector_SessionInitSQL_Unit(t *testing.T) {
+	if got := (&Connector{}).sessionInitSQL(); got != "" {
+		t.Errorf("sessionInitSQL() = %q, want an empty batch", got)
+	}
+
+	connector := &Connector{SessionContext: map[string]string{
+		"run_id":  "1234",
+		"git_sha": "0c1d2e3",
+		"author":  "O'Brien",
+	}}
+	want := "EXEC sys.sp_set_session_context @key = N'author', @value = N'O''Brien';\n" +
+		"EXEC sys.sp_set_session_context @key = N'git_sha', @value = N'0c1d2e3';\n" +
+		"EXEC sys.sp_set_session_context @key = N'run_id', @value = N'1234';\n"
+
+	if got := connector.sessionInitSQL(); got != want {
+		t.Errorf("sessionInitSQL() = %q, want %q", got, want)
+	}
+}
+
+// TestConnector_ValidateSession_Unit tests the limits of the session metadata
+func TestConnector_ValidateSession_Unit(t *testing.T) {
+	tests := []struct {
+		name      string
+		connector *Connector
+		wantErr   bool
+		errMsg    string
+	}{
+		{
+			name:      "valid",
+			connector: &Connector{ApplicationName: "terraform", WorkstationID: "runner", SessionContext: map[string]string{"run_id": "1234"}},
+			wantErr:   false,
+		},
+		{
+			name:      "application_name_too_long",
+			connector: &Connector{ApplicationName: strings.Repeat("a", 129)},
+			wantErr:   true,
+			errMsg:    "application name is longer than 128 characters",
+		},
+		{
+			name:      "workstation_id_too_long",
+			connector: &Connector{WorkstationID: strings.Repeat("w", 129)},
+			wantErr:   true,
+			errMsg:    "workstation id is longer than 128 characters",
+		},
+		{
+			name:      "empty_session_context_key",
+			connector: &Connector{SessionContext: map[string]string{"": "value"}},
+			wantErr:   true,
+			errMsg:    "session context keys cannot be empty",
+		},
+		{
+			name:      "session_context_value_too_long",
+			connector: &Connector{SessionContext: map[string]string{"plan": strings.Repeat("v", 4001)}},
+			wantErr:   true,
+			errMsg:    `session context value of "plan" is longer than 4000 characters`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.connector.validateSession()
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("validateSession() expected error but got none")
+					return
+				}
+				if !contains(err.Error(), tt.errMsg) {
+					t.Errorf("validateSession() error = %v, expected to contain %v", err, tt.errMsg)
+				}
+			} else if err != nil {
+				t.Errorf("validateSession() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+// TestConnector_SessionContext_Unit tests that every driver connector writes the session context
+func TestConnector_SessionContext_Unit(t *testing.T) {
+	sessionContext := map[string]string{"run_id": "1234"}
+
+	tests := []struct {
+		name      string
+		connector *Connector
+	}{
+		{
+			name:      "sql_login",
+			connector: &Connector{LocalUserLogin: &LocalUserLogin{Username: "user", Password: "pass"}},
+		},
+		{
+			name:      "entra_login",
+			connector: &Connector{EntraLogin: &EntraLogin{Method: ActiveDirectoryDefault}},
+		},
+		{
+			name:      "access_token",
+			connector: &Connector{AccessTokenLogin: &AccessTokenLogin{Token: "token"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.connector.Host = "test.database.windows.net"
+			tt.connector.Database = "testdb"
+			tt.connector.SessionContext = sessionContext
+
+			driverConnector, err := tt.connector.connector()
+			if err != nil {
+				t.Fatalf("connector() unexpected error = %v", err)
+			}
+
+			mssqlConnector, ok := driverConnector.(*mssql.Connector)
+			if !ok {
+				t.Fatalf("connector() = %T, want *mssql.Connector", driverConnector)
+			}
+			if mssqlConnector.SessionInitSQL != tt.connector.sessionInitSQL() {
+				t.Errorf("SessionInitSQL = %q, want %q", mssqlConnector.SessionInitSQL, tt.connector.sessionInitSQL())
+			}
+		})
+	}
+}
@@ -10,12 +10,14 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	mssql "github.com/microsoft/go-mssqldb"
-	azuread "github.com/microsoft/go-mssqldb/azuread"
 )
 
 type FedAuth string
@@ -28,6 +30,9 @@ func (f FedAuth) String() string {
 const (
 	defaultTimeout = 30 * time.Second
 
+	// engineEditionAzureSQLDatabase is the SERVERPROPERTY('EngineEdition') of Azure SQL Database.
+	engineEditionAzureSQLDatabase = 5
+
 	// Represents different authentication options.
 	ActiveDirectoryServicePrincipal FedAuth = "ActiveDirectoryServicePrincipal"
 	ActiveDirectoryApplication      FedAuth = "ActiveDirectoryApplication"
@@ -43,25 +48,92 @@ const (
 // Connector holds the configuration and authentication details required to connect to a SQL Server instance.
 // It supports multiple authentication methods including local user login, Azure application login, and managed identity login.
 // The struct also includes metadata about the target database, such as whether it is an Azure or contained database,
-// as well as connection parameters like host, port, database name, timeout, and default language.
+// as well as connection parameters like host, port, database name, timeouts, and default language.
+//
+// Timeout bounds opening the pool and dialing every new connection, and defaults to 30 seconds.
+// StatementTimeout bounds every call of the query methods; zero leaves them bounded only by their context.
+//
+// A Connector owns a single connection pool. The pool is opened by the first call to Connect, shared by
+// every caller afterwards and released by Close. A Connector must not be copied after first use.
 type Connector struct {
 	Host                  string
 	Port                  int
 	Database              string
 	Timeout               time.Duration
+	StatementTimeout      time.Duration
 	LocalUserLogin        *LocalUserLogin
 	AzureApplicationLogin *AzureApplicationLogin
 	ManagedIdentityLogin  *ManagedIdentityLogin
-	isAzureDatabase       bool
-	defaultLanguage       string
+	FederatedLogin        *FederatedLogin
+	EntraLogin            *EntraLogin
+	AccessTokenLogin      *AccessTokenLogin
+	TLS                   *TLSConfig
+
+	// Named instance and high availability settings. InstanceName is resolved by the SQL Server Browser
+	// service when Port is zero. FailoverPartner is the mirroring partner, as "host" or "host:port".
+	// MultiSubnetFailover keeps the driver default, enabled, when nil. ReadOnlyIntent declares a read-only
+	// application intent, routed to a readable secondary replica by an availability group listener.
+	InstanceName        string
+	FailoverPartner     string
+	MultiSubnetFailover *bool
+	ReadOnlyIntent      bool
+
+	// Session metadata recorded by SQL Audit and Extended Events. ApplicationName defaults to
+	// DefaultApplicationName. SessionContext is written with sp_set_session_context on every session.
+	ApplicationName string
+	WorkstationID   string
+	SessionContext  map[string]string
+
+	// ExecuteAsUser is the database user impersonated by the mutating statements, between EXECUTE AS USER
+	// and REVERT. The statements run as the connecting principal when empty. It is only kept by the
+	// connectors to the same database: ForDatabase connectors to other databases run as the connecting principal.
+	ExecuteAsUser string
+
+	// Connection pool limits. Zero values keep the database/sql defaults.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+
+	// Retry policy of the statements failing with a transient error. Zero values keep the defaults;
+	// a negative MaxRetries disables retries.
+	MaxRetries      int
+	RetryMinBackoff time.Duration
+	RetryMaxBackoff time.Duration
+
+	isAzureDatabase     bool
+	isContainedDatabase bool
+	engineEdition       int
+	defaultLanguage     string
+
+	mu       sync.Mutex
+	db       *sql.DB
+	siblings map[siblingKey]*Connector
+}
+
+// siblingKey identifies a connector returned by ForDatabase or ReadOnly.
+type siblingKey struct {
+	database       string
+	readOnlyIntent bool
 }
 
+// openConnectors tracks the connectors holding an open pool so they can be released on shutdown.
+var openConnectors = struct {
+	sync.Mutex
+	set map[*Connector]struct{}
+}{set: map[*Connector]struct{}{}}
+
 // AzureApplicationLogin represents Azure Active Directory application login details.
+// The application authenticates either with a client secret or with a PFX/PEM client certificate,
+// read from ClientCertificatePath or given inline as base64 in ClientCertificate. The client secret is
+// given inline, or read from ClientSecretFile or ClientSecretCommand for every new connection.
 type AzureApplicationLogin struct {
-	ClientCertificatePath     string // TODO: implement certificate
-	ClientCertificatePassword string // TODO: implement certificate
+	ClientCertificatePath     string
+	ClientCertificate         string
+	ClientCertificatePassword string
 	ClientId                  string
 	ClientSecret              string
+	ClientSecretFile          string
+	ClientSecretCommand       []string
 	TenantId                  string
 }
 
@@ -72,10 +144,48 @@ type ManagedIdentityLogin struct {
 	ResourceId   string
 }
 
+// FederatedLogin represents workload identity federation login details.
+// The OIDC token issued by the CI platform is exchanged for an Entra ID access token. It is either given
+// inline in OIDCToken or read from OIDCTokenFilePath every time a new access token is requested.
+type FederatedLogin struct {
+	ClientId          string
+	TenantId          string
+	OIDCToken         string
+	OIDCTokenFilePath string
+}
+
+// EntraLogin represents a Microsoft Entra ID login using any of the declared FedAuth modes.
+// Which of the optional fields are used depends on Method:
+//   - ActiveDirectoryServicePrincipal, ActiveDirectoryApplication: ClientId, ClientSecret and TenantId.
+//   - ActiveDirectoryPassword: ClientId, Username and Password.
+//   - ActiveDirectoryManagedIdentity, ActiveDirectoryMSI: ClientId or ResourceId of a user-assigned identity.
+//   - ActiveDirectoryInteractive: ClientId, and Username as a login hint.
+//   - ActiveDirectoryDeviceCode: ClientId.
+//   - ActiveDirectoryDefault, ActiveDirectoryAzCli: none.
+type EntraLogin struct {
+	Method       FedAuth
+	ClientId     string
+	TenantId     string
+	ClientSecret string
+	Username     string
+	Password     string
+	ResourceId   string
+}
+
+// AccessTokenLogin represents a pre-acquired Entra ID access token, given inline in Token
+// or read from TokenFilePath every time a new connection is opened.
+type AccessTokenLogin struct {
+	Token         string
+	TokenFilePath string
+}
+
 // LocalUserLogin represents local user login details.
+// The password is given inline, or read from PasswordFile or PasswordCommand for every new connection.
 type LocalUserLogin struct {
-	Username string
-	Password string
+	Username        string
+	Password        string
+	PasswordFile    string
+	PasswordCommand []string
 }
 
 // connector returns a driver.Connector based on the specified authentication method.
@@ -87,27 +197,75 @@ func (c *Connector) connector() (driver.Connector, error) {
 
 	connectionString := &url.URL{
 		Scheme: "sqlserver",
-		Host:   fmt.Sprintf("%s:%d", c.Host, c.Port),
+		Host:   c.Host,
+		Path:   c.InstanceName,
+	}
+	if c.Port != 0 {
+		connectionString.Host = fmt.Sprintf("%s:%d", c.Host, c.Port)
 	}
 
 	query := url.Values{}
 	query.Add("database", c.Database)
-	query.Add("app name", "terraform-sql-provider")
+	c.applySession(query)
+	if c.Timeout > 0 {
+		// The driver expects whole seconds; round up so that a sub-second timeout is not disabled.
+		// "connection timeout" is not used: the driver applies it to every read, long statements included.
+		query.Add("dial timeout", strconv.Itoa(int((c.Timeout+time.Second-1)/time.Second)))
+	}
+	if c.TLS != nil {
+		c.TLS.apply(query)
+	}
+	c.applyHighAvailability(query)
 
+	if c.hasSecretSource() {
+		return &secretConnector{connector: c, connectionString: connectionString, query: query}, nil
+	}
+	return c.withSessionContext(c.authenticatedConnector(context.Background(), connectionString, query))
+}
+
+// authenticatedConnector returns a driver.Connector to connectionString with the authentication method of the connector.
+func (c *Connector) authenticatedConnector(ctx context.Context, connectionString *url.URL, query url.Values) (driver.Connector, error) {
 	// Determine the authentication method and construct the connection string accordingly
 	switch {
 	case c.LocalUserLogin != nil:
-		connectionString.User = url.UserPassword(c.LocalUserLogin.Username, c.LocalUserLogin.Password)
+		password, err := c.LocalUserLogin.password(ctx)
+		if err != nil {
+			return nil, err
+		}
+		connectionString.User = url.UserPassword(c.LocalUserLogin.Username, password)
 		connectionString.RawQuery = query.Encode()
 		return mssql.NewConnector(connectionString.String())
 	case c.AzureApplicationLogin != nil:
-		return c.configureAzureADConnector(connectionString, query)
+		return c.configureAzureADConnector(ctx, connectionString, query)
 	case c.ManagedIdentityLogin != nil:
 		return c.configureManagedIdentityConnector(connectionString, query)
+	case c.FederatedLogin != nil:
+		return c.configureFederatedConnector(connectionString, query)
+	case c.EntraLogin != nil:
+		return c.configureEntraConnector(connectionString, query)
+	case c.AccessTokenLogin != nil:
+		return c.configureAccessTokenConnector(connectionString, query)
 	default:
-		query.Add("fedauth", ActiveDirectoryDefault.String())
 		connectionString.RawQuery = query.Encode()
-		return azuread.NewConnector(connectionString.String())
+		return newEntraConnector(connectionString.String(), defaultIdentity())
+	}
+}
+
+// applyHighAvailability sets the connection string parameters of the high availability settings.
+func (c *Connector) applyHighAvailability(query url.Values) {
+	if c.FailoverPartner != "" {
+		if host, port, err := net.SplitHostPort(c.FailoverPartner); err == nil {
+			query.Add("failoverpartner", host)
+			query.Add("failoverport", port)
+		} else {
+			query.Add("failoverpartner", c.FailoverPartner)
+		}
+	}
+	if c.MultiSubnetFailover != nil {
+		query.Add("multisubnetfailover", strconv.FormatBool(*c.MultiSubnetFailover))
+	}
+	if c.ReadOnlyIntent {
+		query.Add("applicationintent", "ReadOnly")
 	}
 }
 
@@ -119,41 +277,86 @@ func (c *Connector) validate() error {
 	if c.Database == "" {
 		return errors.New("missing database name")
 	}
-	if c.Port == 0 {
+	// A named instance without a port is resolved by the SQL Server Browser service.
+	if c.Port == 0 && c.InstanceName == "" {
 		c.Port = 1433
 	}
-	return nil
+	if c.FailoverPartner != "" {
+		if _, port, err := net.SplitHostPort(c.FailoverPartner); err == nil {
+			if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+				return fmt.Errorf("invalid failover partner port %q", port)
+			}
+		}
+	}
+	if c.TLS != nil {
+		if err := c.TLS.validate(); err != nil {
+			return fmt.Errorf("invalid TLS configuration: %w", err)
+		}
+	}
+	return c.validateSession()
 }
 
-// configureAzureADConnector configures the connection string for Azure AD authentication.
-func (c *Connector) configureAzureADConnector(connectionString *url.URL, query url.Values) (driver.Connector, error) {
-	userId := c.AzureApplicationLogin.ClientId
-	if c.AzureApplicationLogin.TenantId != "" {
-		userId = fmt.Sprintf("%s@%s", c.AzureApplicationLogin.ClientId, c.AzureApplicationLogin.TenantId)
+// configureAzureADConnector configures the connection for a service principal, authenticating with
+// a client certificate or a client secret.
+func (c *Connector) configureAzureADConnector(ctx context.Context, connectionString *url.URL, query url.Values) (driver.Connector, error) {
+	if err := c.AzureApplicationLogin.validate(); err != nil {
+		return nil, err
+	}
+
+	var clientSecret string
+	if !c.AzureApplicationLogin.hasClientCertificate() {
+		var err error
+		clientSecret, err = c.AzureApplicationLogin.clientSecret(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	identity, err := c.AzureApplicationLogin.identity(clientSecret)
+	if err != nil {
+		return nil, err
 	}
 
-	query.Add("fedauth", ActiveDirectoryServicePrincipal.String())
-	query.Add("user id", userId)
-	query.Add("password", c.AzureApplicationLogin.ClientSecret)
 	connectionString.RawQuery = query.Encode()
-	return azuread.NewConnector(connectionString.String())
+	return newEntraConnector(connectionString.String(), identity)
 }
 
-// configureManagedIdentityConnector configures the connection string for Managed Identity authentication.
+// configureManagedIdentityConnector configures the connection for Managed Identity authentication.
 func (c *Connector) configureManagedIdentityConnector(connectionString *url.URL, query url.Values) (driver.Connector, error) {
-	query.Add("fedauth", ActiveDirectoryManagedIdentity.String())
+	connectionString.RawQuery = query.Encode()
+	return newEntraConnector(connectionString.String(), c.ManagedIdentityLogin.identity())
+}
 
-	if c.ManagedIdentityLogin.UserIdentity && (c.ManagedIdentityLogin.UserId != "" || c.ManagedIdentityLogin.ResourceId != "") {
-		if c.ManagedIdentityLogin.UserId != "" {
-			query.Add("user id", c.ManagedIdentityLogin.UserId)
-		}
-		if c.ManagedIdentityLogin.ResourceId != "" {
-			query.Add("resource id", c.ManagedIdentityLogin.ResourceId)
-		}
+// configureFederatedConnector configures the connection for workload identity federation.
+func (c *Connector) configureFederatedConnector(connectionString *url.URL, query url.Values) (driver.Connector, error) {
+	identity, err := c.FederatedLogin.identity()
+	if err != nil {
+		return nil, err
+	}
+
+	connectionString.RawQuery = query.Encode()
+	return newEntraConnector(connectionString.String(), identity)
+}
+
+// configureEntraConnector configures the connection for the FedAuth mode selected in the Entra login.
+func (c *Connector) configureEntraConnector(connectionString *url.URL, query url.Values) (driver.Connector, error) {
+	identity, err := c.EntraLogin.identity()
+	if err != nil {
+		return nil, err
 	}
 
 	connectionString.RawQuery = query.Encode()
-	return azuread.NewConnector(connectionString.String())
+	return newEntraConnector(connectionString.String(), identity)
+}
+
+// configureAccessTokenConnector configures the connection to authenticate with a pre-acquired access token.
+func (c *Connector) configureAccessTokenConnector(connectionString *url.URL, query url.Values) (driver.Connector, error) {
+	if err := c.AccessTokenLogin.validate(); err != nil {
+		return nil, err
+	}
+
+	connectionString.RawQuery = query.Encode()
+	return mssql.NewAccessTokenConnector(connectionString.String(), c.AccessTokenLogin.token)
 }
 
 // getVersion retrieves the version of the connected SQL Server.
@@ -184,6 +387,28 @@ func (c *Connector) getVersion(ctx context.Context, db *sql.DB) (string, error)
 	return version, err
 }
 
+// getEngineEdition retrieves the engine edition of the connected SQL Server.
+func (c *Connector) getEngineEdition(ctx context.Context, db *sql.DB) (int, error) {
+	var engineEdition int
+	var err error
+
+	if db == nil {
+		err = errors.New("connection is null")
+		return 0, err
+	}
+
+	query := "SELECT CAST(SERVERPROPERTY('EngineEdition') AS int)"
+
+	row := db.QueryRowContext(ctx, query)
+	if err = row.Err(); err != nil {
+		return 0, fmt.Errorf("cannot retrieve engine edition: %w", err)
+	}
+
+	err = row.Scan(&engineEdition)
+
+	return engineEdition, err
+}
+
 // getDefaultLanguage retrieves the default language of the connected SQL Server.
 func (c *Connector) getDefaultLanguage(ctx context.Context, db *sql.DB) (string, error) {
 	var defaultLanguage string
@@ -240,14 +465,39 @@ func (c *Connector) containedEnabled(ctx context.Context, db *sql.DB) (bool, err
 	return containedEnabled, err
 }
 
-// Connect establishes a connection to the SQL Server using the configured authentication method.
-// It validates the connection, retrieves server metadata, and ensures database compatibility.
-// Returns a *sql.DB connection and an error if the connection fails or database is incompatible.
+// Connect returns the connection pool of the connector, opening it on first use.
+// The first call validates the connection, retrieves server metadata, and ensures database compatibility.
+// Subsequent calls return the same *sql.DB, which is safe for concurrent use. If opening the pool fails,
+// the next call tries again. The returned pool must not be closed by callers; use Close instead.
 func (c *Connector) Connect() (*sql.DB, error) {
 	if c == nil {
 		return nil, errors.New("no connector provided")
 	}
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.db != nil {
+		return c.db, nil
+	}
+
+	db, err := c.open()
+	if err != nil {
+		return nil, err
+	}
+
+	c.db = db
+
+	openConnectors.Lock()
+	openConnectors.set[c] = struct{}{}
+	openConnectors.Unlock()
+
+	return db, nil
+}
+
+// open creates a new connection pool and loads the server metadata.
+// The pool is closed again if any of the checks fail.
+func (c *Connector) open() (*sql.DB, error) {
 	// Set default timeout if not provided
 	if c.Timeout == 0 {
 		c.Timeout = defaultTimeout
@@ -259,31 +509,62 @@ func (c *Connector) Connect() (*sql.DB, error) {
 	}
 
 	db := sql.OpenDB(driverConnector)
+	c.configurePool(db)
 
 	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
 	defer cancel()
 
-	err = db.PingContext(ctx)
+	if err := c.retry(ctx, func() error { return c.loadMetadata(ctx, db) }); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// configurePool applies the pool limits of the connector to db.
+func (c *Connector) configurePool(db *sql.DB) {
+	if c.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(c.MaxOpenConns)
+	}
+	if c.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(c.MaxIdleConns)
+	}
+	if c.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(c.ConnMaxLifetime)
+	}
+}
+
+// loadMetadata checks the connection and records the server metadata used by the queries.
+func (c *Connector) loadMetadata(ctx context.Context, db *sql.DB) error {
+	err := db.PingContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error connecting to the database: %s", err)
+		return fmt.Errorf("error connecting to the database: %w", err)
 	}
 
 	// Get the Server version and update isAzureDatabase accordingly
 	version, err := c.getVersion(ctx, db)
 
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving the server version: %s", err)
+		return fmt.Errorf("error retrieving the server version: %s", err)
 	}
 
-	if strings.Contains(version, "Microsoft SQL Azure") {
-		c.isAzureDatabase = true
+	c.isAzureDatabase = strings.Contains(version, "Microsoft SQL Azure")
+
+	// Get the engine edition to tell Azure SQL Database and Managed Instance apart
+	engineEdition, err := c.getEngineEdition(ctx, db)
+
+	if err != nil {
+		return fmt.Errorf("error retrieving the engine edition: %s", err)
 	}
 
-	// Get the Server version and update isAzureDatabase accordingly
+	c.engineEdition = engineEdition
+
+	// Get the Server default language
 	defaultLanguage, err := c.getDefaultLanguage(ctx, db)
 
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving the server default language: %s", err)
+		return fmt.Errorf("error retrieving the server default language: %s", err)
 	}
 
 	c.defaultLanguage = defaultLanguage
@@ -292,48 +573,174 @@ func (c *Connector) Connect() (*sql.DB, error) {
 	isContainedDatabase, err := c.containedEnabled(ctx, db)
 
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving the contained status: %s", err)
+		return fmt.Errorf("error retrieving the contained status: %s", err)
 	}
 
-	// This provider only supports contained databases. Return an error if the database is not contained.
-	if !isContainedDatabase && !c.isAzureDatabase {
-		return nil, fmt.Errorf("the target database is not a contained database. This provider only supports contained databases")
+	// Azure SQL Databases always accept contained users. Other databases without contained
+	// database authentication only accept users mapped to a server login.
+	c.isContainedDatabase = isContainedDatabase || c.isAzureDatabase
+
+	return nil
+}
+
+// IsContainedDatabase reports whether the database of c accepts contained users, which is always the case
+// on Azure SQL Database. It is only known once c connected.
+func (c *Connector) IsContainedDatabase() bool {
+	return c.isContainedDatabase
+}
+
+// ForDatabase returns a connector to database sharing the server, authentication and connection
+// settings of c. The connector is created on first use and kept, so that every caller targeting the
+// same database shares its connection pool. It is closed along with c.
+// c itself is returned when database is empty or is the database of c.
+func (c *Connector) ForDatabase(database string) *Connector {
+	if c == nil || database == "" || strings.EqualFold(database, c.Database) {
+		return c
 	}
 
-	return db, nil
+	return c.sibling(database, c.ReadOnlyIntent)
 }
 
-// validateDatabaseConnection validates that the database connection is not nil and is alive.
-// This is a common validation pattern used across all database operations.
-func (c *Connector) validateDatabaseConnection(ctx context.Context, db *sql.DB) error {
-	if db == nil {
-		return errors.New("database connection is nil")
+// ReadOnly returns a connector to the database of c declaring a read-only application intent, with its
+// own connection pool, kept and closed like the connectors returned by ForDatabase.
+// c itself is returned when it already declares a read-only intent.
+func (c *Connector) ReadOnly() *Connector {
+	if c == nil || c.ReadOnlyIntent {
+		return c
 	}
-	if err := db.PingContext(ctx); err != nil {
-		return fmt.Errorf("database ping failed: %w", err)
+
+	return c.sibling(c.Database, true)
+}
+
+// sibling returns the connector to database with the given application intent, creating it on first use.
+func (c *Connector) sibling(database string, readOnlyIntent bool) *Connector {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := siblingKey{database: strings.ToLower(database), readOnlyIntent: readOnlyIntent}
+	if sibling, ok := c.siblings[key]; ok {
+		return sibling
 	}
-	return nil
+
+	sibling := c.derive(database, readOnlyIntent)
+
+	if c.siblings == nil {
+		c.siblings = map[siblingKey]*Connector{}
+	}
+	c.siblings[key] = sibling
+
+	return sibling
 }
 
-// validateDatabaseConnectionWithRetry validates database connection with retry logic.
-// This should be used for query operations that might benefit from retrying on transient failures.
-func (c *Connector) validateDatabaseConnectionWithRetry(ctx context.Context, db *sql.DB, maxRetries int) error {
-	if maxRetries <= 0 {
-		maxRetries = 3
+// derive returns a new connector to database with the given application intent, sharing the server,
+// authentication and connection settings of c.
+func (c *Connector) derive(database string, readOnlyIntent bool) *Connector {
+	connector := &Connector{
+		Host:                  c.Host,
+		Port:                  c.Port,
+		Database:              database,
+		Timeout:               c.Timeout,
+		StatementTimeout:      c.StatementTimeout,
+		LocalUserLogin:        c.LocalUserLogin,
+		AzureApplicationLogin: c.AzureApplicationLogin,
+		ManagedIdentityLogin:  c.ManagedIdentityLogin,
+		FederatedLogin:        c.FederatedLogin,
+		EntraLogin:            c.EntraLogin,
+		AccessTokenLogin:      c.AccessTokenLogin,
+		TLS:                   c.TLS,
+		InstanceName:          c.InstanceName,
+		FailoverPartner:       c.FailoverPartner,
+		MultiSubnetFailover:   c.MultiSubnetFailover,
+		ReadOnlyIntent:        readOnlyIntent,
+		ApplicationName:       c.ApplicationName,
+		WorkstationID:         c.WorkstationID,
+		SessionContext:        c.SessionContext,
+		MaxOpenConns:          c.MaxOpenConns,
+		MaxIdleConns:          c.MaxIdleConns,
+		ConnMaxLifetime:       c.ConnMaxLifetime,
+		MaxRetries:            c.MaxRetries,
+		RetryMinBackoff:       c.RetryMinBackoff,
+		RetryMaxBackoff:       c.RetryMaxBackoff,
+	}
+
+	// The impersonated user belongs to the database of c. It usually does not exist in the other databases,
+	// and in master a database-scoped impersonation could not use server permissions such as ALTER ANY LOGIN.
+	if strings.EqualFold(database, c.Database) {
+		connector.ExecuteAsUser = c.ExecuteAsUser
 	}
 
-	for i := 0; i < maxRetries; i++ {
-		if err := c.validateDatabaseConnection(ctx, db); err == nil {
-			return nil
+	return connector
+}
+
+// Close releases the connection pool of the connector and of the connectors returned by ForDatabase.
+// It is a no-op when no pool is open. The connector can be used again afterwards; the next call to
+// Connect opens a new pool.
+func (c *Connector) Close() error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var errs []error
+	for _, sibling := range c.siblings {
+		if err := sibling.Close(); err != nil {
+			errs = append(errs, err)
 		}
-		if i < maxRetries-1 {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(time.Millisecond * 100 * time.Duration(i+1)):
-				// Continue to next retry
-			}
+	}
+
+	if c.db == nil {
+		return errors.Join(errs...)
+	}
+
+	errs = append(errs, c.db.Close())
+	c.db = nil
+
+	openConnectors.Lock()
+	delete(openConnectors.set, c)
+	openConnectors.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// CloseAll closes the connection pool of every connector opened in this process.
+// It is meant to be called once the provider server has stopped.
+func CloseAll() error {
+	openConnectors.Lock()
+	connectors := make([]*Connector, 0, len(openConnectors.set))
+	for c := range openConnectors.set {
+		connectors = append(connectors, c)
+	}
+	openConnectors.Unlock()
+
+	var errs []error
+	for _, c := range connectors {
+		if err := c.Close(); err != nil {
+			errs = append(errs, err)
 		}
 	}
-	return c.validateDatabaseConnection(ctx, db) // Return the final error
+	return errors.Join(errs...)
+}
+
+// withStatementTimeout returns a context bounded by the statement timeout of the connector.
+// The context is returned unchanged, with a cancel function, when no statement timeout is set.
+func (c *Connector) withStatementTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.StatementTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.StatementTimeout)
+}
+
+// validateDatabaseConnection validates that the database connection is not nil and is alive.
+// This is a common validation pattern used across all database operations.
+// The ping is retried on transient errors, such as an Azure SQL Database being reconfigured.
+func (c *Connector) validateDatabaseConnection(ctx context.Context, db *sql.DB) error {
+	if db == nil {
+		return errors.New("database connection is nil")
+	}
+	if err := c.retry(ctx, func() error { return db.PingContext(ctx) }); err != nil {
+		return fmt.Errorf("database ping failed: %w", err)
+	}
+	return nil
 }
@@ -5,6 +5,10 @@
 package queries
 
 import (
+	"context"
+	"database/sql"
+	"net/url"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -404,3 +408,442 @@ func TestConnector_TimeoutHandling_Unit(t *testing.T) {
 		})
 	}
 }
+
+// TestConnector_WithStatementTimeout_Unit tests that the statement timeout bounds the query context
+func TestConnector_WithStatementTimeout_Unit(t *testing.T) {
+	tests := []struct {
+		name             string
+		statementTimeout time.Duration
+		parentTimeout    time.Duration
+		wantDeadline     bool
+		wantAtMost       time.Duration
+	}{
+		{
+			name:         "no_statement_timeout",
+			wantDeadline: false,
+		},
+		{
+			name:             "statement_timeout",
+			statementTimeout: time.Minute,
+			wantDeadline:     true,
+			wantAtMost:       time.Minute,
+		},
+		{
+			name:             "shorter_parent_deadline_kept",
+			statementTimeout: time.Hour,
+			parentTimeout:    time.Minute,
+			wantDeadline:     true,
+			wantAtMost:       time.Minute,
+		},
+		{
+			name:          "parent_deadline_without_statement_timeout",
+			parentTimeout: time.Minute,
+			wantDeadline:  true,
+			wantAtMost:    time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := context.Background()
+			if tt.parentTimeout > 0 {
+				var cancel context.CancelFunc
+				parent, cancel = context.WithTimeout(parent, tt.parentTimeout)
+				defer cancel()
+			}
+
+			connector := &Connector{StatementTimeout: tt.statementTimeout}
+			ctx, cancel := connector.withStatementTimeout(parent)
+
+			deadline, ok := ctx.Deadline()
+			if ok != tt.wantDeadline {
+				t.Fatalf("Deadline() ok = %v, want %v", ok, tt.wantDeadline)
+			}
+			if ok && time.Until(deadline) > tt.wantAtMost {
+				t.Errorf("Deadline() = %v from now, want at most %v", time.Until(deadline), tt.wantAtMost)
+			}
+
+			cancel()
+			if ctx.Err() == nil {
+				t.Error("cancel() did not cancel the context")
+			}
+		})
+	}
+}
+
+// TestConnector_DialTimeout_Unit tests that the driver accepts the dial timeout built from Timeout
+func TestConnector_DialTimeout_Unit(t *testing.T) {
+	for _, timeout := range []time.Duration{0, 500 * time.Millisecond, 45 * time.Second} {
+		connector := &Connector{
+			Host:           "sql.example.com",
+			Port:           1433,
+			Database:       "testdb",
+			Timeout:        timeout,
+			LocalUserLogin: &LocalUserLogin{Username: "user", Password: "pass"},
+		}
+		if _, err := connector.connector(); err != nil {
+			t.Errorf("connector() with timeout %v unexpected error = %v", timeout, err)
+		}
+	}
+}
+
+// TestConnector_ConfigurePool_Unit tests that the pool limits are applied to the connection pool
+func TestConnector_ConfigurePool_Unit(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxOpenConns    int
+		expectedMaxOpen int
+	}{
+		{
+			name:            "default_unlimited",
+			maxOpenConns:    0,
+			expectedMaxOpen: 0,
+		},
+		{
+			name:            "custom_limit",
+			maxOpenConns:    5,
+			expectedMaxOpen: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector := &Connector{
+				Host:            "test.database.windows.net",
+				Database:        "testdb",
+				LocalUserLogin:  &LocalUserLogin{Username: "user", Password: "pass"},
+				MaxOpenConns:    tt.maxOpenConns,
+				MaxIdleConns:    1,
+				ConnMaxLifetime: 10 * time.Minute,
+			}
+
+			driverConnector, err := connector.connector()
+			if err != nil {
+				t.Fatalf("connector() unexpected error = %v", err)
+			}
+
+			// sql.OpenDB does not connect, so the pool can be inspected without a server
+			db := sql.OpenDB(driverConnector)
+			defer db.Close()
+
+			connector.configurePool(db)
+
+			if got := db.Stats().MaxOpenConnections; got != tt.expectedMaxOpen {
+				t.Errorf("MaxOpenConnections = %v, want %v", got, tt.expectedMaxOpen)
+			}
+		})
+	}
+}
+
+// TestConnector_Close_Unit tests closing connectors that never opened a pool
+func TestConnector_Close_Unit(t *testing.T) {
+	t.Run("nil_connector", func(t *testing.T) {
+		var connector *Connector
+		if err := connector.Close(); err != nil {
+			t.Errorf("Close() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("unopened_connector", func(t *testing.T) {
+		connector := &Connector{Host: "test.database.windows.net", Database: "testdb"}
+		if err := connector.Close(); err != nil {
+			t.Errorf("Close() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("failed_connect_keeps_no_pool", func(t *testing.T) {
+		connector := &Connector{Database: "testdb"}
+		if _, err := connector.Connect(); err == nil || !contains(err.Error(), "missing host name") {
+			t.Errorf("Connect() error = %v, expected to contain %v", err, "missing host name")
+		}
+		if connector.db != nil {
+			t.Error("Expected no pool to be kept after a failed Connect")
+		}
+		if err := CloseAll(); err != nil {
+			t.Errorf("CloseAll() unexpected error = %v", err)
+		}
+	})
+}
+
+// TestConnector_ForDatabase_Unit tests that connectors to other databases share the settings of the provider connector
+func TestConnector_ForDatabase_Unit(t *testing.T) {
+	connector := &Connector{
+		Host:            "sql.example.com",
+		Port:            1433,
+		Database:        "testdb",
+		Timeout:         30 * time.Second,
+		LocalUserLogin:  &LocalUserLogin{Username: "user", Password: "pass"},
+		TLS:             &TLSConfig{Encrypt: EncryptMandatory},
+		MaxOpenConns:    5,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: time.Minute,
+		InstanceName:    "SQL01",
+		FailoverPartner: "sql-mirror.example.com",
+		ExecuteAsUser:   "security_admin",
+		ApplicationName: "terraform/production",
+		WorkstationID:   "runner-42",
+		SessionContext:  map[string]string{"run_id": "1234"},
+		MaxRetries:      2,
+	}
+
+	if got := connector.ForDatabase(""); got != connector {
+		t.Errorf("ForDatabase(\"\") = %p, want the connector itself", got)
+	}
+	if got := connector.ForDatabase("TestDB"); got != connector {
+		t.Errorf("ForDatabase(\"TestDB\") = %p, want the connector itself", got)
+	}
+
+	master := connector.ForDatabase("master")
+	if master == connector {
+		t.Fatal("ForDatabase(\"master\") returned the connector itself")
+	}
+	if master.Database != "master" {
+		t.Errorf("ForDatabase() Database = %v, want %v", master.Database, "master")
+	}
+	if got := connector.ForDatabase("MASTER"); got != master {
+		t.Error("ForDatabase() expected the same connector for the same database")
+	}
+
+	// Every exported setting but the database and the impersonated user is shared, including fields added later.
+	source, sibling := reflect.ValueOf(connector).Elem(), reflect.ValueOf(master).Elem()
+	for i := 0; i < source.NumField(); i++ {
+		field := source.Type().Field(i)
+		if !field.IsExported() || field.Name == "Database" || field.Name == "ExecuteAsUser" {
+			continue
+		}
+		if !reflect.DeepEqual(source.Field(i).Interface(), sibling.Field(i).Interface()) {
+			t.Errorf("ForDatabase() %s = %v, want %v", field.Name, sibling.Field(i).Interface(), source.Field(i).Interface())
+		}
+	}
+
+	// The impersonated user is a user of the provider database: the logins in master run as the connecting principal.
+	if master.ExecuteAsUser != "" {
+		t.Errorf("ForDatabase(\"master\") ExecuteAsUser = %q, want none", master.ExecuteAsUser)
+	}
+	if got := connector.ForDatabase("reporting").ExecuteAsUser; got != "" {
+		t.Errorf("ForDatabase(\"reporting\") ExecuteAsUser = %q, want none", got)
+	}
+	if got := connector.ReadOnly().ExecuteAsUser; got != connector.ExecuteAsUser {
+		t.Errorf("ReadOnly() ExecuteAsUser = %q, want %q", got, connector.ExecuteAsUser)
+	}
+
+	if err := connector.Close(); err != nil {
+		t.Errorf("Close() unexpected error = %v", err)
+	}
+}
+
+// TestConnector_ReadOnly_Unit tests the connectors declaring a read-only application intent
+func TestConnector_ReadOnly_Unit(t *testing.T) {
+	connector := &Connector{
+		Host:           "sql-listener.example.com",
+		Database:       "testdb",
+		LocalUserLogin: &LocalUserLogin{Username: "user", Password: "pass"},
+	}
+
+	readOnly := connector.ReadOnly()
+	if readOnly == connector || !readOnly.ReadOnlyIntent || readOnly.Database != "testdb" {
+		t.Fatalf("ReadOnly() = %+v, want a read-only connector to testdb", readOnly)
+	}
+	if connector.ReadOnlyIntent {
+		t.Error("ReadOnly() changed the intent of the connector")
+	}
+	if got := connector.ReadOnly(); got != readOnly {
+		t.Error("ReadOnly() expected the same connector on every call")
+	}
+	if got := readOnly.ReadOnly(); got != readOnly {
+		t.Error("ReadOnly() on a read-only connector expected the connector itself")
+	}
+
+	// The read-only intent is kept by the connectors to other databases, which are distinct from
+	// the read-write connectors to the same database.
+	reporting := readOnly.ForDatabase("reporting")
+	if !reporting.ReadOnlyIntent {
+		t.Error("ForDatabase() on a read-only connector expected a read-only connector")
+	}
+	if reporting == connector.ForDatabase("reporting") {
+		t.Error("ForDatabase() expected distinct read-only and read-write connectors")
+	}
+	if _, err := reporting.connector(); err != nil {
+		t.Errorf("connector() unexpected error = %v", err)
+	}
+
+	if err := connector.Close(); err != nil {
+		t.Errorf("Close() unexpected error = %v", err)
+	}
+}
+
+// TestConnector_HighAvailability_Unit tests the connection string parameters of the high availability settings
+func TestConnector_HighAvailability_Unit(t *testing.T) {
+	disabled := false
+
+	tests := []struct {
+		name      string
+		connector *Connector
+		want      url.Values
+	}{
+		{
+			name:      "defaults",
+			connector: &Connector{},
+			want:      url.Values{},
+		},
+		{
+			name:      "failover_partner",
+			connector: &Connector{FailoverPartner: "sql-mirror.example.com"},
+			want:      url.Values{"failoverpartner": {"sql-mirror.example.com"}},
+		},
+		{
+			name:      "failover_partner_with_port",
+			connector: &Connector{FailoverPartner: "sql-mirror.example.com:1533"},
+			want:      url.Values{"failoverpartner": {"sql-mirror.example.com"}, "failoverport": {"1533"}},
+		},
+		{
+			name:      "multi_subnet_failover_disabled",
+			connector: &Connector{MultiSubnetFailover: &disabled},
+			want:      url.Values{"multisubnetfailover": {"false"}},
+		},
+		{
+			name:      "read_only_intent",
+			connector: &Connector{ReadOnlyIntent: true},
+			want:      url.Values{"applicationintent": {"ReadOnly"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{}
+			tt.connector.applyHighAvailability(query)
+
+			if query.Encode() != tt.want.Encode() {
+				t.Errorf("applyHighAvailability() = %v, want %v", query.Encode(), tt.want.Encode())
+			}
+		})
+	}
+}
+
+// TestConnector_NamedInstance_Unit tests the port defaults and validation of named instances
+func TestConnector_NamedInstance_Unit(t *testing.T) {
+	tests := []struct {
+		name     string
+		port     int
+		instance string
+		partner  string
+		wantPort int
+		wantErr  bool
+	}{
+		{name: "default_instance", wantPort: 1433},
+		{name: "named_instance_through_browser", instance: "SQL01", wantPort: 0},
+		{name: "named_instance_with_port", instance: "SQL01", port: 1533, wantPort: 1533},
+		{name: "failover_partner_instance", instance: "SQL01", partner: "sql-mirror", wantPort: 0},
+		{name: "invalid_failover_partner_port", partner: "sql-mirror:http", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector := &Connector{
+				Host:            "sql.example.com",
+				Port:            tt.port,
+				Database:        "testdb",
+				InstanceName:    tt.instance,
+				FailoverPartner: tt.partner,
+				LocalUserLogin:  &LocalUserLogin{Username: "user", Password: "pass"},
+			}
+
+			_, err := connector.connector()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("connector() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("connector() unexpected error = %v", err)
+			}
+			if connector.Port != tt.wantPort {
+				t.Errorf("Port = %v, want %v", connector.Port, tt.wantPort)
+			}
+		})
+	}
+}
+
+// TestConnector_ConfigureEntraConnector_Unit tests that every FedAuth mode builds a driver connector
+func TestConnector_ConfigureEntraConnector_Unit(t *testing.T) {
+	tests := []struct {
+		name    string
+		login   *EntraLogin
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:  "service_principal",
+			login: &EntraLogin{Method: ActiveDirectoryServicePrincipal, ClientId: "client", ClientSecret: "secret", TenantId: "tenant"},
+		},
+		{
+			name:  "application",
+			login: &EntraLogin{Method: ActiveDirectoryApplication, ClientId: "client", ClientSecret: "secret"},
+		},
+		{
+			name:  "password",
+			login: &EntraLogin{Method: ActiveDirectoryPassword, ClientId: "client", Username: "user@example.com", Password: "password"},
+		},
+		{
+			name:  "default",
+			login: &EntraLogin{Method: ActiveDirectoryDefault},
+		},
+		{
+			name:  "managed_identity_with_client_id",
+			login: &EntraLogin{Method: ActiveDirectoryManagedIdentity, ClientId: "client"},
+		},
+		{
+			name:  "msi_with_resource_id",
+			login: &EntraLogin{Method: ActiveDirectoryMSI, ResourceId: "/subscriptions/test/resourceGroups/test/providers/Microsoft.ManagedIdentity/userAssignedIdentities/test"},
+		},
+		{
+			name:  "interactive",
+			login: &EntraLogin{Method: ActiveDirectoryInteractive, ClientId: "client", Username: "user@example.com"},
+		},
+		{
+			name:  "device_code",
+			login: &EntraLogin{Method: ActiveDirectoryDeviceCode},
+		},
+		{
+			name:  "azcli",
+			login: &EntraLogin{Method: ActiveDirectoryAzCli},
+		},
+		{
+			name:    "unsupported_method",
+			login:   &EntraLogin{Method: FedAuth("ActiveDirectoryKerberos")},
+			wantErr: true,
+			errMsg:  "unsupported Entra authentication method",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector := &Connector{
+				Host:       "test.database.windows.net",
+				Database:   "testdb",
+				EntraLogin: tt.login,
+			}
+
+			driverConnector, err := connector.connector()
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("connector() expected error but got none")
+					return
+				}
+				if !contains(err.Error(), tt.errMsg) {
+					t.Errorf("connector() error = %v, expected to contain %v", err, tt.errMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("connector() unexpected error = %v", err)
+			}
+			if driverConnector == nil {
+				t.Error("connector() returned a nil driver connector")
+			}
+		})
+	}
+}
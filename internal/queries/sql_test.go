@@ -99,13 +99,13 @@ func TestConnector_connector_local_sql_authentication(t *testing.T) {
 	}
 
 	// Connecting to the database and checking if the connection is successful
-	db, err := connector.Connect() // The actual function to test
+	_, err := connector.Connect() // The actual function to test
 	if err != nil {
 		t.Errorf("cannot connect to local server using SQL Authentication: %s", err)
 		return
 	}
 	defer func() {
-		if err := db.Close(); err != nil {
+		if err := connector.Close(); err != nil {
 			log.Printf("Error closing database: %v", err)
 		}
 	}()
@@ -123,13 +123,13 @@ func TestConnector_connector_azure_sql_authentication(t *testing.T) {
 	}
 
 	// Connecting to the database and checking if the connection is successful
-	db, err := connector.Connect() // The actual function to test
+	_, err := connector.Connect() // The actual function to test
 	if err != nil {
 		t.Errorf("cannot connect to local server using SQL Authentication: %s", err)
 		return
 	}
 	defer func() {
-		if err := db.Close(); err != nil {
+		if err := connector.Close(); err != nil {
 			log.Printf("Error closing database: %v", err)
 		}
 	}()
@@ -146,13 +146,13 @@ func TestConnector_connector_azure_aad_authentication(t *testing.T) {
 		},
 	}
 
-	db, err := connector.Connect() // The actual function to test
+	_, err := connector.Connect() // The actual function to test
 	if err != nil {
 		t.Errorf("cannot connect to local server using AAD Authentication with SPN: %s", err)
 		return
 	}
 	defer func() {
-		if err := db.Close(); err != nil {
+		if err := connector.Close(); err != nil {
 			log.Printf("Error closing database: %v", err)
 		}
 	}()
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+)
+
+// Encryption is the encryption mode negotiated with SQL Server.
+type Encryption string
+
+func (e Encryption) String() string {
+	return string(e)
+}
+
+// Represents the supported encryption modes.
+const (
+	// EncryptStrict uses TDS 8.0, where the TLS handshake happens before any TDS message.
+	EncryptStrict Encryption = "strict"
+	// EncryptMandatory encrypts the whole connection after the TDS pre-login.
+	EncryptMandatory Encryption = "mandatory"
+	// EncryptOptional only encrypts the login packet unless the server requires encryption.
+	EncryptOptional Encryption = "optional"
+	// EncryptDisable never encrypts the connection.
+	EncryptDisable Encryption = "disable"
+)
+
+// EncryptionModes lists the supported encryption modes.
+var EncryptionModes = []Encryption{EncryptStrict, EncryptMandatory, EncryptOptional, EncryptDisable}
+
+// TLSVersions lists the supported minimum TLS versions.
+var TLSVersions = []string{"1.0", "1.1", "1.2", "1.3"}
+
+// TLSConfig represents the encryption settings of the connection.
+// When a TLSConfig is set, the server certificate is verified unless TrustServerCertificate is true,
+// against the system roots or the PEM bundle in CACertificatePath.
+type TLSConfig struct {
+	Encrypt                Encryption
+	TrustServerCertificate bool
+	CACertificatePath      string
+	HostNameInCertificate  string
+	MinTLSVersion          string
+}
+
+// validate checks the encryption mode, the TLS version and that the settings don't contradict each other.
+func (t *TLSConfig) validate() error {
+	if t.Encrypt != "" && !slices.Contains(EncryptionModes, t.Encrypt) {
+		return fmt.Errorf("unsupported encryption mode %q", t.Encrypt)
+	}
+	if t.MinTLSVersion != "" && !slices.Contains(TLSVersions, t.MinTLSVersion) {
+		return fmt.Errorf("unsupported minimum TLS version %q", t.MinTLSVersion)
+	}
+	if t.Encrypt == EncryptDisable && (t.TrustServerCertificate || t.CACertificatePath != "" || t.HostNameInCertificate != "" || t.MinTLSVersion != "") {
+		return errors.New("TLS settings cannot be used when encryption is disabled")
+	}
+	if t.Encrypt == EncryptStrict && t.TrustServerCertificate {
+		return errors.New("trust server certificate cannot be used with strict encryption")
+	}
+	if t.TrustServerCertificate && t.CACertificatePath != "" {
+		return errors.New("trust server certificate and CA certificate are mutually exclusive")
+	}
+	if t.CACertificatePath != "" {
+		if err := ValidateCACertificate(t.CACertificatePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply adds the encryption settings to the connection string parameters.
+// TrustServerCertificate is always set, as the driver trusts any certificate when encrypt is omitted.
+func (t *TLSConfig) apply(query url.Values) {
+	if t.Encrypt != "" {
+		query.Add("encrypt", t.Encrypt.String())
+	}
+	query.Add("trustservercertificate", strconv.FormatBool(t.TrustServerCertificate))
+	if t.CACertificatePath != "" {
+		query.Add("certificate", t.CACertificatePath)
+	}
+	if t.HostNameInCertificate != "" {
+		query.Add("hostnameincertificate", t.HostNameInCertificate)
+	}
+	if t.MinTLSVersion != "" {
+		query.Add("tlsmin", t.MinTLSVersion)
+	}
+}
+
+// ValidateCACertificate checks that the file at path can be read and holds at least one PEM certificate.
+func ValidateCACertificate(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read CA certificate: %w", err)
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(data) {
+		return fmt.Errorf("no PEM certificate found in %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ============================================================================
+// TLS CONFIGURATION UNIT TESTS
+// ============================================================================
+
+// TestTLSConfig_Validate_Unit tests the encryption settings validation
+func TestTLSConfig_Validate_Unit(t *testing.T) {
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caPath, generateTestCertificatePEM(t), 0o600); err != nil {
+		t.Fatalf("cannot write test certificate: %v", err)
+	}
+
+	invalidCAPath := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := os.WriteFile(invalidCAPath, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("cannot write test certificate: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		tls     *TLSConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "empty",
+			tls:     &TLSConfig{},
+			wantErr: false,
+		},
+		{
+			name:    "strict_with_ca_certificate",
+			tls:     &TLSConfig{Encrypt: EncryptStrict, CACertificatePath: caPath, HostNameInCertificate: "sql.internal", MinTLSVersion: "1.2"},
+			wantErr: false,
+		},
+		{
+			name:    "mandatory_trust_server_certificate",
+			tls:     &TLSConfig{Encrypt: EncryptMandatory, TrustServerCertificate: true},
+			wantErr: false,
+		},
+		{
+			name:    "disable",
+			tls:     &TLSConfig{Encrypt: EncryptDisable},
+			wantErr: false,
+		},
+		{
+			name:    "unsupported_encryption_mode",
+			tls:     &TLSConfig{Encrypt: "always"},
+			wantErr: true,
+			errMsg:  "unsupported encryption mode",
+		},
+		{
+			name:    "unsupported_tls_version",
+			tls:     &TLSConfig{MinTLSVersion: "1.4"},
+			wantErr: true,
+			errMsg:  "unsupported minimum TLS version",
+		},
+		{
+			name:    "disable_with_tls_settings",
+			tls:     &TLSConfig{Encrypt: EncryptDisable, MinTLSVersion: "1.2"},
+			wantErr: true,
+			errMsg:  "encryption is disabled",
+		},
+		{
+			name:    "strict_trust_server_certificate",
+			tls:     &TLSConfig{Encrypt: EncryptStrict, TrustServerCertificate: true},
+			wantErr: true,
+			errMsg:  "cannot be used with strict encryption",
+		},
+		{
+			name:    "trust_server_certificate_with_ca_certificate",
+			tls:     &TLSConfig{TrustServerCertificate: true, CACertificatePath: caPath},
+			wantErr: true,
+			errMsg:  "mutually exclusive",
+		},
+		{
+			name:    "missing_ca_certificate",
+			tls:     &TLSConfig{CACertificatePath: filepath.Join(t.TempDir(), "missing.pem")},
+			wantErr: true,
+			errMsg:  "cannot read CA certificate",
+		},
+		{
+			name:    "invalid_ca_certificate",
+			tls:     &TLSConfig{CACertificatePath: invalidCAPath},
+			wantErr: true,
+			errMsg:  "no PEM certificate found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tls.validate()
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("validate() expected error but got none")
+					return
+				}
+				if !contains(err.Error(), tt.errMsg) {
+					t.Errorf("validate() error = %v, expected to contain %v", err, tt.errMsg)
+				}
+			} else if err != nil {
+				t.Errorf("validate() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+// TestTLSConfig_Apply_Unit tests the connection string parameters set by the encryption settings
+func TestTLSConfig_Apply_Unit(t *testing.T) {
+	tests := []struct {
+		name string
+		tls  *TLSConfig
+		want url.Values
+	}{
+		{
+			name: "defaults_verify_server_certificate",
+			tls:  &TLSConfig{},
+			want: url.Values{"trustservercertificate": {"false"}},
+		},
+		{
+			name: "all_settings",
+			tls: &TLSConfig{
+				Encrypt:               EncryptStrict,
+				CACertificatePath:     "/etc/ssl/internal-ca.pem",
+				HostNameInCertificate: "sql.internal",
+				MinTLSVersion:         "1.2",
+			},
+			want: url.Values{
+				"encrypt":                {"strict"},
+				"trustservercertificate": {"false"},
+				"certificate":            {"/etc/ssl/internal-ca.pem"},
+				"hostnameincertificate":  {"sql.internal"},
+				"tlsmin":                 {"1.2"},
+			},
+		},
+		{
+			name: "trust_server_certificate",
+			tls:  &TLSConfig{Encrypt: EncryptOptional, TrustServerCertificate: true},
+			want: url.Values{"encrypt": {"optional"}, "trustservercertificate": {"true"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{}
+			tt.tls.apply(query)
+
+			if query.Encode() != tt.want.Encode() {
+				t.Errorf("apply() = %v, want %v", query.Encode(), tt.want.Encode())
+			}
+		})
+	}
+}
+
+// TestConnector_TLS_Unit tests that the driver accepts the connection string built with encryption settings
+func TestConnector_TLS_Unit(t *testing.T) {
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caPath, generateTestCertificatePEM(t), 0o600); err != nil {
+		t.Fatalf("cannot write test certificate: %v", err)
+	}
+
+	connector := &Connector{
+		Host:           "sql.example.com",
+		Port:           1433,
+		Database:       "testdb",
+		LocalUserLogin: &LocalUserLogin{Username: "user", Password: "pass"},
+		TLS:            &TLSConfig{Encrypt: EncryptMandatory, CACertificatePath: caPath, MinTLSVersion: "1.2"},
+	}
+	if _, err := connector.connector(); err != nil {
+		t.Errorf("connector() unexpected error = %v", err)
+	}
+
+	connector.TLS = &TLSConfig{Encrypt: "always"}
+	if _, err := connector.connector(); err == nil || !contains(err.Error(), "invalid TLS configuration") {
+		t.Errorf("connector() error = %v, expected to contain %v", err, "invalid TLS configuration")
+	}
+}
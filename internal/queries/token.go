@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/microsoft/go-mssqldb/msdsn"
+)
+
+const scopeDefaultSuffix = "/.default"
+
+// tokenRefreshMargin is how long before its expiry a cached access token is renewed, so that a connection
+// opened late in a long apply never logs in with a token about to expire.
+const tokenRefreshMargin = 5 * time.Minute
+
+// AuthenticationError reports that no Entra ID access token could be acquired for an identity.
+// It is returned, possibly wrapped, by Connect and tells credential failures apart from network
+// or server errors.
+type AuthenticationError struct {
+	Identity string
+	Err      error
+}
+
+// Error implements the error interface.
+func (e *AuthenticationError) Error() string {
+	return fmt.Sprintf("cannot acquire an Entra ID access token for %s: %v", e.Identity, e.Err)
+}
+
+// Unwrap returns the error of the credential.
+func (e *AuthenticationError) Unwrap() error {
+	return e.Err
+}
+
+// entraIdentity describes how to acquire the Entra ID access tokens of a login.
+type entraIdentity struct {
+	// name identifies the identity in the token cache and in the errors; it never includes a secret.
+	name string
+	// tenant is the tenant the identity authenticates in, empty when it follows the tenant of the server.
+	// A same application registered in several tenants is a different identity in each of them.
+	tenant string
+	// fingerprint tells apart the secrets of a same identity, so that a rotated secret replaces the tokens of the previous one.
+	fingerprint string
+	// workflow is the ADAL workflow announced to the server during login.
+	workflow byte
+	// credential returns the credential of the identity for the authority host and tenant announced by the server.
+	credential func(authorityHost, tenant string) (azcore.TokenCredential, error)
+}
+
+// secretFingerprint returns a digest of secret to use as the fingerprint of an identity.
+func secretFingerprint(secret ...string) string {
+	digest := sha256.Sum256([]byte(strings.Join(secret, "\x00")))
+	return hex.EncodeToString(digest[:])
+}
+
+// tokenKey identifies the access tokens of an identity for a resource. The secret of the identity is
+// not part of the key: a rotated secret replaces the entry instead of adding one.
+type tokenKey struct {
+	identity string
+	tenant   string
+	stsURL   string
+	scope    string
+}
+
+// cachedToken holds the credential of an identity and its last access token for a resource.
+// Its lock is held while a token is requested, so that concurrent connections share a single request.
+type cachedToken struct {
+	mu          sync.Mutex
+	fingerprint string
+	credential  azcore.TokenCredential
+	token       azcore.AccessToken
+}
+
+// fresh reports whether the token can still be used at now without being renewed.
+func (t *cachedToken) fresh(now time.Time) bool {
+	if t.token.Token == "" {
+		return false
+	}
+	if !t.token.RefreshOn.IsZero() && !now.Before(t.token.RefreshOn) {
+		return false
+	}
+	return now.Before(t.token.ExpiresOn.Add(-tokenRefreshMargin))
+}
+
+// tokenCache shares the Entra ID access tokens of the connectors of the process. A token is requested
+// once per identity and resource, reused by every new connection, and renewed shortly before it expires.
+type tokenCache struct {
+	mu      sync.Mutex
+	entries map[tokenKey]*cachedToken
+}
+
+// entraTokens is the token cache of the process.
+var entraTokens = &tokenCache{}
+
+// token returns an access token of identity for the server SPN and STS URL announced by the server.
+// When renewing a token fails, the cached token is returned as long as it has not expired.
+func (c *tokenCache) token(ctx context.Context, identity entraIdentity, serverSPN, stsURL string) (string, error) {
+	scope := serverSPN
+	if !strings.HasSuffix(scope, scopeDefaultSuffix) {
+		scope += scopeDefaultSuffix
+	}
+	key := tokenKey{identity: identity.name, tenant: identity.tenant, stsURL: stsURL, scope: scope}
+
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = map[tokenKey]*cachedToken{}
+	}
+	entry, ok := c.entries[key]
+	if !ok || entry.fingerprint != identity.fingerprint {
+		// The credential and token of a previous secret are dropped along with the entry.
+		entry = &cachedToken{fingerprint: identity.fingerprint}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.fresh(time.Now()) {
+		return entry.token.Token, nil
+	}
+
+	token, err := entry.renew(ctx, identity, stsURL, scope)
+	if err != nil {
+		if entry.token.Token != "" && time.Now().Before(entry.token.ExpiresOn) {
+			return entry.token.Token, nil
+		}
+		return "", &AuthenticationError{Identity: identity.name, Err: err}
+	}
+
+	entry.token = token
+	return token.Token, nil
+}
+
+// renew requests a new access token, creating the credential of the identity on first use.
+func (t *cachedToken) renew(ctx context.Context, identity entraIdentity, stsURL, scope string) (azcore.AccessToken, error) {
+	if t.credential == nil {
+		authorityHost, tenant := stsURL, ""
+		if i := strings.LastIndex(stsURL, "/"); i >= 0 {
+			authorityHost, tenant = stsURL[:i], stsURL[i+1:]
+		}
+
+		credential, err := identity.credential(authorityHost, tenant)
+		if err != nil {
+			return azcore.AccessToken{}, err
+		}
+		t.credential = credential
+	}
+
+	return t.credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{scope}})
+}
+
+// newEntraConnector returns a driver.Connector that authenticates with the Entra ID access tokens of identity,
+// taken from the token cache of the process. The token scope is derived from the server SPN sent by SQL Server
+// during login.
+func newEntraConnector(dsn string, identity entraIdentity) (driver.Connector, error) {
+	config, err := msdsn.Parse(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return mssql.NewActiveDirectoryTokenConnector(config, identity.workflow,
+		func(ctx context.Context, serverSPN, stsURL string) (string, error) {
+			return entraTokens.token(ctx, identity, serverSPN, stsURL)
+		},
+	)
+}
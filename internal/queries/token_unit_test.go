@@ -0,0 +1,348 @@
+// SPDX-FileCopyrightText: 2024 AWARE - Altogether We Are Retailers
+// SPDX-FileContributor: Cédric Ghiot <cedric@weareretail.ai>
+// SPDX-License-Identifier: MIT
+
+package queries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	mssql "github.com/microsoft/go-mssqldb"
+)
+
+// ============================================================================
+// ENTRA ID TOKEN CACHE UNIT TESTS
+// ============================================================================
+
+const (
+	testServerSPN = "https://database.windows.net/"
+	testSTSURL    = "https://login.microsoftonline.com/tenant-from-server"
+)
+
+// fakeCredential is an azcore.TokenCredential returning tokens with a fixed lifetime.
+type fakeCredential struct {
+	mu       sync.Mutex
+	calls    int
+	lifetime time.Duration
+	err      error
+	scopes   []string
+}
+
+func (f *fakeCredential) GetToken(_ context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	f.scopes = options.Scopes
+	if f.err != nil {
+		return azcore.AccessToken{}, f.err
+	}
+	return azcore.AccessToken{Token: fmt.Sprintf("token-%d", f.calls), ExpiresOn: time.Now().Add(f.lifetime)}, nil
+}
+
+// fakeIdentity returns an identity backed by credential, recording the tenant it is created for.
+func fakeIdentity(name string, credential *fakeCredential, tenant *string) entraIdentity {
+	return entraIdentity{
+		name:     name,
+		workflow: mssql.FedAuthADALWorkflowPassword,
+		credential: func(_, t string) (azcore.TokenCredential, error) {
+			if tenant != nil {
+				*tenant = t
+			}
+			return credential, nil
+		},
+	}
+}
+
+// TestTokenCache_Token_Unit tests that access tokens are reused until shortly before they expire
+func TestTokenCache_Token_Unit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reused_while_fresh", func(t *testing.T) {
+		cache := &tokenCache{}
+		credential := &fakeCredential{lifetime: time.Hour}
+		var tenant string
+		identity := fakeIdentity("service principal client", credential, &tenant)
+
+		for i := 0; i < 3; i++ {
+			token, err := cache.token(ctx, identity, testServerSPN, testSTSURL)
+			if err != nil {
+				t.Fatalf("token() unexpected error = %v", err)
+			}
+			if token != "token-1" {
+				t.Errorf("token() = %s, want token-1", token)
+			}
+		}
+		if credential.calls != 1 {
+			t.Errorf("GetToken() called %d times, want 1", credential.calls)
+		}
+		if tenant != "tenant-from-server" {
+			t.Errorf("credential created for tenant %q, want tenant-from-server", tenant)
+		}
+		if len(credential.scopes) != 1 || credential.scopes[0] != "https://database.windows.net//.default" {
+			t.Errorf("GetToken() scopes = %v", credential.scopes)
+		}
+	})
+
+	t.Run("renewed_before_expiry", func(t *testing.T) {
+		cache := &tokenCache{}
+		credential := &fakeCredential{lifetime: tokenRefreshMargin - time.Minute}
+		identity := fakeIdentity("service principal client", credential, nil)
+
+		for i := 1; i <= 2; i++ {
+			token, err := cache.token(ctx, identity, testServerSPN, testSTSURL)
+			if err != nil {
+				t.Fatalf("token() unexpected error = %v", err)
+			}
+			if want := fmt.Sprintf("token-%d", i); token != want {
+				t.Errorf("token() = %s, want %s", token, want)
+			}
+		}
+	})
+
+	t.Run("suggested_refresh", func(t *testing.T) {
+		entry := &cachedToken{token: azcore.AccessToken{
+			Token:     "token",
+			ExpiresOn: time.Now().Add(time.Hour),
+			RefreshOn: time.Now().Add(-time.Second),
+		}}
+		if entry.fresh(time.Now()) {
+			t.Error("fresh() = true, want false once the suggested refresh time has passed")
+		}
+	})
+
+	t.Run("identities_and_resources_kept_apart", func(t *testing.T) {
+		cache := &tokenCache{}
+		first := &fakeCredential{lifetime: time.Hour}
+		second := &fakeCredential{lifetime: time.Hour}
+
+		_, _ = cache.token(ctx, fakeIdentity("service principal first", first, nil), testServerSPN, testSTSURL)
+		_, _ = cache.token(ctx, fakeIdentity("service principal second", second, nil), testServerSPN, testSTSURL)
+		_, _ = cache.token(ctx, fakeIdentity("service principal first", first, nil), "https://other.database.windows.net/", testSTSURL)
+
+		otherTenant := fakeIdentity("service principal first", first, nil)
+		otherTenant.tenant = "other-tenant"
+		_, _ = cache.token(ctx, otherTenant, testServerSPN, testSTSURL)
+
+		if first.calls != 3 || second.calls != 1 {
+			t.Errorf("GetToken() called %d and %d times, want 3 and 1", first.calls, second.calls)
+		}
+	})
+
+	t.Run("rotated_secret_replaces_the_entry", func(t *testing.T) {
+		cache := &tokenCache{}
+		credential := &fakeCredential{lifetime: time.Hour}
+		identity := fakeIdentity("service principal client", credential, nil)
+		identity.fingerprint = secretFingerprint("secret")
+
+		_, _ = cache.token(ctx, identity, testServerSPN, testSTSURL)
+		for _, secret := range []string{"rotated", "rotated again"} {
+			identity.fingerprint = secretFingerprint(secret)
+			token, err := cache.token(ctx, identity, testServerSPN, testSTSURL)
+			if err != nil {
+				t.Fatalf("token() unexpected error = %v", err)
+			}
+			if token == "token-1" {
+				t.Error("token() returned the token of the previous secret")
+			}
+		}
+
+		if len(cache.entries) != 1 {
+			t.Errorf("token cache holds %d entries, want 1", len(cache.entries))
+		}
+		if credential.calls != 3 {
+			t.Errorf("GetToken() called %d times, want 3", credential.calls)
+		}
+	})
+
+	t.Run("concurrent_connections_share_a_request", func(t *testing.T) {
+		cache := &tokenCache{}
+		credential := &fakeCredential{lifetime: time.Hour}
+		identity := fakeIdentity("service principal client", credential, nil)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = cache.token(ctx, identity, testServerSPN, testSTSURL)
+			}()
+		}
+		wg.Wait()
+
+		if credential.calls != 1 {
+			t.Errorf("GetToken() called %d times, want 1", credential.calls)
+		}
+	})
+}
+
+// TestTokenCache_Errors_Unit tests the errors of the credentials
+func TestTokenCache_Errors_Unit(t *testing.T) {
+	ctx := context.Background()
+	credentialErr := errors.New("AADSTS7000215: invalid client secret")
+
+	t.Run("authentication_error", func(t *testing.T) {
+		cache := &tokenCache{}
+		credential := &fakeCredential{err: credentialErr}
+
+		_, err := cache.token(ctx, fakeIdentity("service principal client", credential, nil), testServerSPN, testSTSURL)
+
+		var authErr *AuthenticationError
+		if !errors.As(err, &authErr) {
+			t.Fatalf("token() error = %v, want an *AuthenticationError", err)
+		}
+		if authErr.Identity != "service principal client" || !errors.Is(err, credentialErr) {
+			t.Errorf("token() error = %v", err)
+		}
+		if !contains(err.Error(), "cannot acquire an Entra ID access token for service principal client") {
+			t.Errorf("token() error = %v", err)
+		}
+	})
+
+	t.Run("credential_creation_error", func(t *testing.T) {
+		cache := &tokenCache{}
+		identity := entraIdentity{
+			name:       "ActiveDirectoryAzCli",
+			credential: func(_, _ string) (azcore.TokenCredential, error) { return nil, credentialErr },
+		}
+
+		_, err := cache.token(ctx, identity, testServerSPN, testSTSURL)
+
+		var authErr *AuthenticationError
+		if !errors.As(err, &authErr) {
+			t.Fatalf("token() error = %v, want an *AuthenticationError", err)
+		}
+	})
+
+	t.Run("valid_token_kept_when_renewal_fails", func(t *testing.T) {
+		cache := &tokenCache{}
+		credential := &fakeCredential{lifetime: tokenRefreshMargin - time.Minute}
+		identity := fakeIdentity("service principal client", credential, nil)
+
+		if _, err := cache.token(ctx, identity, testServerSPN, testSTSURL); err != nil {
+			t.Fatalf("token() unexpected error = %v", err)
+		}
+
+		credential.err = credentialErr
+		token, err := cache.token(ctx, identity, testServerSPN, testSTSURL)
+		if err != nil {
+			t.Fatalf("token() unexpected error = %v", err)
+		}
+		if token != "token-1" {
+			t.Errorf("token() = %s, want the cached token-1", token)
+		}
+	})
+}
+
+// TestEntraLogin_Identity_Unit tests the identities of the Entra login methods
+func TestEntraLogin_Identity_Unit(t *testing.T) {
+	tests := []struct {
+		name         string
+		login        *EntraLogin
+		wantName     string
+		wantWorkflow byte
+		wantErr      bool
+		errMsg       string
+	}{
+		{
+			name:         "service_principal",
+			login:        &EntraLogin{Method: ActiveDirectoryServicePrincipal, ClientId: "client", ClientSecret: "secret"},
+			wantName:     "ActiveDirectoryServicePrincipal client",
+			wantWorkflow: mssql.FedAuthADALWorkflowPassword,
+		},
+		{
+			name:         "managed_identity",
+			login:        &EntraLogin{Method: ActiveDirectoryMSI, ClientId: "client"},
+			wantName:     "ActiveDirectoryMSI client",
+			wantWorkflow: mssql.FedAuthADALWorkflowMSI,
+		},
+		{
+			name:         "default",
+			login:        &EntraLogin{Method: ActiveDirectoryDefault},
+			wantName:     "ActiveDirectoryDefault",
+			wantWorkflow: mssql.FedAuthADALWorkflowPassword,
+		},
+		{
+			name:    "password_without_client_id",
+			login:   &EntraLogin{Method: ActiveDirectoryPassword, Username: "user@example.com", Password: "password"},
+			wantErr: true,
+			errMsg:  "client id is required for ActiveDirectoryPassword",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := tt.login.identity()
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("identity() expected error but got none")
+					return
+				}
+				if !contains(err.Error(), tt.errMsg) {
+					t.Errorf("identity() error = %v, expected to contain %v", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("identity() unexpected error = %v", err)
+			}
+			if identity.name != tt.wantName || identity.workflow != tt.wantWorkflow {
+				t.Errorf("identity() = %s (workflow %d), want %s (workflow %d)", identity.name, identity.workflow, tt.wantName, tt.wantWorkflow)
+			}
+		})
+	}
+}
+
+// TestAzureApplicationLogin_Identity_Unit tests that the fingerprint of a service principal follows its secret
+func TestAzureApplicationLogin_Identity_Unit(t *testing.T) {
+	login := &AzureApplicationLogin{ClientId: "client", TenantId: "tenant"}
+
+	first, err := login.identity("secret")
+	if err != nil {
+		t.Fatalf("identity() unexpected error = %v", err)
+	}
+	rotated, err := login.identity("rotated")
+	if err != nil {
+		t.Fatalf("identity() unexpected error = %v", err)
+	}
+
+	if first.name != "service principal client" || first.name != rotated.name {
+		t.Errorf("identity() names = %q and %q, want service principal client", first.name, rotated.name)
+	}
+	if first.tenant != "tenant" {
+		t.Errorf("identity() tenant = %q, want tenant", first.tenant)
+	}
+	if first.fingerprint == rotated.fingerprint {
+		t.Error("identity() fingerprints are equal for different secrets")
+	}
+	if contains(first.fingerprint, "secret") {
+		t.Error("identity() fingerprint discloses the secret")
+	}
+}
+
+// TestFederatedLogin_Identity_Unit tests that the fingerprint of a federated identity follows its inline OIDC token
+func TestFederatedLogin_Identity_Unit(t *testing.T) {
+	first, err := (&FederatedLogin{ClientId: "client", TenantId: "tenant", OIDCToken: "first"}).identity()
+	if err != nil {
+		t.Fatalf("identity() unexpected error = %v", err)
+	}
+	rotated, err := (&FederatedLogin{ClientId: "client", TenantId: "tenant", OIDCToken: "rotated"}).identity()
+	if err != nil {
+		t.Fatalf("identity() unexpected error = %v", err)
+	}
+
+	if first.name != rotated.name {
+		t.Errorf("identity() names = %q and %q, want the same name", first.name, rotated.name)
+	}
+	if first.fingerprint == rotated.fingerprint {
+		t.Error("identity() fingerprints are equal for different OIDC tokens")
+	}
+}
@@ -7,10 +7,15 @@ package queries
 import (
 	"context"
 	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
 	"terraform-provider-mssqlpermissions/internal/queries/model"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mssql "github.com/microsoft/go-mssqldb"
 )
 
 // validateUser validates the given user object.
@@ -22,7 +27,19 @@ func (c *Connector) validateUser(user *model.User) error {
 		return errors.New("a user must have a name")
 	}
 
-	if user.Password == "" && !user.External {
+	if user.LoginName != "" {
+		if user.External {
+			return errors.New("an external user cannot be mapped to a login")
+		}
+		if user.Password != "" {
+			return errors.New("a user mapped to a login cannot have a password")
+		}
+		if user.DefaultLanguage != "" {
+			return errors.New("a user mapped to a login cannot have a default language")
+		}
+	}
+
+	if user.Password == "" && !user.External && user.LoginName == "" {
 		return errors.New("a contained user must have a password if it's not external")
 	}
 
@@ -51,6 +68,8 @@ func (c *Connector) validateUser(user *model.User) error {
 // It takes a context, a database connection, and a user model as input.
 // It returns an error if the user creation fails, or nil if successful.
 func (c *Connector) CreateUser(ctx context.Context, db *sql.DB, user *model.User) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
 
 	var err error
 
@@ -86,8 +105,17 @@ func (c *Connector) CreateUser(ctx context.Context, db *sql.DB, user *model.User
 			// Note: this is an undocumented, unsupported option. See https://github.com/MicrosoftDocs/sql-docs/issues/2323
 			query = query + " + ' WITH OBJECT_ID= ' + QuoteName(@objectID)"
 		}
+	} else if userCopy.LoginName != "" { // The user is mapped to a server login.
+
+		query = query + " + ' FOR LOGIN ' + QUOTENAME(@loginName) + ' WITH DEFAULT_SCHEMA = ' + QuoteName(@defaultSchema)"
+
 	} else { // The authentication type is SQL Server authentication.
 
+		// Users with a password can only be created in a contained database.
+		if !c.isContainedDatabase {
+			return errors.New("cannot create user. the database is not a contained database: set a login name to map the user to a server login")
+		}
+
 		query = query + " + ' WITH PASSWORD = ' + QUOTENAME(@password, '''') + ', DEFAULT_SCHEMA = ' + QuoteName(@defaultSchema)"
 
 		if !c.isAzureDatabase {
@@ -103,12 +131,14 @@ func (c *Connector) CreateUser(ctx context.Context, db *sql.DB, user *model.User
 	// The full TSQL script.
 	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
 
-	_, err = db.ExecContext(
+	_, err = c.execContext(
 		ctx,
+		db,
 		tsql,
 		sql.Named("name", userCopy.Name),
 		sql.Named("password", userCopy.Password),
 		sql.Named("objectID", userCopy.ObjectID),
+		sql.Named("loginName", userCopy.LoginName),
 		sql.Named("defaultSchema", userCopy.DefaultSchema),
 		sql.Named("defaultLanguage", userCopy.DefaultLanguage))
 
@@ -123,6 +153,9 @@ func (c *Connector) CreateUser(ctx context.Context, db *sql.DB, user *model.User
 // It takes a context, a database connection, and a user object as input.
 // It returns the retrieved user object and an error if any.
 func (c *Connector) GetUser(ctx context.Context, db *sql.DB, user *model.User) (*model.User, error) {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	var err error
 
 	type DatabasePrincipals struct {
@@ -135,25 +168,38 @@ func (c *Connector) GetUser(ctx context.Context, db *sql.DB, user *model.User) (
 		AuthenticationType     int
 		AuthenticationTypeDesc string
 		DefaultLanguageName    sql.NullString
+		LoginName              sql.NullString
 	}
 
 	var result DatabasePrincipals
 
+	// Without a name, SID or principal id the query would return an arbitrary user.
+	if user.Name == "" && user.SID == "" && user.PrincipalID <= 0 {
+		return nil, errors.New("cannot retrieve user: a name, SID or principal id is required")
+	}
+
 	// Check if the database connection is nil.
 	if err := c.validateDatabaseConnection(ctx, db); err != nil {
 		return nil, err
 	}
 
-	// SQL query to retrieve a user
-	query := "SELECT [name], [principal_id], [type], [type_desc], [default_schema_name], CONVERT(varchar(max), [sid], 1) as [sid], [authentication_type], [authentication_type_desc], [default_language_name] FROM sys.database_principals"
-
-	if user.Name != "" {
-		query = query + " WHERE [name] = @name"
-	} else if user.PrincipalID != 0 {
-		query = query + " WHERE [principal_id] = @principal_id"
+	// SQL query to retrieve a user. Roles and application roles are not users.
+	query := "SELECT [name], [principal_id], [type], [type_desc], [default_schema_name], CONVERT(varchar(max), [sid], 1) as [sid], [authentication_type], [authentication_type_desc], [default_language_name], SUSER_SNAME([sid]) AS [login_name] FROM sys.database_principals WHERE [type] NOT IN ('A', 'R')"
+
+	// The user is looked up by name, by principal id and SID, by SID or by principal id, in that order of preference.
+	// A principal id is only trusted along with the SID, when known, as SQL Server reuses the ids of dropped principals.
+	switch {
+	case user.Name != "":
+		query = query + " AND [name] = @name"
+	case user.PrincipalID > 0 && user.SID != "":
+		query = query + " AND [principal_id] = @principal_id AND [sid] = CONVERT(varbinary(85), @sid, 1)"
+	case user.SID != "":
+		query = query + " AND [sid] = CONVERT(varbinary(85), @sid, 1)"
+	default:
+		query = query + " AND [principal_id] = @principal_id"
 	}
 	// Execute query
-	row := db.QueryRowContext(ctx, query, sql.Named("name", user.Name), sql.Named("principal_id", user.PrincipalID))
+	row := c.queryRowContext(ctx, db, query, sql.Named("name", user.Name), sql.Named("sid", user.SID), sql.Named("principal_id", user.PrincipalID))
 
 	// Populate the result object with the result of the query.
 	err = row.Scan(
@@ -165,7 +211,8 @@ func (c *Connector) GetUser(ctx context.Context, db *sql.DB, user *model.User) (
 		&result.SID,
 		&result.AuthenticationType,
 		&result.AuthenticationTypeDesc,
-		&result.DefaultLanguageName)
+		&result.DefaultLanguageName,
+		&result.LoginName)
 
 	// Check if the user was not found.
 	if err == sql.ErrNoRows {
@@ -188,15 +235,116 @@ func (c *Connector) GetUser(ctx context.Context, db *sql.DB, user *model.User) (
 	user.SID = result.SID
 	user.PrincipalID = result.PrincipalID
 
+	// The SID of an external user holds its Entra ID object id. A known object id is kept as given.
+	if !user.External {
+		user.ObjectID = ""
+	} else if user.ObjectID == "" {
+		user.ObjectID = objectIDFromSID(result.SID)
+	}
+
+	// Only users authenticated by the instance are mapped to a login. Azure SQL Database cannot
+	// resolve the login of a user outside of master: the known login name is kept, or else it is
+	// looked up in master.
+	switch {
+	case result.AuthenticationTypeDesc != "INSTANCE" && result.AuthenticationTypeDesc != "WINDOWS":
+		user.LoginName = ""
+	case result.LoginName.Valid:
+		user.LoginName = result.LoginName.String
+	case user.LoginName == "" && c.isAzureDatabase:
+		loginName, err := c.loginNameFromMaster(ctx, result.SID)
+		if isLoginError(err) {
+			// The credentials of the provider are often only valid in the user database.
+			tflog.Warn(ctx, "Cannot log in to master to read the login of the user, leaving it empty", map[string]interface{}{
+				"user":  result.Name,
+				"error": err.Error(),
+			})
+		} else if err != nil {
+			return nil, err
+		}
+		user.LoginName = loginName
+	}
+
 	return user, nil
 }
 
+// objectIDFromSID returns the Entra ID identifier held in the SID of an external principal, which is that
+// GUID in its binary form. It is the object id of users and groups, but the application (client) id of
+// service principals and managed identities, which SQL Server builds their SID from.
+// It returns an empty string when sid is not such a SID.
+func objectIDFromSID(sid string) string {
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(sid), "0x"))
+	if err != nil || len(raw) != 16 {
+		return ""
+	}
+
+	// The first three groups of a binary GUID are little-endian.
+	return fmt.Sprintf("%08x-%04x-%04x-%x-%x",
+		binary.LittleEndian.Uint32(raw[0:4]),
+		binary.LittleEndian.Uint16(raw[4:6]),
+		binary.LittleEndian.Uint16(raw[6:8]),
+		raw[8:10],
+		raw[10:16])
+}
+
+// loginNameFromMaster returns the name of the SQL login with the given SID, read from the master database
+// of an Azure SQL Database server. It returns an empty string when no SQL login has the SID.
+// The connector to master is kept by c, so that reading many users opens a single pool.
+func (c *Connector) loginNameFromMaster(ctx context.Context, sid string) (string, error) {
+	master := c.ForDatabase("master")
+
+	db, err := master.Connect()
+	if err != nil {
+		return "", fmt.Errorf("cannot read the login of the user from master: %w", err)
+	}
+
+	var name string
+	row := master.queryRowContext(ctx, db, "SELECT [name] FROM [sys].[sql_logins] WHERE [sid] = CONVERT(varbinary(85), @sid, 1)", sql.Named("sid", sid))
+	err = row.Scan(&name)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot read the login of the user from master: %w", err)
+	}
+
+	return name, nil
+}
+
+// loginErrors lists the SQL Server error numbers of a connection refused for its credentials or database.
+var loginErrors = map[int32]string{
+	4060:  "cannot open the database requested by the login",
+	18456: "login failed",
+}
+
+// isLoginError reports whether err is the refusal of the server to log in, as opposed to a transient
+// or permission failure once logged in.
+func isLoginError(err error) bool {
+	var sqlErr mssql.Error
+	if !errors.As(err, &sqlErr) {
+		return false
+	}
+
+	_, ok := loginErrors[sqlErr.Number]
+	return ok
+}
+
 // UpdateUser updates a user on the specified database.
 // It takes a context, a database connection, and a user model as input.
 // It returns an error if the user update fails, or nil if successful.
 func (c *Connector) UpdateUser(ctx context.Context, db *sql.DB, user *model.User) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	var err error
 
+	if user.LoginName != "" && user.Password != "" {
+		return errors.New("cannot update user. a user mapped to a login cannot have a password")
+	}
+
+	if user.OldPassword != "" && user.Password == "" {
+		return errors.New("cannot update user. an old password can only be given along with a new password")
+	}
+
 	// Get the original user
 	originalUser, err := c.GetUser(ctx, db, user)
 	if err != nil {
@@ -222,7 +370,8 @@ func (c *Connector) UpdateUser(ctx context.Context, db *sql.DB, user *model.User
 		query = query + " + 'DEFAULT_SCHEMA = ' + QuoteName(@defaultSchema) + ', '"
 	}
 
-	if !c.isAzureDatabase && user.DefaultLanguage != originalUser.DefaultLanguage {
+	// Only contained users have a default language.
+	if !c.isAzureDatabase && user.LoginName == "" && user.DefaultLanguage != originalUser.DefaultLanguage {
 		altered = true
 		// Set the default language to NONE if it's not specified.
 		if user.DefaultLanguage == "" {
@@ -232,9 +381,14 @@ func (c *Connector) UpdateUser(ctx context.Context, db *sql.DB, user *model.User
 		}
 	}
 
+	// SQL Server checks the old password, when given, before changing the password.
 	if user.Password != "" {
 		altered = true
-		query = query + " + 'PASSWORD = ' + QUOTENAME(@password, '''') + ', '"
+		query = query + " + 'PASSWORD = ' + QUOTENAME(@password, '''')"
+		if user.OldPassword != "" {
+			query = query + " + ' OLD_PASSWORD = ' + QUOTENAME(@oldPassword, '''')"
+		}
+		query = query + " + ', '"
 	}
 
 	if !altered {
@@ -247,11 +401,13 @@ func (c *Connector) UpdateUser(ctx context.Context, db *sql.DB, user *model.User
 	// The full TSQL script.
 	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
 
-	_, err = db.ExecContext(
+	_, err = c.execContext(
 		ctx,
+		db,
 		tsql,
 		sql.Named("name", user.Name),
 		sql.Named("password", user.Password),
+		sql.Named("oldPassword", user.OldPassword),
 		sql.Named("defaultSchema", user.DefaultSchema),
 		sql.Named("defaultLanguage", user.DefaultLanguage))
 
@@ -263,10 +419,43 @@ func (c *Connector) UpdateUser(ctx context.Context, db *sql.DB, user *model.User
 
 }
 
+// RenameUser renames a user on the specified database to newName.
+// The user keeps its principal id, SID, permissions, role memberships and owned schemas.
+// It returns an error if the rename fails, or nil if successful.
+func (c *Connector) RenameUser(ctx context.Context, db *sql.DB, user *model.User, newName string) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
+	if user.Name == "" || newName == "" {
+		return errors.New("cannot rename user. a user must have a name")
+	}
+
+	// Check if the database connection is nil.
+	if err := c.validateDatabaseConnection(ctx, db); err != nil {
+		return err
+	}
+
+	// SQL query to rename a user
+	query := "'ALTER USER ' + QUOTENAME(@name) + ' WITH NAME = ' + QUOTENAME(@newName)"
+
+	// The full TSQL script.
+	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
+
+	_, err := c.execContext(ctx, db, tsql, sql.Named("name", user.Name), sql.Named("newName", newName))
+	if err != nil {
+		return fmt.Errorf("cannot rename user. Underlying sql error : %w", err)
+	}
+
+	return nil
+}
+
 // DeleteUser deletes a user from the specified database.
 // It takes a context, a database connection, and a user model as input.
 // It returns an error if the user deletion fails, or nil if successful.
 func (c *Connector) DeleteUser(ctx context.Context, db *sql.DB, user *model.User) error {
+	ctx, cancel := c.withStatementTimeout(ctx)
+	defer cancel()
+
 	var err error
 
 	// Get the original user
@@ -290,8 +479,9 @@ func (c *Connector) DeleteUser(ctx context.Context, db *sql.DB, user *model.User
 	// The full TSQL script.
 	tsql := fmt.Sprintf("DECLARE @sql NVARCHAR(MAX)\nSET @sql = %s;\nEXEC (@sql)", query)
 
-	_, err = db.ExecContext(
+	_, err = c.execContext(
 		ctx,
+		db,
 		tsql,
 		sql.Named("name", user.Name),
 	)
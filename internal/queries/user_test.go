@@ -403,3 +403,175 @@ func TestConnector_DeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestConnector_CreateUser_ForLogin(t *testing.T) {
+	if !runLocalTests {
+		t.Skip("login-mapped users are tested on the local SQL Server")
+	}
+
+	connector := testConnectors.localSQL
+	ctx := context.Background()
+	db, err := connector.Connect()
+	if err != nil {
+		t.Fatalf("Unable to connect: %v", err)
+	}
+
+	// Create the server login the user is mapped to.
+	loginName := generateRandomString(10)
+	_, err = db.ExecContext(ctx, fmt.Sprintf("CREATE LOGIN [%s] WITH PASSWORD = '%s1aA!'", loginName, generateRandomString(16)))
+	if err != nil {
+		t.Fatalf("Unable to create the login: %v", err)
+	}
+	defer func() {
+		_, _ = db.ExecContext(ctx, fmt.Sprintf("DROP LOGIN [%s]", loginName))
+	}()
+
+	user := &model.User{
+		Name:      generateRandomString(10),
+		LoginName: loginName,
+	}
+
+	if err := connector.CreateUser(ctx, db, user); err != nil {
+		t.Fatalf("Connector.CreateUser() error = %v", err)
+	}
+	defer func() {
+		if err := connector.DeleteUser(ctx, db, user); err != nil {
+			t.Errorf("error during cleanup = %v", err)
+		}
+	}()
+
+	got, err := connector.GetUser(ctx, db, &model.User{Name: user.Name})
+	if err != nil {
+		t.Fatalf("Connector.GetUser() error = %v", err)
+	}
+	if got.LoginName != loginName {
+		t.Errorf("Connector.GetUser() LoginName = %v, want %v", got.LoginName, loginName)
+	}
+	if got.External {
+		t.Errorf("Connector.GetUser() External = %v, want false", got.External)
+	}
+}
+
+// TestConnector_GetUser_BySIDAndPrincipalID tests that a user is found by its SID or its principal id alone, as on import.
+func TestConnector_GetUser_BySIDAndPrincipalID(t *testing.T) {
+	connector := testConnectors.localSQL
+	ctx := context.Background()
+	db, err := connector.Connect()
+	if err != nil {
+		t.Fatalf("Unable to connect: %v", err)
+	}
+
+	user := &model.User{
+		Name:            generateRandomString(10),
+		Password:        fmt.Sprintf("%s1aA!", generateRandomString(16)),
+		DefaultSchema:   "dbo",
+		DefaultLanguage: "Français",
+	}
+
+	if err := connector.CreateUser(ctx, db, user); err != nil {
+		t.Fatalf("Connector.CreateUser() error = %v", err)
+	}
+	defer func() {
+		if err := connector.DeleteUser(ctx, db, user); err != nil {
+			t.Errorf("error during cleanup = %v", err)
+		}
+	}()
+
+	created, err := connector.GetUser(ctx, db, &model.User{Name: user.Name})
+	if err != nil {
+		t.Fatalf("Connector.GetUser() error = %v", err)
+	}
+
+	for _, lookup := range []*model.User{{SID: created.SID}, {PrincipalID: created.PrincipalID}} {
+		got, err := connector.GetUser(ctx, db, lookup)
+		if err != nil {
+			t.Fatalf("Connector.GetUser() error = %v", err)
+		}
+		if got.Name != user.Name || got.DefaultLanguage != created.DefaultLanguage || got.DefaultSchema != "dbo" {
+			t.Errorf("Connector.GetUser() = %+v, want %+v", got, created)
+		}
+	}
+
+	if _, err := connector.GetUser(ctx, db, &model.User{SID: "0x00"}); err == nil || err.Error() != "user not found" {
+		t.Errorf("Connector.GetUser() error = %v, want user not found", err)
+	}
+}
+
+// TestConnector_RenameUser tests that a renamed user keeps its principal id and is found by it under its new name.
+func TestConnector_RenameUser(t *testing.T) {
+	connector := testConnectors.localSQL
+	ctx := context.Background()
+	db, err := connector.Connect()
+	if err != nil {
+		t.Fatalf("Unable to connect: %v", err)
+	}
+
+	user := &model.User{
+		Name:     generateRandomString(10),
+		Password: fmt.Sprintf("%s1aA!", generateRandomString(16)),
+	}
+
+	if err := connector.CreateUser(ctx, db, user); err != nil {
+		t.Fatalf("Connector.CreateUser() error = %v", err)
+	}
+
+	created, err := connector.GetUser(ctx, db, &model.User{Name: user.Name})
+	if err != nil {
+		t.Fatalf("Connector.GetUser() error = %v", err)
+	}
+
+	newName := generateRandomString(10)
+	if err := connector.RenameUser(ctx, db, &model.User{Name: user.Name}, newName); err != nil {
+		t.Fatalf("Connector.RenameUser() error = %v", err)
+	}
+	defer func() {
+		if err := connector.DeleteUser(ctx, db, &model.User{Name: newName}); err != nil {
+			t.Errorf("error during cleanup = %v", err)
+		}
+	}()
+
+	got, err := connector.GetUser(ctx, db, &model.User{PrincipalID: created.PrincipalID, SID: created.SID})
+	if err != nil {
+		t.Fatalf("Connector.GetUser() error = %v", err)
+	}
+	if got.Name != newName || got.SID != created.SID {
+		t.Errorf("Connector.GetUser() = %+v, want name %v and SID %v", got, newName, created.SID)
+	}
+
+	if _, err := connector.GetUser(ctx, db, &model.User{Name: user.Name}); err == nil || err.Error() != "user not found" {
+		t.Errorf("Connector.GetUser() error = %v, want user not found", err)
+	}
+}
+
+// TestConnector_UpdateUser_OldPassword tests that the password of a contained user is changed when its old password is given.
+func TestConnector_UpdateUser_OldPassword(t *testing.T) {
+	connector := testConnectors.localSQL
+	ctx := context.Background()
+	db, err := connector.Connect()
+	if err != nil {
+		t.Fatalf("Unable to connect: %v", err)
+	}
+
+	user := &model.User{
+		Name:     generateRandomString(10),
+		Password: fmt.Sprintf("%s1aA!", generateRandomString(16)),
+	}
+
+	if err := connector.CreateUser(ctx, db, user); err != nil {
+		t.Fatalf("Connector.CreateUser() error = %v", err)
+	}
+	defer func() {
+		if err := connector.DeleteUser(ctx, db, &model.User{Name: user.Name}); err != nil {
+			t.Errorf("error during cleanup = %v", err)
+		}
+	}()
+
+	rotated := &model.User{
+		Name:        user.Name,
+		Password:    fmt.Sprintf("%s1aA!", generateRandomString(16)),
+		OldPassword: user.Password,
+	}
+	if err := connector.UpdateUser(ctx, db, rotated); err != nil {
+		t.Errorf("Connector.UpdateUser() error = %v", err)
+	}
+}
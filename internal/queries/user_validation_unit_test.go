@@ -5,8 +5,13 @@
 package queries
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"terraform-provider-mssqlpermissions/internal/queries/model"
 	"testing"
+
+	mssql "github.com/microsoft/go-mssqldb"
 )
 
 // ============================================================================
@@ -119,6 +124,60 @@ func TestValidateUser_Unit(t *testing.T) {
 			wantErr: true,
 			errMsg:  "an external user cannot have a password",
 		},
+		{
+			// The password policy is left to SQL Server, which only applies it when the server enforces one
+			name:      "contained_user_with_weak_password",
+			connector: localConnector,
+			user: &model.User{
+				Name:     "testuser",
+				Password: "password",
+			},
+			wantErr: false,
+		},
+
+		// Login-mapped user cases
+		{
+			name:      "valid_login_mapped_user",
+			connector: localConnector,
+			user: &model.User{
+				Name:      "testuser",
+				LoginName: "testlogin",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "login_mapped_user_with_password",
+			connector: localConnector,
+			user: &model.User{
+				Name:      "testuser",
+				LoginName: "testlogin",
+				Password:  "TestPassword123!",
+			},
+			wantErr: true,
+			errMsg:  "a user mapped to a login cannot have a password",
+		},
+		{
+			name:      "external_login_mapped_user",
+			connector: localConnector,
+			user: &model.User{
+				Name:      "testuser@domain.com",
+				LoginName: "testlogin",
+				External:  true,
+			},
+			wantErr: true,
+			errMsg:  "an external user cannot be mapped to a login",
+		},
+		{
+			name:      "login_mapped_user_with_default_language",
+			connector: localConnector,
+			user: &model.User{
+				Name:            "testuser",
+				LoginName:       "testlogin",
+				DefaultLanguage: "us_english",
+			},
+			wantErr: true,
+			errMsg:  "a user mapped to a login cannot have a default language",
+		},
 
 		// Error cases - ObjectID validation
 		{
@@ -222,3 +281,179 @@ func TestConnectorConfiguration_Unit(t *testing.T) {
 		})
 	}
 }
+
+// TestObjectIDFromSID_Unit tests the object ids read from the SID of external users
+func TestObjectIDFromSID_Unit(t *testing.T) {
+	tests := []struct {
+		name string
+		sid  string
+		want string
+	}{
+		{
+			name: "external_user",
+			sid:  "0xFF19966F868B11D0B42D00C04FC964FF",
+			want: "6f9619ff-8b86-d011-b42d-00c04fc964ff",
+		},
+		{
+			name: "sql_user",
+			sid:  "0x0105000000000009030000008F8C1F9B1A2BC94E8E2D7C1A8D2B2F3E",
+			want: "",
+		},
+		{
+			name: "not_hexadecimal",
+			sid:  "0xZZ19966F868B11D0B42D00C04FC964FF",
+			want: "",
+		},
+		{
+			name: "empty",
+			sid:  "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := objectIDFromSID(tt.sid); got != tt.want {
+				t.Errorf("objectIDFromSID(%q) = %q, want %q", tt.sid, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRename_Validation_Unit tests that renames are rejected before using the connection
+func TestRename_Validation_Unit(t *testing.T) {
+	connector := &Connector{}
+
+	tests := []struct {
+		name    string
+		oldName string
+		newName string
+		errMsg  string
+	}{
+		{
+			name:    "missing_name",
+			newName: "renamed",
+			errMsg:  "must have a name",
+		},
+		{
+			name:    "missing_new_name",
+			oldName: "original",
+			errMsg:  "must have a name",
+		},
+		{
+			name:    "nil_connection",
+			oldName: "original",
+			newName: "renamed",
+			errMsg:  "database connection is nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := connector.RenameUser(context.Background(), nil, &model.User{Name: tt.oldName}, tt.newName)
+			if err == nil || !contains(err.Error(), tt.errMsg) {
+				t.Errorf("RenameUser() error = %v, expected to contain %v", err, tt.errMsg)
+			}
+
+			err = connector.RenameDatabaseRole(context.Background(), nil, &model.Role{Name: tt.oldName}, tt.newName)
+			if err == nil || !contains(err.Error(), tt.errMsg) {
+				t.Errorf("RenameDatabaseRole() error = %v, expected to contain %v", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+// TestPrincipalLookup_Validation_Unit tests that users and roles are not looked up without a key
+func TestPrincipalLookup_Validation_Unit(t *testing.T) {
+	connector := &Connector{}
+
+	tests := []struct {
+		name        string
+		principalID int64
+		errMsg      string
+	}{
+		{
+			name:   "no_key",
+			errMsg: "a name, SID or principal id is required",
+		},
+		{
+			name:        "negative_principal_id",
+			principalID: -1,
+			errMsg:      "a name, SID or principal id is required",
+		},
+		{
+			name:        "principal_id",
+			principalID: 5,
+			errMsg:      "database connection is nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := connector.GetUser(context.Background(), nil, &model.User{PrincipalID: tt.principalID})
+			if err == nil || !contains(err.Error(), tt.errMsg) {
+				t.Errorf("GetUser() error = %v, expected to contain %v", err, tt.errMsg)
+			}
+
+			_, err = connector.GetDatabaseRole(context.Background(), nil, &model.Role{PrincipalID: tt.principalID})
+			if err == nil || !contains(err.Error(), tt.errMsg) {
+				t.Errorf("GetDatabaseRole() error = %v, expected to contain %v", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+// TestUpdateUser_Validation_Unit tests that UpdateUser rejects invalid password changes before using the connection
+func TestUpdateUser_Validation_Unit(t *testing.T) {
+	connector := &Connector{}
+
+	tests := []struct {
+		name   string
+		user   *model.User
+		errMsg string
+	}{
+		{
+			name:   "login_user_with_password",
+			user:   &model.User{Name: "testuser", LoginName: "testlogin", Password: "TestPassword123!"},
+			errMsg: "a user mapped to a login cannot have a password",
+		},
+		{
+			name:   "old_password_without_password",
+			user:   &model.User{Name: "testuser", OldPassword: "TestPassword123!"},
+			errMsg: "an old password can only be given along with a new password",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := connector.UpdateUser(context.Background(), nil, tt.user)
+			if err == nil || !contains(err.Error(), tt.errMsg) {
+				t.Errorf("UpdateUser() error = %v, expected to contain %v", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+// TestIsLoginError_Unit tests the errors after which the login of a user is left empty
+func TestIsLoginError_Unit(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "login_failed", err: fmt.Errorf("cannot read the login of the user from master: %w", mssql.Error{Number: 18456}), want: true},
+		{name: "cannot_open_database", err: mssql.Error{Number: 4060}, want: true},
+		{name: "permission_denied", err: mssql.Error{Number: 229}, want: false},
+		{name: "transient", err: mssql.Error{Number: 40613}, want: false},
+		{name: "not_sql", err: errors.New("connection reset"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isLoginError(tt.err); got != tt.want {
+				t.Errorf("isLoginError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"log"
 
 	"terraform-provider-mssqlpermissions/internal/provider"
+	"terraform-provider-mssqlpermissions/internal/queries"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
@@ -46,6 +47,11 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	// Release the database connection pools once Terraform has stopped the provider.
+	if closeErr := queries.CloseAll(); closeErr != nil {
+		log.Printf("error closing database connections: %s", closeErr)
+	}
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}